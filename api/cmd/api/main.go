@@ -3,13 +3,18 @@ package main
 import (
 	"context"
 	"log"
+	"math/rand"
+	"time"
 
 	_ "github.com/RinatHar/FarmFocus/api/docs" // важно: импорт сгенерированной документации
 	"github.com/RinatHar/FarmFocus/api/internal/config"
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/handler"
 	"github.com/RinatHar/FarmFocus/api/internal/middleware"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/RinatHar/FarmFocus/api/internal/repository"
 	"github.com/RinatHar/FarmFocus/api/internal/scheduler"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
@@ -76,13 +81,58 @@ func main() {
 	bedRepo := repository.NewBedRepo(dbpool)
 	userPlantRepo := repository.NewUserPlantRepo(dbpool)
 	goodRepo := repository.NewGoodRepo(dbpool)
+	levelRewardRepo := repository.NewLevelRewardRepo(dbpool)
+	droughtEventRepo := repository.NewDroughtEventRepo(dbpool)
+	plantHistoryRepo := repository.NewPlantHistoryRepo(dbpool)
+	itemRepo := repository.NewItemRepo(dbpool)
+	userItemRepo := repository.NewUserItemRepo(dbpool)
+	plantRevivalRepo := repository.NewPlantRevivalRepo(dbpool)
+	plantEffectRepo := repository.NewPlantEffectRepo(dbpool)
+	produceRepo := repository.NewProduceRepo(dbpool)
+	goldLedgerRepo := repository.NewGoldLedgerRepo(dbpool)
+	hybridRepo := repository.NewHybridRepo(dbpool)
+	decorationRepo := repository.NewDecorationRepo(dbpool)
+	adminAuditRepo := repository.NewAdminAuditRepo(dbpool)
+	achievementRepo := repository.NewAchievementRepo(dbpool)
+	questRepo := repository.NewQuestRepo(dbpool)
+	friendRepo := repository.NewFriendRepo(dbpool)
+	giftRepo := repository.NewGiftRepo(dbpool)
+	tradeRepo := repository.NewTradeRepo(dbpool)
+	leaderboardRepo := repository.NewLeaderboardRepo(dbpool)
+	guildRepo := repository.NewGuildRepo(dbpool)
+
+	// Инициализация сервисов
+	levelService := service.NewLevelService(seedRepo, levelRewardRepo)
+	droughtService := service.NewDroughtService(droughtEventRepo)
+	plantLifecycleService := service.NewPlantLifecycleService(userPlantRepo, cfg.WitheredPlantLifetimeDays)
+	itemService := service.NewItemService(itemRepo, plantEffectRepo, plantRevivalRepo)
+	marketService := service.NewMarketService(produceRepo, seedRepo)
+	seedDropService := service.NewSeedDropService(seedRepo, rand.New(rand.NewSource(time.Now().UnixNano())))
+	farmService := service.NewFarmService(bedRepo, userPlantRepo, decorationRepo)
+	breedingService := service.NewBreedingService(hybridRepo, bedRepo, seedRepo, rand.New(rand.NewSource(time.Now().UnixNano())))
+	adminService := service.NewAdminService(adminAuditRepo, levelService)
+	achievementService := service.NewAchievementService(achievementRepo)
+	friendService := service.NewFriendService(friendRepo, userRepo, userStatRepo, bedRepo)
+	questService := service.NewQuestService(questRepo, userStatRepo, levelService, rand.New(rand.NewSource(time.Now().UnixNano())))
+	rewardLimits := model.RewardLimits{
+		DailyXPSoftCap:          cfg.DailyXPSoftCap,
+		DailyGrowthSoftCap:      cfg.DailyGrowthSoftCap,
+		DiminishingReturnsAfter: cfg.DiminishingReturnsAfter,
+	}
+	giftService := service.NewGiftService(giftRepo, tradeRepo, seedRepo, service.GiftLimits{
+		DailySendLimit:    cfg.DailyGiftSendLimit,
+		DailyReceiveLimit: cfg.DailyGiftReceiveLimit,
+	})
+	leaderboardService := service.NewLeaderboardService(leaderboardRepo)
+	guildService := service.NewGuildService(guildRepo, levelService, rand.New(rand.NewSource(time.Now().UnixNano())))
 
 	// Создаем планировщики
 	droughtScheduler := scheduler.NewDroughtScheduler(
 		taskRepo,
 		habitRepo,
-		userPlantRepo,
 		userRepo,
+		userStatRepo,
+		droughtService,
 		"02:00",
 	)
 
@@ -95,13 +145,44 @@ func main() {
 	shopRefreshScheduler := scheduler.NewShopRefreshScheduler(
 		goodRepo,
 		userRepo,
+		itemRepo,
 		"02:00",
 	)
 
+	// Ежедневные задания выдаются после проверки засухи и сброса привычек
+	questScheduler := scheduler.NewQuestScheduler(
+		userRepo,
+		questService,
+		"02:10",
+	)
+
+	// Засохшие растения обрабатываются после проверки засухи
+	witheredPlantScheduler := scheduler.NewWitheredPlantScheduler(
+		userRepo,
+		plantLifecycleService,
+		"02:30",
+	)
+
+	// Снимки рейтингов пересчитываются в течение дня, а не только раз в сутки
+	leaderboardScheduler := scheduler.NewLeaderboardScheduler(
+		leaderboardService,
+		constants.LeaderboardRefreshInterval,
+	)
+
+	// Недельные цели гильдий выдаются вскоре после начала недели
+	guildGoalScheduler := scheduler.NewGuildGoalScheduler(
+		guildService,
+		"00:05",
+	)
+
 	// Запускаем планировщики
 	droughtScheduler.Start()
+	witheredPlantScheduler.Start()
 	habitResetScheduler.Start()
 	shopRefreshScheduler.Start()
+	questScheduler.Start()
+	leaderboardScheduler.Start()
+	guildGoalScheduler.Start()
 
 	// Инициализация всех хендлеров
 	userHandler := handler.NewUserHandler(
@@ -116,37 +197,62 @@ func main() {
 		userPlantRepo,
 		goodRepo,
 		progressLogRepo,
+		droughtService,
+		droughtEventRepo,
+		itemRepo,
+		userItemRepo,
+		plantRevivalRepo,
+		plantEffectRepo,
+		produceRepo,
+		decorationRepo,
 	)
 	userStatHandler := handler.NewUserStatHandler(userStatRepo)
-	taskHandler := handler.NewTaskHandler(taskRepo, progressLogRepo, userStatRepo, userPlantRepo)
-	habitHandler := handler.NewHabitHandler(habitRepo, progressLogRepo, userStatRepo, userPlantRepo)
+	taskHandler := handler.NewTaskHandler(taskRepo, progressLogRepo, userStatRepo, tagRepo, levelService, rewardLimits, achievementService, cfg.DifficultyRewards)
+	habitHandler := handler.NewHabitHandler(habitRepo, progressLogRepo, userStatRepo, tagRepo, levelService, rewardLimits, achievementService, cfg.DifficultyRewards)
 	tagHandler := handler.NewTagHandler(tagRepo, taskRepo, habitRepo)
 	seedHandler := handler.NewSeedHandler(seedRepo)
 	userSeedHandler := handler.NewUserSeedHandler(userSeedRepo)
-	bedHandler := handler.NewBedHandler(bedRepo)
+	bedHandler := handler.NewBedHandler(bedRepo, farmService)
 	userPlantHandler := handler.NewUserPlantHandler(
 		userPlantRepo,
-		userSeedRepo,
 		userStatRepo,
-		bedRepo,
 		seedRepo,
+		levelService,
+		plantHistoryRepo,
+		plantRevivalRepo,
+		itemService,
+		seedDropService,
+		breedingService,
+		achievementService,
 	)
 	goodHandler := handler.NewGoodHandler(
 		goodRepo,
-		userStatRepo,
-		userSeedRepo,
-		bedRepo,
 		seedRepo,
+		itemRepo,
 	)
+	itemHandler := handler.NewItemHandler(itemRepo, userItemRepo)
+	marketHandler := handler.NewMarketHandler(marketService, produceRepo, goldLedgerRepo)
+	hybridHandler := handler.NewHybridHandler(hybridRepo)
+	decorationHandler := handler.NewDecorationHandler(decorationRepo)
+	adminHandler := handler.NewAdminHandler(adminService, adminAuditRepo)
+	achievementHandler := handler.NewAchievementHandler(achievementService)
+	questHandler := handler.NewQuestHandler(questService)
+	friendHandler := handler.NewFriendHandler(friendRepo, friendService)
+	giftHandler := handler.NewGiftHandler(giftRepo, giftService)
+	tradeHandler := handler.NewTradeHandler(tradeRepo, giftService)
+	leaderboardHandler := handler.NewLeaderboardHandler(leaderboardService)
+	guildHandler := handler.NewGuildHandler(guildService)
 
 	// Routes
-	setupRoutes(e, userHandler, userStatHandler, taskHandler, habitHandler, tagHandler, seedHandler, userSeedHandler, bedHandler, userPlantHandler, goodHandler)
+	setupRoutes(e, middleware.AdminMiddleware(userRepo), middleware.RateLimitMiddleware(cfg.CompletionRateLimitPerMinute, time.Minute), userHandler, userStatHandler, taskHandler, habitHandler, tagHandler, seedHandler, userSeedHandler, bedHandler, userPlantHandler, goodHandler, itemHandler, marketHandler, hybridHandler, decorationHandler, adminHandler, achievementHandler, questHandler, friendHandler, giftHandler, tradeHandler, leaderboardHandler, guildHandler)
 
 	e.Logger.Fatal(e.Start(":" + cfg.Port))
 }
 
 func setupRoutes(
 	e *echo.Echo,
+	adminMiddleware echo.MiddlewareFunc,
+	completionLimiter echo.MiddlewareFunc,
 	userHandler *handler.UserHandler,
 	userStatHandler *handler.UserStatHandler,
 	taskHandler *handler.TaskHandler,
@@ -157,33 +263,38 @@ func setupRoutes(
 	bedHandler *handler.BedHandler,
 	userPlantHandler *handler.UserPlantHandler,
 	goodHandler *handler.GoodHandler,
+	itemHandler *handler.ItemHandler,
+	marketHandler *handler.MarketHandler,
+	hybridHandler *handler.HybridHandler,
+	decorationHandler *handler.DecorationHandler,
+	adminHandler *handler.AdminHandler,
+	achievementHandler *handler.AchievementHandler,
+	questHandler *handler.QuestHandler,
+	friendHandler *handler.FriendHandler,
+	giftHandler *handler.GiftHandler,
+	tradeHandler *handler.TradeHandler,
+	leaderboardHandler *handler.LeaderboardHandler,
+	guildHandler *handler.GuildHandler,
 ) {
 	// User routes
 	u := e.Group("/users")
 	u.GET("/me", userHandler.GetCurrentUser)
 	u.POST("", userHandler.CreateOrUpdateUser)
 	u.PUT("/me", userHandler.UpdateUser)
+	u.PUT("/me/privacy", userHandler.UpdatePrivacy)
 	u.GET("/sync", userHandler.SyncUserData)
 	u.POST("/recover-plants", userHandler.RecoverPlants)
+	u.GET("/drought-events", userHandler.GetDroughtEvents)
 
 	// UserStat routes
 	us := e.Group("/user-stats")
 	us.GET("", userStatHandler.GetUserStats)
 	us.GET("/level-info", userStatHandler.GetLevelInfo)
-	us.POST("/experience", userStatHandler.AddExperience)
-	us.POST("/gold", userStatHandler.AddGold)
-	us.POST("/streak/increment", userStatHandler.IncrementStreak)
-	us.POST("/streak/reset", userStatHandler.ResetStreak)
+	us.GET("/gold-ledger", marketHandler.GetGoldLedger)
 
 	goodGroup := e.Group("/goods")
 	goodGroup.GET("", goodHandler.GetUserGoods)
 	goodGroup.GET("/type/:type", goodHandler.GetUserGoodsByType)
-	goodGroup.POST("", goodHandler.CreateGood)
-	goodGroup.PUT("/:id/quantity", goodHandler.UpdateGoodQuantity)
-	goodGroup.PUT("/:id/cost", goodHandler.UpdateGoodCost)
-	goodGroup.DELETE("/:id", goodHandler.DeleteGood)
-	goodGroup.POST("/batch", goodHandler.CreateBatchGoods)
-	goodGroup.PATCH("/:id/add-quantity", goodHandler.AddQuantity)
 	goodGroup.POST("/:id/buy", goodHandler.BuyGood)
 
 	// Task routes
@@ -193,8 +304,8 @@ func setupRoutes(
 	taskGroup.GET("/:id", taskHandler.GetByID)
 	taskGroup.PUT("/:id", taskHandler.Update)
 	taskGroup.DELETE("/:id", taskHandler.Delete)
-	taskGroup.PATCH("/:id/done", taskHandler.MarkAsDone)
-	taskGroup.PATCH("/:id/undone", taskHandler.MarkAsUndone)
+	taskGroup.PATCH("/:id/done", taskHandler.MarkAsDone, completionLimiter)
+	taskGroup.PATCH("/:id/undone", taskHandler.MarkAsUndone, completionLimiter)
 
 	// Habit routes
 	habitGroup := e.Group("/habits")
@@ -203,10 +314,8 @@ func setupRoutes(
 	habitGroup.GET("/:id", habitHandler.GetByID)
 	habitGroup.PUT("/:id", habitHandler.Update)
 	habitGroup.DELETE("/:id", habitHandler.Delete)
-	habitGroup.PATCH("/:id/done", habitHandler.MarkAsDone)
-	habitGroup.PATCH("/:id/undone", habitHandler.MarkAsUndone)
-	habitGroup.PATCH("/:id/increment", habitHandler.IncrementCount)
-	habitGroup.PATCH("/:id/reset", habitHandler.ResetCount)
+	habitGroup.PATCH("/:id/done", habitHandler.MarkAsDone, completionLimiter)
+	habitGroup.PATCH("/:id/undone", habitHandler.MarkAsUndone, completionLimiter)
 
 	// Tag routes
 	tg := e.Group("/tags")
@@ -221,9 +330,6 @@ func setupRoutes(
 	s := e.Group("/seeds")
 	s.GET("", seedHandler.GetAll)
 	s.GET("/:id", seedHandler.GetByID)
-	s.POST("", seedHandler.Create)
-	s.PUT("/:id", seedHandler.Update)
-	s.DELETE("/:id", seedHandler.Delete)
 	s.GET("/level", seedHandler.GetByLevel)
 	s.GET("/rarity", seedHandler.GetByRarity)
 
@@ -232,8 +338,6 @@ func setupRoutes(
 	userSeeds.GET("", userSeedHandler.GetUserSeeds)
 	userSeeds.GET("/with-details", userSeedHandler.GetUserSeedsWithDetails)
 	userSeeds.GET("/available", userSeedHandler.GetAvailableSeeds)
-	userSeeds.POST("", userSeedHandler.AddSeed)
-	userSeeds.POST("/:seedId/add", userSeedHandler.AddQuantity)
 	userSeeds.POST("/:seedId/subtract", userSeedHandler.SubtractQuantity)
 	userSeeds.DELETE("/:seedId", userSeedHandler.DeleteUserSeed)
 	userSeeds.GET("/count", userSeedHandler.GetSeedCount)
@@ -243,23 +347,119 @@ func setupRoutes(
 	b.GET("", bedHandler.GetUserBeds)
 	b.GET("/:id", bedHandler.GetBedByID)
 	b.GET("/cell/:cellNumber", bedHandler.GetBedByCellNumber)
-	b.POST("", bedHandler.CreateBed)
-	b.POST("/:id/unlock", bedHandler.UnlockBed)
 	b.POST("/:id/lock", bedHandler.LockBed)
 	b.GET("/available", bedHandler.GetAvailableBeds)
 	b.GET("/empty", bedHandler.GetEmptyBeds)
 	b.GET("/with-plants", bedHandler.GetBedsWithPlants)
-	b.POST("/init", bedHandler.CreateInitialBeds)
+	b.GET("/layout", bedHandler.GetLayout)
+	b.POST("/expand", bedHandler.ExpandFarm)
 
 	// UserPlant routes
 	up := e.Group("/user-plants")
 	up.GET("", userPlantHandler.GetUserPlants)
 	up.GET("/:id", userPlantHandler.GetUserPlantByID)
 	up.POST("", userPlantHandler.CreateUserPlant)
-	up.POST("/:id/add-growth", userPlantHandler.AddGrowth)
 	up.POST("/:id/harvest", userPlantHandler.HarvestPlant)
 	up.DELETE("/:id", userPlantHandler.DeleteUserPlant)
 	up.GET("/with-details", userPlantHandler.GetPlantsWithDetails)
 	up.GET("/ready", userPlantHandler.GetReadyForHarvest)
 	up.GET("/growing", userPlantHandler.GetGrowingPlants)
+	up.GET("/history", userPlantHandler.GetPlantHistory)
+	up.GET("/revivals", userPlantHandler.GetPlantRevivals)
+	up.POST("/:id/revive", userPlantHandler.RevivePlant)
+	up.POST("/:id/apply", userPlantHandler.ApplyItem)
+
+	// Item routes
+	items := e.Group("/items")
+	items.GET("", itemHandler.GetAll)
+
+	userItems := e.Group("/user-items")
+	userItems.GET("", itemHandler.GetUserItems)
+
+	// Market routes
+	market := e.Group("/market")
+	market.GET("", marketHandler.GetPrices)
+	market.POST("/sell", marketHandler.SellProduce)
+
+	e.GET("/barn", marketHandler.GetBarn)
+
+	// Hybrid routes
+	hybrids := e.Group("/hybrids")
+	hybrids.GET("", hybridHandler.GetDiscoveries)
+	hybrids.GET("/recipes", hybridHandler.GetRecipes)
+
+	// Decoration routes
+	decorations := e.Group("/decorations")
+	decorations.GET("", decorationHandler.GetCatalog)
+	decorations.GET("/placed", decorationHandler.GetPlaced)
+	decorations.POST("/placed", decorationHandler.Place)
+	decorations.PUT("/placed/:id", decorationHandler.Move)
+	decorations.DELETE("/placed/:id", decorationHandler.Remove)
+
+	// Achievement routes
+	e.GET("/achievements", achievementHandler.GetAchievements)
+
+	// Quest routes
+	quests := e.Group("/quests")
+	quests.GET("", questHandler.GetToday)
+	quests.POST("/:id/claim", questHandler.Claim)
+
+	// Friend routes
+	friends := e.Group("/friends")
+	friends.GET("", friendHandler.GetFriends)
+	friends.GET("/requests", friendHandler.GetRequests)
+	friends.POST("/requests", friendHandler.SendRequest)
+	friends.POST("/requests/:id/accept", friendHandler.AcceptRequest)
+	friends.POST("/requests/:id/decline", friendHandler.DeclineRequest)
+	friends.DELETE("/:id", friendHandler.RemoveFriend)
+	friends.GET("/:id/farm", friendHandler.GetFriendFarm)
+
+	// Gift routes
+	gifts := e.Group("/gifts")
+	gifts.GET("", giftHandler.GetGifts)
+	gifts.POST("", giftHandler.SendGift)
+	gifts.POST("/:id/accept", giftHandler.AcceptGift)
+	gifts.POST("/:id/decline", giftHandler.DeclineGift)
+
+	// Trade routes
+	trades := e.Group("/trades")
+	trades.GET("", tradeHandler.GetTrades)
+	trades.POST("", tradeHandler.ProposeTrade)
+	trades.POST("/:id/accept", tradeHandler.AcceptTrade)
+	trades.POST("/:id/decline", tradeHandler.DeclineTrade)
+
+	// Leaderboard routes
+	e.GET("/leaderboards", leaderboardHandler.GetLeaderboard)
+
+	// Guild routes
+	guilds := e.Group("/guilds")
+	guilds.POST("", guildHandler.CreateGuild)
+	guilds.GET("/me", guildHandler.GetMyGuild)
+	guilds.POST("/leave", guildHandler.LeaveGuild)
+	guilds.POST("/contribute", guildHandler.Contribute)
+	guilds.GET("/:id", guildHandler.GetGuild)
+	guilds.POST("/:id/join", guildHandler.JoinGuild)
+
+	// Admin routes: изменения каталога и экономики доступны только администраторам
+	admin := e.Group("/admin", adminMiddleware)
+
+	adminSeeds := admin.Group("/seeds")
+	adminSeeds.POST("", seedHandler.Create)
+	adminSeeds.PUT("/:id", seedHandler.Update)
+	adminSeeds.DELETE("/:id", seedHandler.Delete)
+
+	adminGoods := admin.Group("/goods")
+	adminGoods.POST("", goodHandler.CreateGood)
+	adminGoods.PUT("/:id/quantity", goodHandler.UpdateGoodQuantity)
+	adminGoods.PUT("/:id/cost", goodHandler.UpdateGoodCost)
+	adminGoods.DELETE("/:id", goodHandler.DeleteGood)
+	adminGoods.POST("/batch", goodHandler.CreateBatchGoods)
+
+	// Ручные изменения прогресса пользователей записываются в журнал
+	adminUsers := admin.Group("/users")
+	adminUsers.POST("/:id/experience", adminHandler.AdjustExperience)
+	adminUsers.POST("/:id/gold", adminHandler.AdjustGold)
+	adminUsers.PUT("/:id/streak", adminHandler.SetStreak)
+
+	admin.GET("/audit-log", adminHandler.GetAuditLog)
 }
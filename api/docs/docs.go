@@ -24,14 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/beds": {
+        "/achievements": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список всех грядок текущего пользователя",
+                "description": "Возвращает все достижения с прогрессом текущего пользователя: для счетчиков progress — текущее значение, ограниченное порогом threshold; для событий — 0 или 1. Открытые достижения помечены unlocked и unlockedAt, награда за них выдается один раз при открытии",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,9 +39,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "beds"
+                    "achievements"
                 ],
-                "summary": "Получить все грядки пользователя",
+                "summary": "Получить достижения",
                 "parameters": [
                     {
                         "type": "string",
@@ -57,7 +57,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Bed"
+                                "$ref": "#/definitions/model.UserAchievement"
                             }
                         }
                     },
@@ -80,14 +80,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/audit-log": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новую грядку для текущего пользователя",
+                "description": "Возвращает ручные изменения прогресса пользователей, начиная с последних. Параметр userId фильтрует записи по пользователю",
                 "consumes": [
                     "application/json"
                 ],
@@ -95,9 +97,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "beds"
+                    "admin"
                 ],
-                "summary": "Создать новую грядку",
+                "summary": "Журнал действий администраторов",
                 "parameters": [
                     {
                         "type": "string",
@@ -107,20 +109,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для создания грядки",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.BedCreateRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (по умолчанию 50, максимум 200)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Bed"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AdminAuditEntry"
+                            }
                         }
                     },
                     "400": {
@@ -141,6 +149,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -153,14 +170,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/beds/available": {
-            "get": {
+        "/admin/goods": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список разблокированных грядок пользователя",
+                "description": "Создает новый товар для пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -168,9 +185,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "beds"
+                    "goods"
                 ],
-                "summary": "Получить доступные грядки",
+                "summary": "Создать товар",
                 "parameters": [
                     {
                         "type": "string",
@@ -178,15 +195,30 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Данные товара",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateGoodRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Bed"
+                            "$ref": "#/definitions/model.Good"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -199,6 +231,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -211,14 +252,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/beds/cell/{cellNumber}": {
-            "get": {
+        "/admin/goods/batch": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает грядку по номеру ячейки для текущего пользователя",
+                "description": "Создает несколько товаров для пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -226,9 +267,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "beds"
+                    "goods"
                 ],
-                "summary": "Получить грядку по номеру ячейки",
+                "summary": "Создать несколько товаров",
                 "parameters": [
                     {
                         "type": "string",
@@ -238,18 +279,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Cell Number",
-                        "name": "cellNumber",
-                        "in": "path",
-                        "required": true
+                        "description": "Массив товаров",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.CreateGoodRequest"
+                            }
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Bed"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Good"
+                            }
                         }
                     },
                     "400": {
@@ -270,8 +319,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -291,14 +340,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/beds/empty": {
-            "get": {
+        "/admin/goods/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список пустых грядок пользователя",
+                "description": "Удаляет товар по ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -306,9 +355,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "beds"
+                    "goods"
                 ],
-                "summary": "Получить пустые грядки",
+                "summary": "Удалить товар",
                 "parameters": [
                     {
                         "type": "string",
@@ -316,15 +365,25 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Good ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Bed"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -337,6 +396,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -349,14 +426,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/beds/init": {
-            "post": {
+        "/admin/goods/{id}/cost": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает начальный набор грядок для пользователя",
+                "description": "Обновляет цену товара по ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -364,9 +441,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "beds"
+                    "goods"
                 ],
-                "summary": "Создать начальные грядки",
+                "summary": "Обновить цену товара",
                 "parameters": [
                     {
                         "type": "string",
@@ -376,12 +453,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для создания начальных грядок",
+                        "type": "integer",
+                        "description": "Good ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая цена",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.BedInitialRequest"
+                            "$ref": "#/definitions/handler.UpdateCostRequest"
                         }
                     }
                 ],
@@ -389,10 +473,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.Good"
                         }
                     },
                     "400": {
@@ -413,57 +494,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/beds/with-plants": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Возвращает грядки с информацией о посаженных растениях",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "beds"
-                ],
-                "summary": "Получить грядки с растениями",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "X-User-ID",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.BedWithUserPlant"
-                            }
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -483,14 +524,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/beds/{id}": {
-            "get": {
+        "/admin/goods/{id}/quantity": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает грядку по указанному ID",
+                "description": "Обновляет количество товара по ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -498,9 +539,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "beds"
+                    "goods"
                 ],
-                "summary": "Получить грядку по ID",
+                "summary": "Обновить количество товара",
                 "parameters": [
                     {
                         "type": "string",
@@ -511,17 +552,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Bed ID",
+                        "description": "Good ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Новое количество",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateQuantityRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Bed"
+                            "$ref": "#/definitions/model.Good"
                         }
                     },
                     "400": {
@@ -572,14 +622,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/beds/{id}/lock": {
+        "/admin/seeds": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Блокирует указанную грядку",
+                "description": "Создает новое семя (только для администраторов). stageThresholds — проценты роста, с которых начинаются стадии роста: первая 0, далее по возрастанию до 100 (по умолчанию [0, 34, 67, 100])",
                 "consumes": [
                     "application/json"
                 ],
@@ -587,9 +637,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "beds"
+                    "seeds"
                 ],
-                "summary": "Заблокировать грядку",
+                "summary": "Создать новое семя",
                 "parameters": [
                     {
                         "type": "string",
@@ -599,21 +649,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Bed ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные семени",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Seed"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.Seed"
                         }
                     },
                     "400": {
@@ -643,15 +692,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -664,14 +704,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/beds/{id}/unlock": {
-            "post": {
+        "/admin/seeds/{id}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Разблокирует указанную грядку",
+                "description": "Обновляет информацию о семени (только для администраторов), включая пороги стадий роста stageThresholds",
                 "consumes": [
                     "application/json"
                 ],
@@ -679,9 +719,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "beds"
+                    "seeds"
                 ],
-                "summary": "Разблокировать грядку",
+                "summary": "Обновить семя",
                 "parameters": [
                     {
                         "type": "string",
@@ -692,20 +732,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Bed ID",
+                        "description": "Seed ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Обновленные данные семени",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Seed"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.Seed"
                         }
                     },
                     "400": {
@@ -754,16 +800,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/goods": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список всех товаров текущего пользователя",
+                "description": "Удаляет семя из системы (только для администраторов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -771,9 +815,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "seeds"
                 ],
-                "summary": "Получить товары пользователя",
+                "summary": "Удалить семя",
                 "parameters": [
                     {
                         "type": "string",
@@ -781,15 +825,25 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seed ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Good"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -802,6 +856,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -812,14 +884,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/users/{id}/experience": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новый товар для пользователя",
+                "description": "Изменяет опыт пользователя на amount (опыт не опускается ниже нуля). При повышении уровня выдаются награды за уровень. Изменение записывается в журнал действий администраторов с указанием причины",
                 "consumes": [
                     "application/json"
                 ],
@@ -827,9 +901,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "admin"
                 ],
-                "summary": "Создать товар",
+                "summary": "Изменить опыт пользователя",
                 "parameters": [
                     {
                         "type": "string",
@@ -839,20 +913,27 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные товара",
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменение опыта и причина",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateGoodRequest"
+                            "$ref": "#/definitions/handler.AdminAdjustRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Good"
+                            "$ref": "#/definitions/model.AdminOverrideResult"
                         }
                     },
                     "400": {
@@ -873,6 +954,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -885,14 +984,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/goods/batch": {
+        "/admin/users/{id}/gold": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает несколько товаров для пользователя",
+                "description": "Изменяет золото пользователя на amount (баланс не опускается ниже нуля). Изменение записывается в журнал золота с источником admin_override и в журнал действий администраторов с указанием причины",
                 "consumes": [
                     "application/json"
                 ],
@@ -900,9 +999,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "admin"
                 ],
-                "summary": "Создать несколько товаров",
+                "summary": "Изменить золото пользователя",
                 "parameters": [
                     {
                         "type": "string",
@@ -912,26 +1011,27 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Массив товаров",
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменение золота и причина",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handler.CreateGoodRequest"
-                            }
+                            "$ref": "#/definitions/handler.AdminAdjustRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Good"
-                            }
+                            "$ref": "#/definitions/model.AdminOverrideResult"
                         }
                     },
                     "400": {
@@ -952,6 +1052,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -964,14 +1082,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/goods/type/{type}": {
-            "get": {
+        "/admin/users/{id}/streak": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает товары указанного типа для текущего пользователя",
+                "description": "Устанавливает текущий стрик пользователя; рекорд стрика не уменьшается. Изменение записывается в журнал действий администраторов с указанием причины",
                 "consumes": [
                     "application/json"
                 ],
@@ -979,9 +1097,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "admin"
                 ],
-                "summary": "Получить товары пользователя по типу",
+                "summary": "Установить стрик пользователя",
                 "parameters": [
                     {
                         "type": "string",
@@ -991,21 +1109,27 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Good Type (seed/bed/tool/fertilizer)",
-                        "name": "type",
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Новый стрик и причина",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AdminSetStreakRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Good"
-                            }
+                            "$ref": "#/definitions/model.AdminOverrideResult"
                         }
                     },
                     "400": {
@@ -1026,6 +1150,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1038,14 +1180,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/goods/{id}": {
-            "delete": {
+        "/barn": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет товар по ID",
+                "description": "Возвращает урожай, собранный пользователем и еще не проданный",
                 "consumes": [
                     "application/json"
                 ],
@@ -1053,9 +1195,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "market"
                 ],
-                "summary": "Удалить товар",
+                "summary": "Амбар",
                 "parameters": [
                     {
                         "type": "string",
@@ -1063,25 +1205,15 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Good ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Produce"
                             }
                         }
                     },
@@ -1094,15 +1226,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1115,14 +1238,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/goods/{id}/add-quantity": {
-            "patch": {
+        "/beds": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавляет указанное количество к существующему товару",
+                "description": "Возвращает список всех грядок текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -1130,9 +1253,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "beds"
                 ],
-                "summary": "Добавить количество товара",
+                "summary": "Получить все грядки пользователя",
                 "parameters": [
                     {
                         "type": "string",
@@ -1140,33 +1263,20 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Good ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Количество для добавления",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.AddQuantityRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Good"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Bed"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1174,17 +1284,57 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/beds/available": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает список разблокированных грядок пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "beds"
+                ],
+                "summary": "Получить доступные грядки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Bed"
+                            }
+                        }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1204,14 +1354,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/goods/{id}/buy": {
-            "post": {
+        "/beds/cell/{cellNumber}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Покупает один товар из магазина, списывая золото и добавляя товар в инвентарь",
+                "description": "Возвращает грядку по номеру ячейки для текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -1219,9 +1369,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "beds"
                 ],
-                "summary": "Купить товар",
+                "summary": "Получить грядку по номеру ячейки",
                 "parameters": [
                     {
                         "type": "string",
@@ -1232,8 +1382,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Good ID",
-                        "name": "id",
+                        "description": "Cell Number",
+                        "name": "cellNumber",
                         "in": "path",
                         "required": true
                     }
@@ -1242,7 +1392,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.BuyGoodResponse"
+                            "$ref": "#/definitions/model.Bed"
                         }
                     },
                     "400": {
@@ -1263,15 +1413,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1293,14 +1434,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/goods/{id}/cost": {
-            "put": {
+        "/beds/empty": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет цену товара по ID",
+                "description": "Возвращает список пустых грядок пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -1308,9 +1449,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "beds"
                 ],
-                "summary": "Обновить цену товара",
+                "summary": "Получить пустые грядки",
                 "parameters": [
                     {
                         "type": "string",
@@ -1318,37 +1459,15 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Good ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Новая цена",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UpdateCostRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Good"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Bed"
                             }
                         }
                     },
@@ -1361,15 +1480,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1382,14 +1492,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/goods/{id}/quantity": {
-            "put": {
+        "/beds/expand": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет количество товара по ID",
+                "description": "Покупает расширение поля за золото: добавляет ряд закрытых грядок снизу (direction=row) или столбец справа (direction=column). Каждое следующее расширение дороже; размер поля ограничен",
                 "consumes": [
                     "application/json"
                 ],
@@ -1397,9 +1507,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goods"
+                    "beds"
                 ],
-                "summary": "Обновить количество товара",
+                "summary": "Расширить поле",
                 "parameters": [
                     {
                         "type": "string",
@@ -1409,19 +1519,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Good ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Новое количество",
+                        "description": "Направление расширения",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateQuantityRequest"
+                            "$ref": "#/definitions/handler.FarmExpandRequest"
                         }
                     }
                 ],
@@ -1429,7 +1532,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Good"
+                            "$ref": "#/definitions/model.FarmExpansion"
                         }
                     },
                     "400": {
@@ -1450,15 +1553,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1471,14 +1565,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/habits": {
+        "/beds/layout": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список всех привычек текущего пользователя",
+                "description": "Возвращает поле пользователя в виде сетки width x height: все грядки с координатами, включая закрытые и пустые, с посаженными растениями, а также стоимость следующего расширения",
                 "consumes": [
                     "application/json"
                 ],
@@ -1486,9 +1580,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "habits"
+                    "beds"
                 ],
-                "summary": "Получить все привычки пользователя",
+                "summary": "Сетка поля",
                 "parameters": [
                     {
                         "type": "string",
@@ -1496,34 +1590,13 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Фильтр по статусу выполнения",
-                        "name": "done",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Фильтр по периоду (day/week/month)",
-                        "name": "period",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Фильтр по ID тега",
-                        "name": "tag_id",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Habit"
-                            }
+                            "$ref": "#/definitions/model.FarmLayout"
                         }
                     },
                     "401": {
@@ -1545,14 +1618,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/beds/with-plants": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новую привычку для текущего пользователя с базовым количеством опыта с учетом count",
+                "description": "Возвращает грядки с информацией о посаженных растениях",
                 "consumes": [
                     "application/json"
                 ],
@@ -1560,9 +1635,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "habits"
+                    "beds"
                 ],
-                "summary": "Создать новую привычку",
+                "summary": "Получить грядки с растениями",
                 "parameters": [
                     {
                         "type": "string",
@@ -1570,30 +1645,15 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Данные для создания привычки",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.HabitCreateRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Habit"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.BedWithUserPlant"
                             }
                         }
                     },
@@ -1618,14 +1678,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/habits/{id}": {
+        "/beds/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает привычку по указанному ID",
+                "description": "Возвращает грядку по указанному ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1633,9 +1693,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "habits"
+                    "beds"
                 ],
-                "summary": "Получить привычку по ID",
+                "summary": "Получить грядку по ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -1646,7 +1706,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Habit ID",
+                        "description": "Bed ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1656,7 +1716,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Habit"
+                            "$ref": "#/definitions/model.Bed"
                         }
                     },
                     "400": {
@@ -1677,6 +1737,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1696,14 +1765,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/beds/{id}/lock": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет информацию о привычке",
+                "description": "Блокирует указанную грядку",
                 "consumes": [
                     "application/json"
                 ],
@@ -1711,9 +1782,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "habits"
+                    "beds"
                 ],
-                "summary": "Обновить привычку",
+                "summary": "Заблокировать грядку",
                 "parameters": [
                     {
                         "type": "string",
@@ -1724,26 +1795,20 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Habit ID",
+                        "description": "Bed ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Обновленные данные привычки",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.HabitUpdateRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Habit"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -1764,6 +1829,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1783,14 +1857,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/decorations": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет привычку по ID, предварительно удаляя связанные записи в логе прогресса",
+                "description": "Возвращает декорации, которые можно купить и поставить на поле. Некоторые дают пассивный бонус соседним грядкам (bonusType: growth_boost или harvest_yield)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1798,9 +1874,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "habits"
+                    "decorations"
                 ],
-                "summary": "Удалить привычку",
+                "summary": "Каталог декораций",
                 "parameters": [
                     {
                         "type": "string",
@@ -1808,39 +1884,69 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Habit ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Decoration"
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/decorations/placed": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает декорации, поставленные текущим пользователем на поле, с координатами",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "decorations"
+                ],
+                "summary": "Декорации на поле",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PlacedDecoration"
+                            }
+                        }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1858,16 +1964,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/habits/{id}/done": {
-            "patch": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Помечает привычку как выполненную, увеличивает счетчик, добавляет опыт пользователю по формуле, создает запись в логе прогресса, увеличивает рост всех активных растений пользователя на 1 и увеличивает стрик если это первое выполнение сегодня",
+                "description": "Покупает декорацию за золото и ставит ее на свободную клетку поля (x, y): на грядке не должно быть растения и другой декорации",
                 "consumes": [
                     "application/json"
                 ],
@@ -1875,9 +1979,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "habits"
+                    "decorations"
                 ],
-                "summary": "Пометить привычку как выполненную",
+                "summary": "Поставить декорацию",
                 "parameters": [
                     {
                         "type": "string",
@@ -1887,18 +1991,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Habit ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Декорация и координаты",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PlaceDecorationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.HabitCompletionResponse"
+                            "$ref": "#/definitions/model.DecorationChange"
                         }
                     },
                     "400": {
@@ -1940,14 +2046,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/habits/{id}/increment": {
-            "patch": {
+        "/decorations/placed/{id}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Увеличивает счетчик привычки на 1",
+                "description": "Переносит поставленную декорацию на другую свободную клетку поля (x, y)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1955,9 +2061,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "habits"
+                    "decorations"
                 ],
-                "summary": "Увеличить счетчик привычки",
+                "summary": "Переместить декорацию",
                 "parameters": [
                     {
                         "type": "string",
@@ -1968,17 +2074,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Habit ID",
+                        "description": "Placed decoration ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Новые координаты",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.MoveDecorationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.HabitIncrementResponse"
+                            "$ref": "#/definitions/model.DecorationChange"
                         }
                     },
                     "400": {
@@ -2018,16 +2133,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/habits/{id}/reset": {
-            "patch": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Сбрасывает счетчик привычки до 0",
+                "description": "Убирает декорацию с поля и возвращает половину ее стоимости",
                 "consumes": [
                     "application/json"
                 ],
@@ -2035,9 +2148,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "habits"
+                    "decorations"
                 ],
-                "summary": "Сбросить счетчик привычки",
+                "summary": "Убрать декорацию",
                 "parameters": [
                     {
                         "type": "string",
@@ -2048,7 +2161,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Habit ID",
+                        "description": "Placed decoration ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2058,7 +2171,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.HabitResetResponse"
+                            "$ref": "#/definitions/model.DecorationChange"
                         }
                     },
                     "400": {
@@ -2100,14 +2213,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/habits/{id}/undone": {
-            "patch": {
+        "/friends": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Помечает привычку как невыполненную, уменьшает счетчик и возвращает опыт обратно на основе последней записи в логе",
+                "description": "Возвращает друзей текущего пользователя с их уровнем",
                 "consumes": [
                     "application/json"
                 ],
@@ -2115,9 +2228,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "habits"
+                    "friends"
                 ],
-                "summary": "Пометить привычку как невыполненную",
+                "summary": "Получить список друзей",
                 "parameters": [
                     {
                         "type": "string",
@@ -2125,28 +2238,15 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Habit ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.HabitUndoResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Friend"
                             }
                         }
                     },
@@ -2159,15 +2259,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2180,14 +2271,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/seeds": {
+        "/friends/requests": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает полный список всех доступных семян",
+                "description": "Возвращает входящие и исходящие заявки в друзья, ожидающие ответа",
                 "consumes": [
                     "application/json"
                 ],
@@ -2195,9 +2286,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "seeds"
+                    "friends"
                 ],
-                "summary": "Получить все семена",
+                "summary": "Получить заявки в друзья",
                 "parameters": [
                     {
                         "type": "string",
@@ -2211,9 +2302,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Seed"
+                            "$ref": "#/definitions/model.FriendRequests"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -2234,7 +2331,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новое семя (только для администраторов)",
+                "description": "Отправляет заявку в друзья пользователю по его Max ID. Если этот пользователь уже отправил встречную заявку, пользователи сразу становятся друзьями (accepted = true). Повторная заявка или заявка существующему другу — 409",
                 "consumes": [
                     "application/json"
                 ],
@@ -2242,9 +2339,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "seeds"
+                    "friends"
                 ],
-                "summary": "Создать новое семя",
+                "summary": "Отправить заявку в друзья",
                 "parameters": [
                     {
                         "type": "string",
@@ -2254,20 +2351,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные семени",
+                        "description": "Max ID пользователя",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Seed"
+                            "$ref": "#/definitions/handler.FriendRequestCreateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Seed"
+                            "$ref": "#/definitions/handler.FriendRequestResponse"
                         }
                     },
                     "400": {
@@ -2288,6 +2385,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2300,14 +2415,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/seeds/level": {
-            "get": {
+        "/friends/requests/{id}/accept": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список семян определенного уровня",
+                "description": "Принимает входящую заявку в друзья",
                 "consumes": [
                     "application/json"
                 ],
@@ -2315,37 +2430,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "seeds"
+                    "friends"
                 ],
-                "summary": "Получить семена по уровню",
+                "summary": "Принять заявку в друзья",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Уровень семян",
-                        "name": "level",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "User ID",
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Seed"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2365,14 +2492,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/seeds/rarity": {
-            "get": {
+        "/friends/requests/{id}/decline": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список семян определенной редкости",
+                "description": "Отклоняет входящую или отменяет исходящую заявку в друзья",
                 "consumes": [
                     "application/json"
                 ],
@@ -2380,34 +2507,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "seeds"
+                    "friends"
                 ],
-                "summary": "Получить семена по редкости",
+                "summary": "Отклонить заявку в друзья",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Редкость семян (common, rare, epic, legendary)",
-                        "name": "rarity",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "User ID",
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Seed"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2418,61 +2539,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/seeds/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Возвращает информацию о конкретном семени по его ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "seeds"
-                ],
-                "summary": "Получить семя по ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Seed ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "X-User-ID",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.Seed"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2499,14 +2567,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/friends/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет информацию о семени (только для администраторов)",
+                "description": "Удаляет пользователя из друзей",
                 "consumes": [
                     "application/json"
                 ],
@@ -2514,9 +2584,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "seeds"
+                    "friends"
                 ],
-                "summary": "Обновить семя",
+                "summary": "Удалить из друзей",
                 "parameters": [
                     {
                         "type": "string",
@@ -2527,27 +2597,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Seed ID",
+                        "description": "Friend user ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Обновленные данные семени",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Seed"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.Seed"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2586,14 +2644,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/friends/{id}/farm": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет семя из системы (только для администраторов)",
+                "description": "Возвращает ферму друга только для просмотра с учетом его настройки приватности: при full — уровень, статистику, грядки и растения; при level_only — только уровень и статистику; при hidden — 403",
                 "consumes": [
                     "application/json"
                 ],
@@ -2601,9 +2661,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "seeds"
+                    "friends"
                 ],
-                "summary": "Удалить семя",
+                "summary": "Посмотреть ферму друга",
                 "parameters": [
                     {
                         "type": "string",
@@ -2614,15 +2674,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Seed ID",
+                        "description": "Friend user ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FriendFarm"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2642,6 +2705,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2663,14 +2735,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tags": {
+        "/gifts": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список всех тегов текущего пользователя",
+                "description": "Возвращает входящие и исходящие подарки, ожидающие ответа",
                 "consumes": [
                     "application/json"
                 ],
@@ -2678,9 +2750,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "gifts"
                 ],
-                "summary": "Получить все теги пользователя",
+                "summary": "Получить подарки",
                 "parameters": [
                     {
                         "type": "string",
@@ -2694,10 +2766,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Tag"
-                            }
+                            "$ref": "#/definitions/model.Gifts"
                         }
                     },
                     "401": {
@@ -2726,7 +2795,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новый тег для текущего пользователя",
+                "description": "Дарит другу семена (kind = seed) или урожай из амбара (kind = produce). Подарок сразу списывается у отправителя и возвращается ему при отклонении или отмене. Дарить можно только друзьям; число подарков и предложений обмена за день ограничено для отправителя и получателя (429)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2734,9 +2803,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "gifts"
                 ],
-                "summary": "Создать новый тег",
+                "summary": "Отправить подарок",
                 "parameters": [
                     {
                         "type": "string",
@@ -2746,20 +2815,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для создания тега",
+                        "description": "Данные подарка",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.TagCreateRequest"
+                            "$ref": "#/definitions/handler.GiftCreateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Tag"
+                            "$ref": "#/definitions/model.Gift"
                         }
                     },
                     "400": {
@@ -2780,57 +2849,35 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/tags/with-count": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Возвращает теги с информацией о количестве привязанных задач",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "tags"
-                ],
-                "summary": "Получить теги с количеством задач",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "X-User-ID",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.TagWithCount"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2850,14 +2897,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tags/{id}": {
-            "get": {
+        "/gifts/{id}/accept": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает тег по указанному ID",
+                "description": "Принимает входящий подарок и добавляет семена или урожай в инвентарь",
                 "consumes": [
                     "application/json"
                 ],
@@ -2865,9 +2912,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "gifts"
                 ],
-                "summary": "Получить тег по ID",
+                "summary": "Принять подарок",
                 "parameters": [
                     {
                         "type": "string",
@@ -2878,7 +2925,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Tag ID",
+                        "description": "Gift ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2888,7 +2935,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Tag"
+                            "$ref": "#/definitions/model.Gift"
                         }
                     },
                     "400": {
@@ -2909,15 +2956,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2937,14 +2975,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/gifts/{id}/decline": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет информацию о теге",
+                "description": "Отклоняет входящий или отменяет исходящий подарок; подарок возвращается отправителю",
                 "consumes": [
                     "application/json"
                 ],
@@ -2952,9 +2992,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "gifts"
                 ],
-                "summary": "Обновить тег",
+                "summary": "Отклонить подарок",
                 "parameters": [
                     {
                         "type": "string",
@@ -2965,26 +3005,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Tag ID",
+                        "description": "Gift ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Обновленные данные тега",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.TagUpdateRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Tag"
+                            "$ref": "#/definitions/model.Gift"
                         }
                     },
                     "400": {
@@ -3005,15 +3036,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -3033,14 +3055,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/goods": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет тег и сбрасывает его у всех связанных задач и привычек",
+                "description": "Возвращает список всех товаров текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -3048,9 +3072,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tags"
+                    "goods"
                 ],
-                "summary": "Удалить тег",
+                "summary": "Получить товары пользователя",
                 "parameters": [
                     {
                         "type": "string",
@@ -3058,25 +3082,15 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Tag ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Good"
                             }
                         }
                     },
@@ -3089,33 +3103,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3128,14 +3115,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks": {
+        "/goods/type/{type}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список всех задач текущего пользователя",
+                "description": "Возвращает товары указанного типа для текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -3143,9 +3130,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "goods"
                 ],
-                "summary": "Получить все задачи пользователя",
+                "summary": "Получить товары пользователя по типу",
                 "parameters": [
                     {
                         "type": "string",
@@ -3154,23 +3141,12 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
-                    {
-                        "type": "boolean",
-                        "description": "Фильтр по статусу выполнения",
-                        "name": "done",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Фильтр по дате (формат: 2006-01-02)",
-                        "name": "date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Фильтр по ID тега",
-                        "name": "tag_id",
-                        "in": "query"
+                        "description": "Good Type (seed/bed/tool/fertilizer)",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -3179,7 +3155,16 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Task"
+                                "$ref": "#/definitions/model.Good"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -3202,14 +3187,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/goods/{id}/buy": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новую задачу для текущего пользователя с базовым количеством опыта",
+                "description": "Покупает один товар из магазина, списывая золото и добавляя товар в инвентарь",
                 "consumes": [
                     "application/json"
                 ],
@@ -3217,9 +3204,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "goods"
                 ],
-                "summary": "Создать новую задачу",
+                "summary": "Купить товар",
                 "parameters": [
                     {
                         "type": "string",
@@ -3229,20 +3216,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для создания задачи",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.TaskCreateRequest"
-                        }
+                        "type": "integer",
+                        "description": "Good ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Task"
+                            "$ref": "#/definitions/handler.BuyGoodResponse"
                         }
                     },
                     "400": {
@@ -3263,6 +3248,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3275,14 +3278,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{id}": {
-            "get": {
+        "/guilds": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает задачу по указанному ID",
+                "description": "Создает гильдию, владельцем которой становится текущий пользователь, и выдает ей цель на текущую неделю. Пользователь может состоять только в одной гильдии (409); название должно быть уникальным (409)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3290,9 +3293,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "guilds"
                 ],
-                "summary": "Получить задачу по ID",
+                "summary": "Создать гильдию",
                 "parameters": [
                     {
                         "type": "string",
@@ -3302,18 +3305,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Task ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Название гильдии",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.GuildCreateRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Task"
+                            "$ref": "#/definitions/model.GuildInfo"
                         }
                     },
                     "400": {
@@ -3334,8 +3339,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3353,14 +3358,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/guilds/contribute": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет информацию о задаче",
+                "description": "Сдает урожай из амбара в недельную цель гильдии. Принимается не больше, чем осталось до цели (amount в ответе). Вносить вклад могут только участники, вступившие в гильдию до выдачи цели текущей недели; вступившие позже вносят вклад со следующей недели (409). Когда цель выполнена, награду вместе с наградами за достигнутые уровни получает каждый такой участник, внесший не меньше 10% цели; при повышении уровня текущего пользователя возвращает блок levelUp. Нехватка урожая или уже выполненная цель — 409",
                 "consumes": [
                     "application/json"
                 ],
@@ -3368,9 +3375,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "guilds"
                 ],
-                "summary": "Обновить задачу",
+                "summary": "Внести вклад в цель гильдии",
                 "parameters": [
                     {
                         "type": "string",
@@ -3380,19 +3387,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Task ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Обновленные данные задачи",
+                        "description": "Количество урожая",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.TaskUpdateRequest"
+                            "$ref": "#/definitions/handler.GuildContributeRequest"
                         }
                     }
                 ],
@@ -3400,7 +3400,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Task"
+                            "$ref": "#/definitions/model.GuildContribution"
                         }
                     },
                     "400": {
@@ -3430,6 +3430,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3440,14 +3449,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/guilds/leave": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет задачу по ID, предварительно удаляя связанные записи в логе прогресса",
+                "description": "Удаляет текущего пользователя из гильдии. Если уходит владелец, владельцем становится участник, вступивший раньше остальных; гильдия без участников удаляется. Вклад ушедшего участника остается в цели, но награду за нее он не получит",
                 "consumes": [
                     "application/json"
                 ],
@@ -3455,9 +3466,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "guilds"
                 ],
-                "summary": "Удалить задачу",
+                "summary": "Покинуть гильдию",
                 "parameters": [
                     {
                         "type": "string",
@@ -3465,28 +3476,12 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Task ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "204": {
                         "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -3517,14 +3512,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{id}/done": {
-            "patch": {
+        "/guilds/me": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Помечает задачу как выполненную, добавляет опыт пользователю по формуле, создает запись в логе прогресса, увеличивает рост всех активных растений пользователя на 1 и увеличивает стрик если это первое выполнение сегодня",
+                "description": "Возвращает гильдию текущего пользователя с участниками, их вкладом и целью текущей недели",
                 "consumes": [
                     "application/json"
                 ],
@@ -3532,9 +3527,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "guilds"
                 ],
-                "summary": "Пометить задачу как выполненную",
+                "summary": "Получить свою гильдию",
                 "parameters": [
                     {
                         "type": "string",
@@ -3542,29 +3537,13 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Task ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.TaskCompletionResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.GuildInfo"
                         }
                     },
                     "401": {
@@ -3597,14 +3576,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{id}/undone": {
-            "patch": {
+        "/guilds/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Помечает задачу как невыполненную и возвращает опыт обратно на основе последней записи в логе",
+                "description": "Возвращает гильдию с участниками, их вкладом и целью текущей недели",
                 "consumes": [
                     "application/json"
                 ],
@@ -3612,9 +3591,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "guilds"
                 ],
-                "summary": "Пометить задачу как невыполненную",
+                "summary": "Получить гильдию",
                 "parameters": [
                     {
                         "type": "string",
@@ -3625,7 +3604,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Task ID",
+                        "description": "Guild ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3635,7 +3614,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.TaskUndoResponse"
+                            "$ref": "#/definitions/model.GuildInfo"
                         }
                     },
                     "400": {
@@ -3677,14 +3656,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-plants": {
-            "get": {
+        "/guilds/{id}/join": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список всех растений текущего пользователя",
+                "description": "Добавляет текущего пользователя в гильдию. Нельзя вступить, если пользователь уже состоит в гильдии или гильдия заполнена (409). Вклад в цель текущей недели новый участник может вносить только со следующей недели",
                 "consumes": [
                     "application/json"
                 ],
@@ -3692,9 +3671,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-plants"
+                    "guilds"
                 ],
-                "summary": "Получить все растения пользователя",
+                "summary": "Вступить в гильдию",
                 "parameters": [
                     {
                         "type": "string",
@@ -3702,15 +3681,28 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Guild ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.UserPlant"
+                            "$ref": "#/definitions/model.GuildInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -3723,6 +3715,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3733,14 +3743,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/habits": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Сажает новое растение на свободную грядку по cellNumber, используя семя из инвентаря",
+                "description": "Возвращает список всех привычек текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -3748,9 +3760,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-plants"
+                    "habits"
                 ],
-                "summary": "Посадить новое растение",
+                "summary": "Получить все привычки пользователя",
                 "parameters": [
                     {
                         "type": "string",
@@ -3760,20 +3772,48 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Данные для посадки растения",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UserPlantCreateRequest"
-                        }
+                        "type": "boolean",
+                        "description": "Фильтр по статусу выполнения",
+                        "name": "done",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по периоду (day/week/month)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по ID тега (устаревший, используйте tag_ids)",
+                        "name": "tag_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по списку ID тегов через запятую (например: 1,2,3)",
+                        "name": "tag_ids",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Режим фильтра по тегам: any (хотя бы один тег, по умолчанию) или all (все теги)",
+                        "name": "tag_match",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.IPlant"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Habit"
+                            }
                         }
                     },
                     "400": {
@@ -3794,24 +3834,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3822,16 +3844,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user-plants/growing": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает растения, которые еще не достигли максимального роста",
+                "description": "Создает новую привычку для текущего пользователя с базовым количеством опыта по сложности; счетчик начинается с 0",
                 "consumes": [
                     "application/json"
                 ],
@@ -3839,9 +3859,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-plants"
+                    "habits"
                 ],
-                "summary": "Получить растущие растения",
+                "summary": "Создать новую привычку",
                 "parameters": [
                     {
                         "type": "string",
@@ -3849,15 +3869,30 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Данные для создания привычки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.HabitCreateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.UserPlantWithSeed"
+                            "$ref": "#/definitions/model.Habit"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -3882,14 +3917,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-plants/ready": {
+        "/habits/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает растения, которые достигли максимального роста и готовы к сбору",
+                "description": "Возвращает привычку по указанному ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -3897,9 +3932,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-plants"
+                    "habits"
                 ],
-                "summary": "Получить растения готовые к сбору",
+                "summary": "Получить привычку по ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -3907,20 +3942,24 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Habit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.UserPlantWithSeed"
-                            }
+                            "$ref": "#/definitions/model.Habit"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3928,57 +3967,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/user-plants/with-details": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Возвращает растения с подробной информацией о семенах",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "user-plants"
-                ],
-                "summary": "Получить растения с деталями",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "X-User-ID",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.UserPlantWithSeed"
-                            }
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -3996,16 +3995,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user-plants/{id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает растение по указанному ID",
+                "description": "Обновляет информацию о привычке. Отметка выполнения и счетчик меняются только выполнением и его отменой; базовый опыт пересчитывается по сложности и счетчику",
                 "consumes": [
                     "application/json"
                 ],
@@ -4013,9 +4010,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-plants"
+                    "habits"
                 ],
-                "summary": "Получить растение по ID",
+                "summary": "Обновить привычку",
                 "parameters": [
                     {
                         "type": "string",
@@ -4026,17 +4023,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Plant ID",
+                        "description": "Habit ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Обновленные данные привычки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.HabitUpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserPlant"
+                            "$ref": "#/definitions/model.Habit"
                         }
                     },
                     "400": {
@@ -4057,15 +4063,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4092,7 +4089,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет растение (например, если оно погибло)",
+                "description": "Удаляет привычку по ID. История ее выполнений сохраняется и продолжает учитываться в дневных ограничениях наград, стрике и рейтингах",
                 "consumes": [
                     "application/json"
                 ],
@@ -4100,9 +4097,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-plants"
+                    "habits"
                 ],
-                "summary": "Удалить растение",
+                "summary": "Удалить привычку",
                 "parameters": [
                     {
                         "type": "string",
@@ -4113,7 +4110,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Plant ID",
+                        "description": "Habit ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4141,15 +4138,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4171,14 +4159,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-plants/{id}/add-growth": {
-            "post": {
+        "/habits/{id}/done": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Увеличивает текущий рост растения на указанное количество",
+                "description": "Помечает привычку как выполненную, увеличивает счетчик, начисляет опыт и золото по таблице наград сложности, создает запись в логе прогресса, увеличивает рост всех активных растений пользователя на очки роста сложности с учетом множителя роста семени и засухи, увеличивает стрик (каждые 7 дней подряд выдается лейка) и ослабляет засуху на одну ступень если это первое выполнение сегодня и возвращает блок levelUp при повышении уровня. После нескольких выполнений за день награды убывают, а опыт и очки роста сверх мягкого дневного лимита начисляются частично — в этом случае возвращается блок rewardCap. Открытые выполнением достижения возвращаются в achievements (награды за них уже выданы), выполненные ежедневные задания — в completedQuests. Отмечать выполнение и отмену можно ограниченное число раз в минуту (429)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4186,9 +4174,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-plants"
+                    "habits"
                 ],
-                "summary": "Добавить рост растению",
+                "summary": "Пометить привычку как выполненную",
                 "parameters": [
                     {
                         "type": "string",
@@ -4199,26 +4187,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Plant ID",
+                        "description": "Habit ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Количество роста для добавления",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UserPlantGrowthRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UserPlantGrowthResponse"
+                            "$ref": "#/definitions/handler.HabitCompletionResponse"
                         }
                     },
                     "400": {
@@ -4239,8 +4218,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4248,8 +4227,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4269,14 +4248,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-plants/{id}/harvest": {
-            "post": {
+        "/habits/{id}/undone": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Собирает готовое к сбору растение и начисляет награды",
+                "description": "Помечает привычку как невыполненную, уменьшает счетчик и в одной транзакции откатывает все эффекты последнего выполнения: опыт, золото, рост растений, увеличение стрика с наградой за стрик, ослабление засухи (если засуха с тех пор не менялась) и прогресс еще не полученных ежедневных заданий. Повторная отмена возвращает 400. Выполнение, выросшее растение которого уже собрано, не отменяется (400): урожай и опыт за сбор уже получены. Также не отменяется выполнение, засчитанное в ежедневное задание, награда за которое уже получена (400): награда за задание обратно не забирается",
                 "consumes": [
                     "application/json"
                 ],
@@ -4284,9 +4263,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-plants"
+                    "habits"
                 ],
-                "summary": "Собрать растение",
+                "summary": "Пометить привычку как невыполненную",
                 "parameters": [
                     {
                         "type": "string",
@@ -4297,7 +4276,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Plant ID",
+                        "description": "Habit ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4307,7 +4286,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserPlantHarvestResult"
+                            "$ref": "#/definitions/handler.HabitUndoResponse"
                         }
                     },
                     "400": {
@@ -4328,8 +4307,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4337,8 +4316,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4358,14 +4337,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-seeds": {
+        "/hybrids": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список семян в инвентаре пользователя",
+                "description": "Возвращает гибридные семена, которые пользователь вывел скрещиванием, с количеством выведений",
                 "consumes": [
                     "application/json"
                 ],
@@ -4373,9 +4352,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-seeds"
+                    "hybrids"
                 ],
-                "summary": "Получить семена пользователя",
+                "summary": "Открытые гибриды",
                 "parameters": [
                     {
                         "type": "string",
@@ -4391,7 +4370,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.UserSeed"
+                                "$ref": "#/definitions/model.HybridDiscovery"
                             }
                         }
                     },
@@ -4414,14 +4393,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/hybrids/recipes": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавляет новые семена или увеличивает количество существующих семян в инвентаре",
+                "description": "Возвращает рецепты скрещивания: пары семян, которые на соседних грядках могут дать гибрид при сборе зрелого растения. Результат рецепта (resultSeed) виден только после открытия гибрида",
                 "consumes": [
                     "application/json"
                 ],
@@ -4429,9 +4410,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-seeds"
+                    "hybrids"
                 ],
-                "summary": "Добавить семена пользователю",
+                "summary": "Рецепты скрещивания",
                 "parameters": [
                     {
                         "type": "string",
@@ -4439,30 +4420,15 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Данные для добавления семян",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UserSeedAddRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserSeed"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.HybridRecipeInfo"
                             }
                         }
                     },
@@ -4487,14 +4453,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-seeds/available": {
+        "/items": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает все существующие семена с информацией о количестве у пользователя",
+                "description": "Возвращает справочник предметов (например, лейка для оживления засохших растений)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4502,9 +4468,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-seeds"
+                    "items"
                 ],
-                "summary": "Получить доступные семена",
+                "summary": "Получить все предметы",
                 "parameters": [
                     {
                         "type": "string",
@@ -4512,12 +4478,6 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Фильтр по уровню семян",
-                        "name": "level",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -4526,16 +4486,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.AvailableSeed"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                                "$ref": "#/definitions/model.Item"
                             }
                         }
                     },
@@ -4551,14 +4502,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-seeds/count": {
+        "/leaderboards": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает количество различных типов семян в инвентаре пользователя",
+                "description": "Возвращает рейтинг за недельный сезон (с понедельника по воскресенье) по метрике: weekly_xp — опыт за сезон (выполнения, сбор урожая, задания и цели гильдии), longest_streak — самая длинная серия дней подряд с выполнениями внутри сезона, total_harvests — растения, собранные за сезон. Область global — все пользователи, friends — друзья и сам пользователь с местами внутри этого круга. Рейтинг строится по снимку, который пересчитывается планировщиком каждые 15 минут; время снимка — computedAt. Параметр season (любая дата сезона) позволяет посмотреть итоги прошлых сезонов",
                 "consumes": [
                     "application/json"
                 ],
@@ -4566,9 +4517,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-seeds"
+                    "leaderboards"
                 ],
-                "summary": "Получить количество уникальных семян",
+                "summary": "Получить рейтинг",
                 "parameters": [
                     {
                         "type": "string",
@@ -4576,13 +4527,42 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "weekly_xp",
+                        "description": "Метрика: weekly_xp, longest_streak, total_harvests",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "global",
+                        "description": "Область: global, friends",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата внутри сезона (YYYY-MM-DD), по умолчанию текущий сезон",
+                        "name": "season",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UserSeedCountResponse"
+                            "$ref": "#/definitions/model.Leaderboard"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
@@ -4606,14 +4586,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-seeds/with-details": {
+        "/market": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает семена пользователя с подробной информацией о каждом типе семян",
+                "description": "Возвращает сегодняшние цены на урожай всех семян, вчерашние цены, изменение в процентах и количество урожая в амбаре. Цены меняются раз в день и одинаковы для всех пользователей",
                 "consumes": [
                     "application/json"
                 ],
@@ -4621,9 +4601,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-seeds"
+                    "market"
                 ],
-                "summary": "Получить семена пользователя с деталями",
+                "summary": "Цены на рынке",
                 "parameters": [
                     {
                         "type": "string",
@@ -4639,7 +4619,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.UserSeedWithDetails"
+                                "$ref": "#/definitions/model.MarketPrice"
                             }
                         }
                     },
@@ -4664,14 +4644,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-seeds/{seedId}": {
-            "delete": {
+        "/market/sell": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Полностью удаляет семена из инвентаря пользователя",
+                "description": "Продает урожай из амбара по сегодняшней цене. Золото (дробная часть отбрасывается) начисляется на баланс и записывается в журнал золота",
                 "consumes": [
                     "application/json"
                 ],
@@ -4679,9 +4659,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-seeds"
+                    "market"
                 ],
-                "summary": "Удалить семена из инвентаря",
+                "summary": "Продать урожай",
                 "parameters": [
                     {
                         "type": "string",
@@ -4691,16 +4671,21 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Seed ID",
-                        "name": "seedId",
-                        "in": "path",
-                        "required": true
+                        "description": "Семя и количество урожая",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.SellProduceRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MarketSale"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4741,14 +4726,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-seeds/{seedId}/add": {
-            "post": {
+        "/quests": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Увеличивает количество существующих семян в инвентаре",
+                "description": "Возвращает ежедневные задания пользователя на сегодня с прогрессом. Задания выдаются планировщиком раз в день по шаблонам, доступным на уровне пользователя; если задания на сегодня еще не выданы, они выдаются при первом запросе. Прогресс засчитывается при выполнении задач и привычек и при сборе урожая, отмена выполнения откатывает прогресс еще не полученного задания",
                 "consumes": [
                     "application/json"
                 ],
@@ -4756,9 +4741,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-seeds"
+                    "quests"
                 ],
-                "summary": "Добавить количество семян",
+                "summary": "Получить ежедневные задания",
                 "parameters": [
                     {
                         "type": "string",
@@ -4766,34 +4751,15 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Seed ID",
-                        "name": "seedId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Количество для добавления",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UserSeedQuantityRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserQuest"
                             }
                         }
                     },
@@ -4806,15 +4772,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4827,14 +4784,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-seeds/{seedId}/subtract": {
+        "/quests/{id}/claim": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Уменьшает количество семян в инвентаре (например, при посадке)",
+                "description": "Выдает награду за выполненное ежедневное задание: золото, опыт и предмет. Награду можно получить один раз (повторный запрос — 409), за невыполненное задание — 400. Награды за достигнутые уровни выдаются в той же транзакции, при повышении уровня возвращается блок levelUp. Награда обратно не забирается, поэтому выполнения задач и привычек, засчитанные в задание, после получения награды нельзя отменить",
                 "consumes": [
                     "application/json"
                 ],
@@ -4842,9 +4799,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-seeds"
+                    "quests"
                 ],
-                "summary": "Уменьшить количество семян",
+                "summary": "Получить награду за задание",
                 "parameters": [
                     {
                         "type": "string",
@@ -4855,24 +4812,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Seed ID",
-                        "name": "seedId",
+                        "description": "Quest ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Количество для вычитания",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UserSeedQuantityRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.QuestClaim"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4901,6 +4852,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4913,14 +4873,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-stats": {
+        "/seeds": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает статистику пользователя. Если статистика не существует - создает новую.",
+                "description": "Возвращает полный список всех доступных семян",
                 "consumes": [
                     "application/json"
                 ],
@@ -4928,9 +4888,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-stats"
+                    "seeds"
                 ],
-                "summary": "Получить статистику пользователя",
+                "summary": "Получить все семена",
                 "parameters": [
                     {
                         "type": "string",
@@ -4944,15 +4904,9 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserStat"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Seed"
                             }
                         }
                     },
@@ -4968,14 +4922,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-stats/experience": {
-            "post": {
+        "/seeds/level": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Увеличивает количество опыта пользователя на указанное значение",
+                "description": "Возвращает список семян определенного уровня",
                 "consumes": [
                     "application/json"
                 ],
@@ -4983,32 +4937,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-stats"
+                    "seeds"
                 ],
-                "summary": "Добавить опыт пользователю",
+                "summary": "Получить семена по уровню",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уровень семян",
+                        "name": "level",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "User ID",
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Количество опыта для добавления",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UserStatAmountRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UserStatMessageResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Seed"
+                            }
                         }
                     },
                     "400": {
@@ -5020,15 +4975,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5041,14 +4987,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-stats/gold": {
-            "post": {
+        "/seeds/rarity": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Увеличивает количество золота пользователя на указанное значение",
+                "description": "Возвращает список семян определенной редкости",
                 "consumes": [
                     "application/json"
                 ],
@@ -5056,32 +5002,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-stats"
+                    "seeds"
                 ],
-                "summary": "Добавить золото пользователю",
+                "summary": "Получить семена по редкости",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "X-User-ID",
-                        "in": "header",
+                        "description": "Редкость семян (common, rare, epic, legendary)",
+                        "name": "rarity",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Количество золота для добавления",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UserStatAmountRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UserStatMessageResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Seed"
+                            }
                         }
                     },
                     "400": {
@@ -5093,15 +5040,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5114,14 +5052,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-stats/level-info": {
+        "/seeds/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает текущий уровень, опыт и прогресс до следующего уровня",
+                "description": "Возвращает информацию о конкретном семени по его ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -5129,10 +5067,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-stats"
+                    "seeds"
                 ],
-                "summary": "Получить информацию об уровне",
+                "summary": "Получить семя по ID",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Seed ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "User ID",
@@ -5145,11 +5090,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.LevelInfoResponse"
+                            "$ref": "#/definitions/model.Seed"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -5178,14 +5123,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-stats/streak/increment": {
-            "post": {
+        "/tags": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Увеличивает ежедневный стрик пользователя на 1",
+                "description": "Возвращает список всех тегов текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -5193,9 +5138,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-stats"
+                    "tags"
                 ],
-                "summary": "Увеличить стрик пользователя",
+                "summary": "Получить все теги пользователя",
                 "parameters": [
                     {
                         "type": "string",
@@ -5209,7 +5154,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UserStatMessageResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Tag"
+                            }
                         }
                     },
                     "401": {
@@ -5231,16 +5179,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user-stats/streak/reset": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Сбрасывает ежедневный стрик пользователя до 0",
+                "description": "Создает новый тег для текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -5248,9 +5194,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user-stats"
+                    "tags"
                 ],
-                "summary": "Сбросить стрик пользователя",
+                "summary": "Создать новый тег",
                 "parameters": [
                     {
                         "type": "string",
@@ -5258,13 +5204,31 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Данные для создания тега",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TagCreateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UserStatMessageResponse"
+                            "$ref": "#/definitions/model.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
@@ -5288,9 +5252,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
-            "post": {
-                "description": "Создает нового пользователя или обновляет существующего по MaxID. При создании также создается статистика, начальные грядки, добавляется стартовый набор семян и товары в магазин.",
+        "/tags/with-count": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает теги с информацией о количестве привязанных задач, привычек и их сумме",
                 "consumes": [
                     "application/json"
                 ],
@@ -5298,35 +5267,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "tags"
                 ],
-                "summary": "Создать или обновить пользователя",
+                "summary": "Получить теги с количеством задач и привычек",
                 "parameters": [
                     {
-                        "description": "Данные пользователя",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UserCreateRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Пользователь обновлен",
-                        "schema": {
-                            "$ref": "#/definitions/model.User"
-                        }
-                    },
-                    "201": {
-                        "description": "Пользователь создан",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TagWithCount"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -5346,14 +5310,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me": {
+        "/tags/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает информацию о текущем аутентифицированном пользователе",
+                "description": "Возвращает тег по указанному ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -5361,9 +5325,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "tags"
                 ],
-                "summary": "Получить текущего пользователя",
+                "summary": "Получить тег по ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -5371,13 +5335,29 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
@@ -5389,6 +5369,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -5415,7 +5404,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет информацию о текущем пользователе (например, имя пользователя)",
+                "description": "Обновляет информацию о теге",
                 "consumes": [
                     "application/json"
                 ],
@@ -5423,9 +5412,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "tags"
                 ],
-                "summary": "Обновить данные пользователя",
+                "summary": "Обновить тег",
                 "parameters": [
                     {
                         "type": "string",
@@ -5435,12 +5424,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Обновленные данные пользователя",
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Обновленные данные тега",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UserUpdateRequest"
+                            "$ref": "#/definitions/handler.TagUpdateRequest"
                         }
                     }
                 ],
@@ -5448,7 +5444,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.Tag"
                         }
                     },
                     "400": {
@@ -5469,6 +5465,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -5488,16 +5493,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/recover-plants": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Восстанавливает засохшие растения после выполнения ежедневного задания",
+                "description": "Удаляет тег и сбрасывает его у всех связанных задач и привычек",
                 "consumes": [
                     "application/json"
                 ],
@@ -5505,9 +5508,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "tags"
                 ],
-                "summary": "Восстановить засохшие растения",
+                "summary": "Удалить тег",
                 "parameters": [
                     {
                         "type": "string",
@@ -5515,11 +5518,21 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -5527,8 +5540,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -5536,8 +5549,26 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -5557,14 +5588,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/sync": {
+        "/tasks": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает все данные пользователя для синхронизации в формате ServerFarmData",
+                "description": "Возвращает список всех задач текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -5572,9 +5603,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "tasks"
                 ],
-                "summary": "Синхронизировать все данные пользователя",
+                "summary": "Получить все задачи пользователя",
                 "parameters": [
                     {
                         "type": "string",
@@ -5582,20 +5613,217 @@ const docTemplate = `{
                         "name": "X-User-ID",
                         "in": "header",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ServerFarmData"
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
+                    {
+                        "type": "boolean",
+                        "description": "Фильтр по статусу выполнения",
+                        "name": "done",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по дате (формат: 2006-01-02)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по ID тега (устаревший, используйте tag_ids)",
+                        "name": "tag_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по списку ID тегов через запятую (например: 1,2,3)",
+                        "name": "tag_ids",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Режим фильтра по тегам: any (хотя бы один тег, по умолчанию) или all (все теги)",
+                        "name": "tag_match",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает новую задачу для текущего пользователя с базовым количеством опыта",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Создать новую задачу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для создания задачи",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TaskCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает задачу по указанному ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Получить задачу по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
                                 "type": "string"
                             }
                         }
@@ -5610,667 +5838,5074 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Обновляет информацию о задаче. Отметка выполнения меняется только выполнением и его отменой; базовый опыт пересчитывается по сложности",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Обновить задачу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Обновленные данные задачи",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TaskUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет задачу по ID. История ее выполнений сохраняется и продолжает учитываться в дневных ограничениях наград, стрике и рейтингах",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Удалить задачу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/done": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Помечает задачу как выполненную, начисляет опыт и золото по таблице наград сложности, создает запись в логе прогресса, увеличивает рост всех активных растений пользователя на очки роста сложности с учетом множителя роста семени и засухи, увеличивает стрик (каждые 7 дней подряд выдается лейка) и ослабляет засуху на одну ступень если это первое выполнение сегодня и возвращает блок levelUp при повышении уровня. После нескольких выполнений за день награды убывают, а опыт и очки роста сверх мягкого дневного лимита начисляются частично — в этом случае возвращается блок rewardCap. Открытые выполнением достижения возвращаются в achievements (награды за них уже выданы), выполненные ежедневные задания — в completedQuests. Отмечать выполнение и отмену можно ограниченное число раз в минуту (429)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Пометить задачу как выполненную",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TaskCompletionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/undone": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Помечает задачу как невыполненную и в одной транзакции откатывает все эффекты последнего выполнения: опыт, золото, рост растений, увеличение стрика с наградой за стрик, ослабление засухи (если засуха с тех пор не менялась) и прогресс еще не полученных ежедневных заданий. Повторная отмена возвращает 400. Выполнение, выросшее растение которого уже собрано, не отменяется (400): урожай и опыт за сбор уже получены. Также не отменяется выполнение, засчитанное в ежедневное задание, награда за которое уже получена (400): награда за задание обратно не забирается",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Пометить задачу как невыполненную",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TaskUndoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/trades": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает входящие и исходящие предложения обмена, ожидающие ответа",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trades"
+                ],
+                "summary": "Получить предложения обмена",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TradeOffers"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Предлагает другу обмен: позиции offer отдает автор, позиции request — получатель. Ничего не списывается до принятия. Обмен возможен только с друзьями и учитывается в дневных лимитах подарков (429)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trades"
+                ],
+                "summary": "Предложить обмен",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные обмена",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TradeCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.TradeOffer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Принимает входящее предложение обмена: обе стороны обмениваются позициями атомарно. Если у кого-то не хватает семян или урожая, обмен не выполняется (409), а предложение остается ожидающим ответа",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trades"
+                ],
+                "summary": "Принять обмен",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Trade offer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TradeOffer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/{id}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отклоняет входящее или отменяет исходящее предложение обмена",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trades"
+                ],
+                "summary": "Отклонить обмен",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Trade offer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TradeOffer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-items": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает предметы, которые есть у текущего пользователя, с их количеством",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Получить инвентарь предметов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserItemWithDetails"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-plants": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает список всех растений текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-plants"
+                ],
+                "summary": "Получить все растения пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserPlant"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Сажает новое растение на свободную открытую грядку с координатами (x, y), используя семя из инвентаря. Координаты должны быть в пределах поля (см. GET /beds/layout)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-plants"
+                ],
+                "summary": "Посадить новое растение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для посадки растения",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserPlantCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.IPlant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-plants/growing": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает растения, которые еще не достигли максимального роста",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-plants"
+                ],
+                "summary": "Получить растущие растения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserPlantWithSeed"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-plants/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает историю растений пользователя, убранных с грядок: собранные, погибшие (засохли или погибли от засухи) и удаленные, начиная с последних",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-plants"
+                ],
+                "summary": "История растений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип события: harvested, died или removed",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (по умолчанию 50, максимум 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PlantHistory"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-plants/ready": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает растения, которые достигли максимального роста и готовы к сбору",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-plants"
+                ],
+                "summary": "Получить растения готовые к сбору",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserPlantWithSeed"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-plants/revivals": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает журнал оживления засохших растений пользователя (лейкой или массовым восстановлением), начиная с последних",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-plants"
+                ],
+                "summary": "Журнал оживления растений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (по умолчанию 50, максимум 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PlantRevival"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-plants/with-details": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает растения с подробной информацией о семенах",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-plants"
+                ],
+                "summary": "Получить растения с деталями",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserPlantWithSeed"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-plants/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает растение по указанному ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-plants"
+                ],
+                "summary": "Получить растение по ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Plant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserPlant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет растение с грядки и записывает удаление в историю растений",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-plants"
+                ],
+                "summary": "Удалить растение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Plant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-plants/{id}/apply": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Применяет предмет из инвентаря к растению и списывает его. Удобрение сразу добавляет очки роста, стимулятор роста временно увеличивает множитель роста, секатор увеличивает урожай при сборе, лейка оживляет засохшее растение. Эффекты одного предмета складываются до maxStacks и действуют durationHours часов (или до сбора, если длительность не задана)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-plants"
+                ],
+                "summary": "Применить предмет к растению",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Plant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Предмет (itemId или itemCode)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ApplyItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ItemApplyResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-plants/{id}/harvest": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Собирает готовое к сбору растение: добавляет урожай в амбар (produceEarned единиц, его можно продать на рынке /market) и начисляет опыт. Урожай и опыт увеличиваются на harvestYieldBonus от инструментов и соседних декораций; дробная часть бонуса выпадает дополнительной единицей с соответствующим шансом. С шансом, зависящим от редкости семени, выпадают семена того же вида и иногда семя следующей редкости (seedDrops). Зрелые растения на соседних грядках скрещиваются с собираемым: если для пары семян есть рецепт, с его шансом выводится гибридное семя (hybrids). Сбор записывается в историю растений. Засохшие растения собрать нельзя (400); при повышении уровня возвращает блок levelUp. Открытые сбором достижения возвращаются в achievements, выполненные ежедневные задания — в completedQuests. Поле growthMultiplier (1 + modification семени) показывает множитель, с которым растение получало очки роста",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-plants"
+                ],
+                "summary": "Собрать растение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Plant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserPlantHarvestResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-plants/{id}/revive": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Оживляет одно засохшее растение, расходуя одну лейку (watering_can). Лейки продаются в магазине и выдаются за каждые 7 дней стрика. Оживление записывается в журнал",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-plants"
+                ],
+                "summary": "Оживить засохшее растение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Plant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RevivePlantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-seeds": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает список семян в инвентаре пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-seeds"
+                ],
+                "summary": "Получить семена пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserSeed"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-seeds/available": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает все существующие семена с информацией о количестве у пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-seeds"
+                ],
+                "summary": "Получить доступные семена",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по уровню семян",
+                        "name": "level",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AvailableSeed"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-seeds/count": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает количество различных типов семян в инвентаре пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-seeds"
+                ],
+                "summary": "Получить количество уникальных семян",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserSeedCountResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-seeds/with-details": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает семена пользователя с подробной информацией о каждом типе семян",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-seeds"
+                ],
+                "summary": "Получить семена пользователя с деталями",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.UserSeedWithDetails"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-seeds/{seedId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Полностью удаляет семена из инвентаря пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-seeds"
+                ],
+                "summary": "Удалить семена из инвентаря",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seed ID",
+                        "name": "seedId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-seeds/{seedId}/subtract": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Уменьшает количество семян в инвентаре (например, при посадке)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-seeds"
+                ],
+                "summary": "Уменьшить количество семян",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seed ID",
+                        "name": "seedId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Количество для вычитания",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserSeedQuantityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает статистику пользователя. Если статистика не существует - создает новую.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-stats"
+                ],
+                "summary": "Получить статистику пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserStat"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-stats/gold-ledger": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает журнал изменений золота пользователя (награды, покупки, продажи на рынке и т.д.), начиная с последних",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "market"
+                ],
+                "summary": "Журнал золота",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (по умолчанию 50, максимум 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.GoldLedgerEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user-stats/level-info": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает текущий уровень, опыт и прогресс до следующего уровня по единой кривой уровней",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-stats"
+                ],
+                "summary": "Получить информацию об уровне",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.LevelInfoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "post": {
+                "description": "Создает нового пользователя или обновляет существующего по MaxID. При создании также создается статистика, начальные грядки, добавляется стартовый набор семян и товары в магазин.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Создать или обновить пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные пользователя",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пользователь обновлен",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "201": {
+                        "description": "Пользователь создан",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/drought-events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает историю засух пользователя (последние сначала): длительность, максимальную тяжесть, число засохших и погибших растений",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "История засух",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.DroughtEvent"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает информацию о текущем аутентифицированном пользователе",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Получить текущего пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Обновляет информацию о текущем пользователе (например, имя пользователя)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Обновить данные пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Обновленные данные пользователя",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/privacy": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Задает, что друзья видят на ферме пользователя (GET /friends/{id}/farm): full — грядки, растения, уровень и статистику; level_only — только уровень и статистику; hidden — ферма скрыта",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Изменить приватность фермы",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Настройка приватности",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserPrivacyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/recover-plants": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Бесплатно оживляет все засохшие растения после выполнения ежедневного задания. Доступно один раз за засуху; каждое оживление записывается в журнал. Отдельные растения можно оживить лейкой через POST /user-plants/{id}/revive",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Восстановить засохшие растения",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecoverPlantsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/sync": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает все данные пользователя для синхронизации в формате ServerFarmData. Если пользователь не существует - создает его с дефолтными данными.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Синхронизировать все данные пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ServerFarmData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.AdminAdjustRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Компенсация за сбой"
+                }
+            }
+        },
+        "handler.AdminSetStreakRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Стрик сброшен из-за сбоя"
+                },
+                "streak": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "handler.ApplyItemRequest": {
+            "type": "object",
+            "properties": {
+                "itemCode": {
+                    "type": "string",
+                    "example": "fertilizer"
+                },
+                "itemId": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handler.BuyGoodResponse": {
+            "type": "object",
+            "properties": {
+                "goodId": {
+                    "type": "integer"
+                },
+                "itemId": {
+                    "type": "integer"
+                },
+                "itemType": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "purchasedItem": {
+                    "description": "Добавлено поле с купленным предметом"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "totalCost": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.CreateGoodRequest": {
+            "type": "object",
+            "required": [
+                "cost",
+                "idGood",
+                "type"
+            ],
+            "properties": {
+                "cost": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "idGood": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.FarmExpandRequest": {
+            "type": "object",
+            "properties": {
+                "direction": {
+                    "type": "string",
+                    "example": "row"
+                }
+            }
+        },
+        "handler.FriendRequestCreateRequest": {
+            "type": "object",
+            "properties": {
+                "maxId": {
+                    "type": "integer",
+                    "example": 123456789
+                }
+            }
+        },
+        "handler.FriendRequestResponse": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "description": "Встречная заявка принята, пользователи стали друзьями",
+                    "type": "boolean"
+                },
+                "request": {
+                    "$ref": "#/definitions/model.FriendRequest"
+                }
+            }
+        },
+        "handler.GiftCreateRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "kind": {
+                    "description": "seed, produce",
+                    "type": "string",
+                    "example": "seed"
+                },
+                "recipientId": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "seedId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handler.GuildContributeRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "handler.GuildCreateRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Зеленые грядки"
+                }
+            }
+        },
+        "handler.HabitCompletionResponse": {
+            "type": "object",
+            "properties": {
+                "achievements": {
+                    "description": "Достижения, открытые этим выполнением",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementUnlock"
+                    }
+                },
+                "completedQuests": {
+                    "description": "Ежедневные задания, выполненные этим выполнением (награду нужно забрать)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserQuest"
+                    }
+                },
+                "drought": {
+                    "$ref": "#/definitions/model.DroughtState"
+                },
+                "goldEarned": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "growthPoints": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "levelUp": {
+                    "$ref": "#/definitions/model.LevelUp"
+                },
+                "plantsGrown": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "rewardCap": {
+                    "description": "Заполняется, если награда уменьшена дневными ограничениями",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.RewardCap"
+                        }
+                    ]
+                },
+                "streakReward": {
+                    "$ref": "#/definitions/model.ItemGrant"
+                },
+                "xpEarned": {
+                    "type": "integer",
+                    "example": 150
+                }
+            }
+        },
+        "handler.HabitCreateRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Ежедневная утренняя зарядка 15 минут"
+                },
+                "difficulty": {
+                    "type": "string",
+                    "example": "medium"
+                },
+                "every": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "period": {
+                    "type": "string",
+                    "example": "day"
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2024-01-15T00:00:00Z"
+                },
+                "tagId": {
+                    "description": "устаревшее поле, используйте tagIds",
+                    "type": "integer",
+                    "example": 1
+                },
+                "tagIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Утренняя зарядка"
+                }
+            }
+        },
+        "handler.HabitUndoResponse": {
+            "type": "object",
+            "properties": {
+                "droughtRestored": {
+                    "description": "Засуха возвращена к состоянию до выполнения",
+                    "type": "boolean",
+                    "example": false
+                },
+                "goldEarned": {
+                    "type": "integer",
+                    "example": -5
+                },
+                "plantsReverted": {
+                    "description": "У скольких растений откачен рост",
+                    "type": "integer",
+                    "example": 3
+                },
+                "streakReverted": {
+                    "description": "Стрик уменьшен на 1",
+                    "type": "boolean",
+                    "example": true
+                },
+                "streakRewardReturned": {
+                    "description": "Сколько предметов награды за стрик списано",
+                    "type": "integer",
+                    "example": 0
+                },
+                "xpEarned": {
+                    "type": "integer",
+                    "example": -150
+                }
+            }
+        },
+        "handler.HabitUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Обновленное описание привычки"
+                },
+                "difficulty": {
+                    "type": "string",
+                    "example": "hard"
+                },
+                "every": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "period": {
+                    "type": "string",
+                    "example": "week"
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2024-01-20T00:00:00Z"
+                },
+                "tagId": {
+                    "description": "устаревшее поле, используйте tagIds",
+                    "type": "integer",
+                    "example": 2
+                },
+                "tagIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2,
+                        3
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Утренняя зарядка - обновлено"
+                }
+            }
+        },
+        "handler.IBed": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "isLock": {
+                    "type": "boolean"
+                },
+                "plant": {
+                    "$ref": "#/definitions/handler.IPlant"
+                },
+                "x": {
+                    "type": "integer"
+                },
+                "y": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.IPlant": {
+            "type": "object",
+            "properties": {
+                "currentGrowth": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "imgPath": {
+                    "description": "Папка с картинками стадий растения",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "stage": {
+                    "description": "Текущая стадия роста, с 1",
+                    "type": "integer"
+                },
+                "stageCount": {
+                    "description": "Количество стадий роста семени",
+                    "type": "integer"
+                },
+                "stageImgPath": {
+                    "description": "Картинка текущей стадии",
+                    "type": "string"
+                },
+                "targetGrowth": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.LevelInfoResponse": {
+            "type": "object",
+            "properties": {
+                "experience": {
+                    "type": "integer"
+                },
+                "experienceForNextLevel": {
+                    "type": "integer"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "progressPercent": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.MoveDecorationRequest": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "y": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handler.PlaceDecorationRequest": {
+            "type": "object",
+            "properties": {
+                "decorationId": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "x": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "y": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "handler.RecoverPlantsResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "plantsRevived": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "revivals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PlantRevival"
+                    }
+                }
+            }
+        },
+        "handler.RevivePlantResponse": {
+            "type": "object",
+            "properties": {
+                "plant": {
+                    "$ref": "#/definitions/model.UserPlant"
+                },
+                "revival": {
+                    "$ref": "#/definitions/model.PlantRevival"
+                },
+                "wateringCansRemaining": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handler.SeedStorage": {
+            "type": "object",
+            "properties": {
+                "bedId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currentGrowth": {
+                    "type": "integer"
+                },
+                "effects": {
+                    "description": "Effects — действующие эффекты предметов (удобрения, инструменты)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PlantEffect"
+                    }
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "imgPath": {
+                    "type": "string"
+                },
+                "isWithered": {
+                    "type": "boolean"
+                },
+                "seedId": {
+                    "type": "integer"
+                },
+                "seedName": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "integer"
+                },
+                "stageCount": {
+                    "type": "integer"
+                },
+                "stageImgPath": {
+                    "type": "string"
+                },
+                "targetGrowth": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.SellProduceRequest": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "seedId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handler.ServerFarmData": {
+            "type": "object",
+            "properties": {
+                "barn": {
+                    "description": "Урожай в амбаре, который можно продать на рынке",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Produce"
+                    }
+                },
+                "canRecover": {
+                    "description": "Доступно бесплатное массовое восстановление",
+                    "type": "boolean"
+                },
+                "coins": {
+                    "type": "integer"
+                },
+                "currentXp": {
+                    "type": "integer"
+                },
+                "decorations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PlacedDecoration"
+                    }
+                },
+                "didTaskToday": {
+                    "type": "boolean"
+                },
+                "drought": {
+                    "$ref": "#/definitions/model.DroughtState"
+                },
+                "droughtEvents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DroughtEvent"
+                    }
+                },
+                "field": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.IBed"
+                    }
+                },
+                "habits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Habit"
+                    }
+                },
+                "inventorySeeds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserSeedWithDetails"
+                    }
+                },
+                "isDrought": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserItemWithDetails"
+                    }
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "seeds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SeedStorage"
+                    }
+                },
+                "shopItem": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ShopItem"
+                    }
+                },
+                "strick": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Tag"
+                    }
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Task"
+                    }
+                },
+                "xpToNextLevel": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ShopItem": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "item": {},
+                "quantity": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.TagCreateRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#FF5733"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Работа"
+                }
+            }
+        },
+        "handler.TagUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#33FF57"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Работа - обновлено"
+                }
+            }
+        },
+        "handler.TaskCompletionResponse": {
+            "type": "object",
+            "properties": {
+                "achievements": {
+                    "description": "Достижения, открытые этим выполнением",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementUnlock"
+                    }
+                },
+                "completedQuests": {
+                    "description": "Ежедневные задания, выполненные этим выполнением (награду нужно забрать)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserQuest"
+                    }
+                },
+                "drought": {
+                    "$ref": "#/definitions/model.DroughtState"
+                },
+                "goldEarned": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "growthPoints": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "levelUp": {
+                    "$ref": "#/definitions/model.LevelUp"
+                },
+                "plantsGrown": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "rewardCap": {
+                    "description": "Заполняется, если награда уменьшена дневными ограничениями",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.RewardCap"
+                        }
+                    ]
+                },
+                "streakReward": {
+                    "$ref": "#/definitions/model.ItemGrant"
+                },
+                "xpEarned": {
+                    "type": "integer",
+                    "example": 150
+                }
+            }
+        },
+        "handler.TaskCreateRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2024-01-15T00:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Нужно завершить все задачи по проекту до конца недели"
+                },
+                "difficulty": {
+                    "type": "string",
+                    "example": "medium"
+                },
+                "tagId": {
+                    "description": "устаревшее поле, используйте tagIds",
+                    "type": "integer",
+                    "example": 1
+                },
+                "tagIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Завершить проект"
+                }
+            }
+        },
+        "handler.TaskUndoResponse": {
+            "type": "object",
+            "properties": {
+                "droughtRestored": {
+                    "description": "Засуха возвращена к состоянию до выполнения",
+                    "type": "boolean",
+                    "example": false
+                },
+                "goldEarned": {
+                    "type": "integer",
+                    "example": -5
+                },
+                "plantsReverted": {
+                    "description": "У скольких растений откачен рост",
+                    "type": "integer",
+                    "example": 3
+                },
+                "streakReverted": {
+                    "description": "Стрик уменьшен на 1",
+                    "type": "boolean",
+                    "example": true
+                },
+                "streakRewardReturned": {
+                    "description": "Сколько предметов награды за стрик списано",
+                    "type": "integer",
+                    "example": 0
+                },
+                "xpEarned": {
+                    "type": "integer",
+                    "example": -150
+                }
+            }
+        },
+        "handler.TaskUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2024-01-20T00:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Обновленное описание задачи"
+                },
+                "difficulty": {
+                    "type": "string",
+                    "example": "hard"
+                },
+                "tagId": {
+                    "description": "устаревшее поле, используйте tagIds",
+                    "type": "integer",
+                    "example": 2
+                },
+                "tagIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2,
+                        3
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Завершить проект - обновлено"
+                }
+            }
+        },
+        "handler.TradeCreateRequest": {
+            "type": "object",
+            "properties": {
+                "offer": {
+                    "description": "Что отдает автор предложения",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TradeLine"
+                    }
+                },
+                "recipientId": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "request": {
+                    "description": "Что отдает получатель",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TradeLine"
+                    }
+                }
+            }
+        },
+        "handler.UpdateCostRequest": {
+            "type": "object",
+            "required": [
+                "cost"
+            ],
+            "properties": {
+                "cost": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "handler.UpdateQuantityRequest": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "handler.UserCreateRequest": {
+            "type": "object",
+            "properties": {
+                "maxId": {
+                    "type": "integer",
+                    "example": 123456789
+                },
+                "username": {
+                    "type": "string",
+                    "example": "john_doe"
+                }
+            }
+        },
+        "handler.UserPlantCreateRequest": {
+            "type": "object",
+            "properties": {
+                "seedId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "x": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "y": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "handler.UserPrivacyRequest": {
+            "type": "object",
+            "properties": {
+                "farmPrivacy": {
+                    "type": "string",
+                    "example": "level_only"
+                }
+            }
+        },
+        "handler.UserSeedCountResponse": {
+            "type": "object",
+            "properties": {
+                "totalSeeds": {
+                    "type": "integer",
+                    "example": 15
+                }
+            }
+        },
+        "handler.UserSeedQuantityRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handler.UserUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "username": {
+                    "type": "string",
+                    "example": "john_doe_updated"
+                }
+            }
+        },
+        "model.AchievementUnlock": {
+            "type": "object",
+            "properties": {
+                "achievementId": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rewardAmount": {
+                    "type": "integer"
+                },
+                "rewardType": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AdminAuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "experience, gold, streak",
+                    "type": "string"
+                },
+                "adminId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "targetUserId": {
+                    "type": "integer"
+                },
+                "valueAfter": {
+                    "type": "integer"
+                },
+                "valueBefore": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AdminOverrideResult": {
+            "type": "object",
+            "properties": {
+                "entry": {
+                    "$ref": "#/definitions/model.AdminAuditEntry"
+                },
+                "levelUp": {
+                    "description": "Повышение уровня после начисления опыта",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.LevelUp"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.AvailableSeed": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "integer"
+                },
+                "rarity": {
+                    "type": "string"
+                },
+                "seedId": {
+                    "type": "integer"
+                },
+                "seedName": {
+                    "type": "string"
+                },
+                "userAmount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Bed": {
+            "type": "object",
+            "properties": {
+                "cellNumber": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isLocked": {
+                    "type": "boolean"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "x": {
+                    "description": "Столбец на поле, с нуля",
+                    "type": "integer"
+                },
+                "y": {
+                    "description": "Ряд на поле, с нуля",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.BedWithUserPlant": {
+            "type": "object",
+            "properties": {
+                "cellNumber": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isLocked": {
+                    "type": "boolean"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "userPlant": {
+                    "$ref": "#/definitions/model.UserPlantWithSeed"
+                },
+                "x": {
+                    "description": "Столбец на поле, с нуля",
+                    "type": "integer"
+                },
+                "y": {
+                    "description": "Ряд на поле, с нуля",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Decoration": {
+            "type": "object",
+            "properties": {
+                "bonusType": {
+                    "type": "string"
+                },
+                "bonusValue": {
+                    "type": "number"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "cost": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
             }
-        }
-    },
-    "definitions": {
-        "handler.AddQuantityRequest": {
+        },
+        "model.DecorationChange": {
             "type": "object",
-            "required": [
-                "amount"
-            ],
             "properties": {
-                "amount": {
-                    "type": "integer",
-                    "minimum": 1
+                "balance": {
+                    "type": "integer"
+                },
+                "decoration": {
+                    "$ref": "#/definitions/model.PlacedDecoration"
+                },
+                "goldChange": {
+                    "description": "Списано (отрицательное) или возвращено золото",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DroughtEvent": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                },
+                "endReason": {
+                    "type": "string"
+                },
+                "endedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "maxSeverity": {
+                    "type": "integer"
+                },
+                "plantsDied": {
+                    "type": "integer"
+                },
+                "plantsWithered": {
+                    "type": "integer"
+                },
+                "severity": {
+                    "type": "integer"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DroughtState": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                },
+                "growthMultiplier": {
+                    "type": "number"
+                },
+                "isDrought": {
+                    "type": "boolean"
+                },
+                "severity": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.BedCreateRequest": {
+        "model.FarmCell": {
             "type": "object",
             "properties": {
                 "cellNumber": {
-                    "type": "integer",
-                    "example": 1
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "decoration": {
+                    "$ref": "#/definitions/model.PlacedDecoration"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isEmpty": {
+                    "type": "boolean"
                 },
                 "isLocked": {
-                    "type": "boolean",
-                    "example": false
+                    "type": "boolean"
+                },
+                "plant": {
+                    "$ref": "#/definitions/model.UserPlantWithSeed"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "x": {
+                    "description": "Столбец на поле, с нуля",
+                    "type": "integer"
+                },
+                "y": {
+                    "description": "Ряд на поле, с нуля",
+                    "type": "integer"
                 }
             }
         },
-        "handler.BedInitialRequest": {
+        "model.FarmExpansion": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer",
-                    "example": 5
+                "balance": {
+                    "type": "integer"
+                },
+                "beds": {
+                    "description": "Добавленные грядки (закрытые)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Bed"
+                    }
+                },
+                "cost": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "height": {
+                    "description": "Высота поля после расширения",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "width": {
+                    "description": "Ширина поля после расширения",
+                    "type": "integer"
                 }
             }
         },
-        "handler.BuyGoodResponse": {
+        "model.FarmLayout": {
             "type": "object",
             "properties": {
-                "goodId": {
+                "canExpandColumn": {
+                    "type": "boolean"
+                },
+                "canExpandRow": {
+                    "type": "boolean"
+                },
+                "cells": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FarmCell"
+                    }
+                },
+                "height": {
                     "type": "integer"
                 },
-                "itemId": {
+                "maxHeight": {
                     "type": "integer"
                 },
-                "itemType": {
+                "maxWidth": {
+                    "type": "integer"
+                },
+                "nextExpansionCost": {
+                    "type": "integer"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Friend": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "integer"
+                },
+                "maxId": {
+                    "type": "integer"
+                },
+                "since": {
+                    "description": "Когда заявка принята",
                     "type": "string"
                 },
-                "message": {
+                "userId": {
+                    "type": "integer"
+                },
+                "username": {
                     "type": "string"
+                }
+            }
+        },
+        "model.FriendFarm": {
+            "type": "object",
+            "properties": {
+                "beds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BedWithUserPlant"
+                    }
                 },
-                "purchasedItem": {
-                    "description": "Добавлено поле с купленным предметом"
+                "farmPrivacy": {
+                    "type": "string"
                 },
-                "quantity": {
+                "level": {
                     "type": "integer"
                 },
-                "remaining": {
+                "stats": {
+                    "$ref": "#/definitions/model.FriendFarmStats"
+                },
+                "userId": {
                     "type": "integer"
                 },
-                "totalCost": {
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FriendFarmStats": {
+            "type": "object",
+            "properties": {
+                "currentStreak": {
+                    "type": "integer"
+                },
+                "longestStreak": {
+                    "type": "integer"
+                },
+                "totalPlantHarvested": {
                     "type": "integer"
                 }
             }
         },
-        "handler.CreateGoodRequest": {
+        "model.FriendRequest": {
             "type": "object",
-            "required": [
-                "cost",
-                "idGood",
-                "type"
-            ],
             "properties": {
-                "cost": {
-                    "type": "integer",
-                    "minimum": 0
+                "createdAt": {
+                    "type": "string"
                 },
-                "idGood": {
-                    "type": "integer",
-                    "minimum": 1
+                "fromMaxId": {
+                    "type": "integer"
                 },
-                "quantity": {
-                    "type": "integer",
-                    "minimum": 0
+                "fromUserId": {
+                    "type": "integer"
                 },
-                "type": {
+                "fromUsername": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "toMaxId": {
+                    "type": "integer"
+                },
+                "toUserId": {
+                    "type": "integer"
+                },
+                "toUsername": {
                     "type": "string"
                 }
             }
         },
-        "handler.HabitCompletionResponse": {
+        "model.FriendRequests": {
             "type": "object",
             "properties": {
-                "plantsGrown": {
-                    "type": "integer",
-                    "example": 3
+                "incoming": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FriendRequest"
+                    }
                 },
-                "xpEarned": {
-                    "type": "integer",
-                    "example": 150
+                "outgoing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FriendRequest"
+                    }
+                }
+            }
+        },
+        "model.Gift": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "seed, produce",
+                    "type": "string"
+                },
+                "recipientId": {
+                    "type": "integer"
+                },
+                "recipientUsername": {
+                    "type": "string"
+                },
+                "resolvedAt": {
+                    "type": "string"
+                },
+                "seedId": {
+                    "type": "integer"
+                },
+                "seedName": {
+                    "type": "string"
+                },
+                "senderId": {
+                    "type": "integer"
+                },
+                "senderUsername": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending, accepted, declined, cancelled",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Gifts": {
+            "type": "object",
+            "properties": {
+                "incoming": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Gift"
+                    }
+                },
+                "outgoing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Gift"
+                    }
                 }
             }
         },
-        "handler.HabitCreateRequest": {
+        "model.GoldLedgerEntry": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer",
-                    "example": 0
-                },
-                "description": {
-                    "type": "string",
-                    "example": "Ежедневная утренняя зарядка 15 минут"
-                },
-                "difficulty": {
-                    "type": "string",
-                    "example": "medium"
+                "amount": {
+                    "type": "integer"
                 },
-                "every": {
-                    "type": "integer",
-                    "example": 1
+                "balanceAfter": {
+                    "type": "integer"
                 },
-                "period": {
-                    "type": "string",
-                    "example": "day"
+                "createdAt": {
+                    "type": "string"
                 },
-                "startDate": {
-                    "type": "string",
-                    "example": "2024-01-15T00:00:00Z"
+                "description": {
+                    "type": "string"
                 },
-                "tagId": {
-                    "type": "integer",
-                    "example": 1
+                "id": {
+                    "type": "integer"
                 },
-                "title": {
-                    "type": "string",
-                    "example": "Утренняя зарядка"
+                "source": {
+                    "type": "string"
                 },
-                "xpReward": {
-                    "type": "integer",
-                    "example": 50
+                "userId": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.HabitIncrementResponse": {
+        "model.Good": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer",
-                    "example": 5
+                "cost": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "idGood": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "'seed', 'bed'",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.HabitResetResponse": {
+        "model.Guild": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Habit count reset successfully"
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "memberCount": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ownerId": {
+                    "description": "nil, если аккаунт владельца удален",
+                    "type": "integer"
                 }
             }
         },
-        "handler.HabitUndoResponse": {
+        "model.GuildContribution": {
             "type": "object",
             "properties": {
-                "xpEarned": {
-                    "type": "integer",
-                    "example": 150
+                "amount": {
+                    "description": "Принято урожая (не больше, чем осталось до цели)",
+                    "type": "integer"
+                },
+                "goal": {
+                    "$ref": "#/definitions/model.GuildGoal"
+                },
+                "levelUp": {
+                    "description": "Повышение уровня текущего пользователя за награду",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.LevelUp"
+                        }
+                    ]
+                },
+                "rewards": {
+                    "description": "Награды участникам, если вклад завершил цель",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GuildReward"
+                    }
                 }
             }
         },
-        "handler.HabitUpdateRequest": {
+        "model.GuildGoal": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer",
-                    "example": 5
+                "completed": {
+                    "type": "boolean"
                 },
-                "description": {
-                    "type": "string",
-                    "example": "Обновленное описание привычки"
+                "completedAt": {
+                    "type": "string"
                 },
-                "difficulty": {
-                    "type": "string",
-                    "example": "hard"
+                "id": {
+                    "type": "integer"
                 },
-                "done": {
-                    "type": "boolean",
-                    "example": false
+                "progress": {
+                    "type": "integer"
                 },
-                "every": {
-                    "type": "integer",
-                    "example": 3
+                "rewardGold": {
+                    "description": "Награда каждому участнику, внесшему минимальный вклад",
+                    "type": "integer"
                 },
-                "period": {
-                    "type": "string",
-                    "example": "week"
+                "rewardXp": {
+                    "type": "integer"
                 },
-                "startDate": {
-                    "type": "string",
-                    "example": "2024-01-20T00:00:00Z"
+                "seedId": {
+                    "type": "integer"
                 },
-                "tagId": {
-                    "type": "integer",
-                    "example": 2
+                "seedName": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "integer"
+                },
+                "templateId": {
+                    "type": "integer"
                 },
                 "title": {
-                    "type": "string",
-                    "example": "Утренняя зарядка - обновлено"
+                    "type": "string"
                 },
-                "xpReward": {
-                    "type": "integer",
-                    "example": 75
+                "weekStart": {
+                    "type": "string"
                 }
             }
         },
-        "handler.IBed": {
+        "model.GuildInfo": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
+                "goal": {
+                    "$ref": "#/definitions/model.GuildGoal"
                 },
-                "isLock": {
-                    "type": "boolean"
+                "guild": {
+                    "$ref": "#/definitions/model.Guild"
                 },
-                "plant": {
-                    "$ref": "#/definitions/handler.IPlant"
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GuildMember"
+                    }
                 }
             }
         },
-        "handler.IPlant": {
+        "model.GuildMember": {
             "type": "object",
             "properties": {
-                "currentGrowth": {
-                    "type": "integer"
-                },
-                "id": {
+                "contributed": {
                     "type": "integer"
                 },
-                "imgPath": {
+                "joinedAt": {
                     "type": "string"
                 },
-                "name": {
+                "role": {
+                    "description": "owner, member",
                     "type": "string"
                 },
-                "targetGrowth": {
+                "userId": {
                     "type": "integer"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "handler.LevelInfoResponse": {
+        "model.GuildReward": {
             "type": "object",
             "properties": {
-                "experience": {
+                "gold": {
                     "type": "integer"
                 },
-                "experienceForNextLevel": {
+                "userId": {
                     "type": "integer"
                 },
-                "level": {
+                "xp": {
                     "type": "integer"
-                },
-                "progressPercent": {
-                    "type": "number"
                 }
             }
         },
-        "handler.SeedStorage": {
+        "model.Habit": {
             "type": "object",
             "properties": {
-                "bedId": {
+                "count": {
                     "type": "integer"
                 },
                 "createdAt": {
                     "type": "string"
                 },
-                "currentGrowth": {
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
-                "icon": {
+                "difficulty": {
                     "type": "string"
                 },
+                "done": {
+                    "type": "boolean"
+                },
+                "every": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "imgPath": {
+                "period": {
                     "type": "string"
                 },
-                "isWithered": {
-                    "type": "boolean"
+                "startDate": {
+                    "type": "string"
                 },
-                "seedId": {
-                    "type": "integer"
+                "tagIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "seedName": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Tag"
+                    }
+                },
+                "title": {
                     "type": "string"
                 },
-                "targetGrowth": {
+                "userId": {
+                    "type": "integer"
+                },
+                "xpReward": {
                     "type": "integer"
                 }
             }
         },
-        "handler.ServerFarmData": {
+        "model.HybridBreed": {
             "type": "object",
             "properties": {
-                "coins": {
+                "isNewDiscovery": {
+                    "description": "Гибрид открыт впервые",
+                    "type": "boolean"
+                },
+                "isUpgrade": {
+                    "description": "Семя более высокой редкости, чем собранное растение",
+                    "type": "boolean"
+                },
+                "partnerPlantId": {
+                    "description": "Растение на соседней грядке, с которым произошло скрещивание",
                     "type": "integer"
                 },
-                "currentXp": {
+                "quantity": {
                     "type": "integer"
                 },
-                "didTaskToday": {
-                    "type": "boolean"
+                "rarity": {
+                    "type": "string"
                 },
-                "field": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.IBed"
-                    }
+                "recipeId": {
+                    "type": "integer"
                 },
-                "habits": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Habit"
-                    }
+                "seedIcon": {
+                    "type": "string"
                 },
-                "inventorySeeds": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.UserSeedWithDetails"
-                    }
+                "seedId": {
+                    "type": "integer"
+                },
+                "seedName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.HybridDiscovery": {
+            "type": "object",
+            "properties": {
+                "discoveredAt": {
+                    "type": "string"
                 },
-                "isDrought": {
-                    "type": "boolean"
+                "lastBredAt": {
+                    "type": "string"
                 },
-                "seeds": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.SeedStorage"
-                    }
+                "parentASeedId": {
+                    "type": "integer"
                 },
-                "shopItem": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handler.ShopItem"
-                    }
+                "parentBSeedId": {
+                    "type": "integer"
                 },
-                "strick": {
+                "recipeId": {
                     "type": "integer"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Tag"
-                    }
+                "seed": {
+                    "$ref": "#/definitions/model.Seed"
                 },
-                "tasks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Task"
-                    }
+                "timesBred": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.ShopItem": {
+        "model.HybridRecipeInfo": {
             "type": "object",
             "properties": {
-                "cost": {
-                    "type": "integer"
+                "chance": {
+                    "type": "number"
+                },
+                "discovered": {
+                    "type": "boolean"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "item": {},
-                "quantity": {
+                "parentASeedId": {
                     "type": "integer"
                 },
-                "type": {
+                "parentASeedName": {
+                    "type": "string"
+                },
+                "parentBSeedId": {
+                    "type": "integer"
+                },
+                "parentBSeedName": {
                     "type": "string"
+                },
+                "resultSeed": {
+                    "$ref": "#/definitions/model.Seed"
                 }
             }
         },
-        "handler.TagCreateRequest": {
+        "model.Item": {
             "type": "object",
             "properties": {
-                "color": {
-                    "type": "string",
-                    "example": "#FF5733"
+                "code": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "durationHours": {
+                    "type": "integer"
+                },
+                "effectType": {
+                    "description": "Эффект при применении к растению; DurationHours = nil — эффект действует до сбора,\nMaxStacks — сколько эффектов предмета одновременно может быть на растении (0 — без ограничений)",
+                    "type": "string"
+                },
+                "effectValue": {
+                    "type": "number"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "maxStacks": {
+                    "type": "integer"
                 },
                 "name": {
-                    "type": "string",
-                    "example": "Работа"
+                    "type": "string"
+                },
+                "type": {
+                    "description": "'consumable', 'fertilizer', 'tool'",
+                    "type": "string"
                 }
             }
         },
-        "handler.TagUpdateRequest": {
+        "model.ItemApplyResult": {
             "type": "object",
             "properties": {
-                "color": {
-                    "type": "string",
-                    "example": "#33FF57"
+                "effect": {
+                    "description": "Для длительных эффектов",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PlantEffect"
+                        }
+                    ]
                 },
-                "name": {
-                    "type": "string",
-                    "example": "Работа - обновлено"
+                "effectType": {
+                    "type": "string"
+                },
+                "itemCode": {
+                    "type": "string"
+                },
+                "itemId": {
+                    "type": "integer"
+                },
+                "newGrowth": {
+                    "description": "Для мгновенного роста",
+                    "type": "integer"
+                },
+                "remaining": {
+                    "description": "Сколько предметов осталось",
+                    "type": "integer"
+                },
+                "revival": {
+                    "description": "Для оживления",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PlantRevival"
+                        }
+                    ]
                 }
             }
         },
-        "handler.TaskCompletionResponse": {
+        "model.ItemGrant": {
             "type": "object",
             "properties": {
-                "plantsGrown": {
-                    "type": "integer",
-                    "example": 3
+                "amount": {
+                    "type": "integer"
                 },
-                "xpEarned": {
-                    "type": "integer",
-                    "example": 150
+                "code": {
+                    "type": "string"
+                },
+                "itemId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "Количество в инвентаре после выдачи",
+                    "type": "integer"
                 }
             }
         },
-        "handler.TaskCreateRequest": {
+        "model.Leaderboard": {
             "type": "object",
             "properties": {
-                "date": {
-                    "type": "string",
-                    "example": "2024-01-15T00:00:00Z"
+                "computedAt": {
+                    "description": "Время снимка; nil, если сезон еще не посчитан",
+                    "type": "string"
                 },
-                "description": {
-                    "type": "string",
-                    "example": "Нужно завершить все задачи по проекту до конца недели"
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LeaderboardEntry"
+                    }
                 },
-                "difficulty": {
-                    "type": "string",
-                    "example": "medium"
+                "me": {
+                    "description": "Место текущего пользователя, если он есть в рейтинге",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.LeaderboardEntry"
+                        }
+                    ]
                 },
-                "tagId": {
-                    "type": "integer",
-                    "example": 1
+                "metric": {
+                    "description": "weekly_xp, longest_streak, total_harvests",
+                    "type": "string"
                 },
-                "title": {
-                    "type": "string",
-                    "example": "Завершить проект"
+                "scope": {
+                    "description": "global, friends",
+                    "type": "string"
+                },
+                "seasonEnd": {
+                    "type": "string"
+                },
+                "seasonStart": {
+                    "type": "string"
                 }
             }
         },
-        "handler.TaskUndoResponse": {
+        "model.LeaderboardEntry": {
             "type": "object",
             "properties": {
-                "xpEarned": {
-                    "type": "integer",
-                    "example": 150
+                "rank": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.TaskUpdateRequest": {
+        "model.LevelReward": {
             "type": "object",
             "properties": {
-                "date": {
-                    "type": "string",
-                    "example": "2024-01-20T00:00:00Z"
+                "amount": {
+                    "type": "integer"
                 },
-                "description": {
-                    "type": "string",
-                    "example": "Обновленное описание задачи"
+                "id": {
+                    "type": "integer"
                 },
-                "difficulty": {
-                    "type": "string",
-                    "example": "hard"
+                "itemId": {
+                    "type": "integer"
                 },
-                "done": {
-                    "type": "boolean",
-                    "example": false
+                "itemName": {
+                    "type": "string"
                 },
-                "tagId": {
-                    "type": "integer",
-                    "example": 2
+                "level": {
+                    "type": "integer"
                 },
-                "title": {
-                    "type": "string",
-                    "example": "Завершить проект - обновлено"
+                "rewardType": {
+                    "description": "gold, seed, bed, item",
+                    "type": "string"
                 },
-                "xpReward": {
-                    "type": "integer",
-                    "example": 150
-                }
-            }
-        },
-        "handler.UpdateCostRequest": {
-            "type": "object",
-            "required": [
-                "cost"
-            ],
-            "properties": {
-                "cost": {
-                    "type": "integer",
-                    "minimum": 0
+                "seedId": {
+                    "type": "integer"
+                },
+                "seedName": {
+                    "type": "string"
                 }
             }
         },
-        "handler.UpdateQuantityRequest": {
+        "model.LevelUp": {
             "type": "object",
-            "required": [
-                "quantity"
-            ],
             "properties": {
-                "quantity": {
-                    "type": "integer",
-                    "minimum": 0
+                "newLevel": {
+                    "type": "integer"
+                },
+                "oldLevel": {
+                    "type": "integer"
+                },
+                "rewards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LevelReward"
+                    }
+                },
+                "unlockedSeeds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Seed"
+                    }
                 }
             }
         },
-        "handler.UserCreateRequest": {
+        "model.MarketPrice": {
             "type": "object",
             "properties": {
-                "maxId": {
-                    "type": "integer",
-                    "example": 123456789
+                "basePrice": {
+                    "type": "number"
                 },
-                "username": {
-                    "type": "string",
-                    "example": "john_doe"
+                "changePercent": {
+                    "description": "Изменение цены относительно вчера, %",
+                    "type": "number"
+                },
+                "owned": {
+                    "description": "Сколько единиц урожая есть в амбаре",
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "seedIcon": {
+                    "type": "string"
+                },
+                "seedId": {
+                    "type": "integer"
+                },
+                "seedName": {
+                    "type": "string"
+                },
+                "yesterdayPrice": {
+                    "type": "number"
                 }
             }
         },
-        "handler.UserPlantCreateRequest": {
+        "model.MarketSale": {
             "type": "object",
             "properties": {
-                "cellNumber": {
-                    "type": "integer",
-                    "example": 1
+                "balance": {
+                    "description": "Баланс золота после продажи",
+                    "type": "integer"
+                },
+                "goldEarned": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "remaining": {
+                    "description": "Сколько единиц урожая осталось в амбаре",
+                    "type": "integer"
                 },
                 "seedId": {
-                    "type": "integer",
-                    "example": 1
+                    "type": "integer"
+                },
+                "unitPrice": {
+                    "type": "number"
                 }
             }
         },
-        "handler.UserPlantGrowthRequest": {
+        "model.PlacedDecoration": {
             "type": "object",
             "properties": {
-                "growthAmount": {
-                    "type": "integer",
-                    "example": 10
+                "createdAt": {
+                    "type": "string"
+                },
+                "decoration": {
+                    "$ref": "#/definitions/model.Decoration"
+                },
+                "decorationId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "x": {
+                    "type": "integer"
+                },
+                "y": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.UserPlantGrowthResponse": {
+        "model.PlantEffect": {
             "type": "object",
             "properties": {
-                "newGrowth": {
-                    "type": "integer",
-                    "example": 50
+                "createdAt": {
+                    "type": "string"
+                },
+                "effectType": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "itemCode": {
+                    "type": "string"
+                },
+                "itemId": {
+                    "type": "integer"
+                },
+                "itemName": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "userPlantId": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
                 }
             }
         },
-        "handler.UserSeedAddRequest": {
+        "model.PlantHistory": {
             "type": "object",
             "properties": {
-                "quantity": {
-                    "type": "integer",
-                    "example": 5
+                "bedId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "finalGrowth": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "plantedAt": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
                 },
                 "seedId": {
-                    "type": "integer",
-                    "example": 1
-                }
-            }
-        },
-        "handler.UserSeedCountResponse": {
-            "type": "object",
-            "properties": {
-                "totalSeeds": {
-                    "type": "integer",
-                    "example": 15
+                    "type": "integer"
+                },
+                "seedName": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "witheredAt": {
+                    "type": "string"
                 }
             }
         },
-        "handler.UserSeedQuantityRequest": {
+        "model.PlantRevival": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "integer",
-                    "example": 3
+                "createdAt": {
+                    "type": "string"
+                },
+                "droughtEventId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "description": "'watering_can', 'bulk'",
+                    "type": "string"
+                },
+                "seedId": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "userPlantId": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.UserStatAmountRequest": {
+        "model.Produce": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "integer",
-                    "example": 100
+                "quantity": {
+                    "type": "integer"
+                },
+                "seedIcon": {
+                    "type": "string"
+                },
+                "seedId": {
+                    "type": "integer"
+                },
+                "seedName": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
-        "handler.UserStatMessageResponse": {
+        "model.QuestClaim": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Operation completed successfully"
+                "gold": {
+                    "type": "integer"
+                },
+                "item": {
+                    "$ref": "#/definitions/model.ItemGrant"
+                },
+                "levelUp": {
+                    "$ref": "#/definitions/model.LevelUp"
+                },
+                "quest": {
+                    "$ref": "#/definitions/model.UserQuest"
+                },
+                "xp": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.UserUpdateRequest": {
+        "model.RewardCap": {
             "type": "object",
             "properties": {
-                "username": {
-                    "type": "string",
-                    "example": "john_doe_updated"
+                "completionsToday": {
+                    "description": "Сколько выполнений было сегодня до текущего",
+                    "type": "integer"
+                },
+                "dailyGrowthSoftCap": {
+                    "description": "Дневной лимит очков роста",
+                    "type": "integer"
+                },
+                "dailyXpSoftCap": {
+                    "description": "Дневной лимит опыта",
+                    "type": "integer"
+                },
+                "goldBeforeCap": {
+                    "description": "Золото без ограничений",
+                    "type": "integer"
+                },
+                "growthBeforeCap": {
+                    "description": "Очки роста без ограничений",
+                    "type": "integer"
+                },
+                "growthCapped": {
+                    "description": "Сработал дневной лимит очков роста",
+                    "type": "boolean"
+                },
+                "multiplier": {
+                    "description": "Множитель убывающей отдачи (1 — без снижения)",
+                    "type": "number"
+                },
+                "xpBeforeCap": {
+                    "description": "Опыт без ограничений",
+                    "type": "integer"
+                },
+                "xpCapped": {
+                    "description": "Сработал дневной лимит опыта",
+                    "type": "boolean"
                 }
             }
         },
-        "model.AvailableSeed": {
+        "model.Seed": {
             "type": "object",
             "properties": {
-                "level": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "goldReward": {
                     "type": "integer"
                 },
-                "rarity": {
+                "icon": {
                     "type": "string"
                 },
-                "seedId": {
+                "id": {
                     "type": "integer"
                 },
-                "seedName": {
+                "imgPlant": {
                     "type": "string"
                 },
-                "userAmount": {
+                "isHybrid": {
+                    "description": "Гибрид: можно получить только скрещиванием",
+                    "type": "boolean"
+                },
+                "levelRequired": {
+                    "type": "integer"
+                },
+                "modification": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rarity": {
+                    "type": "string"
+                },
+                "stageThresholds": {
+                    "description": "StageThresholds — проценты роста, с которых начинаются стадии роста (первая — 0).\nКартинка стадии N — ImgPlant/stateN.png",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "targetGrowth": {
+                    "type": "integer"
+                },
+                "xpReward": {
                     "type": "integer"
                 }
             }
         },
-        "model.Bed": {
+        "model.SeedDrop": {
             "type": "object",
             "properties": {
-                "cellNumber": {
+                "isUpgrade": {
+                    "description": "Семя более высокой редкости, чем собранное растение",
+                    "type": "boolean"
+                },
+                "quantity": {
                     "type": "integer"
                 },
-                "createdAt": {
+                "rarity": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "isLocked": {
-                    "type": "boolean"
+                "seedIcon": {
+                    "type": "string"
                 },
-                "userId": {
+                "seedId": {
                     "type": "integer"
+                },
+                "seedName": {
+                    "type": "string"
                 }
             }
         },
-        "model.BedWithUserPlant": {
+        "model.Tag": {
             "type": "object",
             "properties": {
-                "cellNumber": {
-                    "type": "integer"
+                "color": {
+                    "type": "string"
                 },
                 "createdAt": {
                     "type": "string"
@@ -6278,56 +10913,52 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "isLocked": {
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
                 },
-                "userId": {
+                "userid": {
                     "type": "integer"
-                },
-                "userPlant": {
-                    "$ref": "#/definitions/model.UserPlantWithSeed"
                 }
             }
         },
-        "model.Good": {
+        "model.TagWithCount": {
             "type": "object",
             "properties": {
-                "cost": {
-                    "type": "integer"
+                "color": {
+                    "type": "string"
                 },
                 "createdAt": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "idGood": {
+                "habit_count": {
                     "type": "integer"
                 },
-                "quantity": {
+                "id": {
                     "type": "integer"
                 },
-                "type": {
-                    "description": "'seed', 'bed'",
+                "name": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "task_count": {
+                    "type": "integer"
                 },
-                "userId": {
+                "total_count": {
+                    "type": "integer"
+                },
+                "userid": {
                     "type": "integer"
                 }
             }
         },
-        "model.Habit": {
+        "model.Task": {
             "type": "object",
-            "properties": {
-                "count": {
-                    "type": "integer"
-                },
+            "properties": {
                 "createdAt": {
                     "type": "string"
                 },
+                "date": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
@@ -6337,23 +10968,20 @@ const docTemplate = `{
                 "done": {
                     "type": "boolean"
                 },
-                "every": {
-                    "type": "integer"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "period": {
-                    "type": "string"
-                },
-                "startDate": {
-                    "type": "string"
-                },
-                "tag": {
-                    "$ref": "#/definitions/model.Tag"
+                "tagIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "tagId": {
-                    "type": "integer"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Tag"
+                    }
                 },
                 "title": {
                     "type": "string"
@@ -6366,144 +10994,196 @@ const docTemplate = `{
                 }
             }
         },
-        "model.Seed": {
+        "model.TradeLine": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "amount": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "seed, produce",
                     "type": "string"
                 },
-                "goldReward": {
+                "seedId": {
                     "type": "integer"
                 },
-                "icon": {
+                "seedName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TradeOffer": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "imgPlant": {
-                    "type": "string"
+                "offer": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TradeLine"
+                    }
                 },
-                "levelRequired": {
+                "proposerId": {
                     "type": "integer"
                 },
-                "modification": {
-                    "type": "number"
-                },
-                "name": {
+                "proposerUsername": {
                     "type": "string"
                 },
-                "rarity": {
+                "recipientId": {
+                    "type": "integer"
+                },
+                "recipientUsername": {
                     "type": "string"
                 },
-                "targetGrowth": {
-                    "type": "integer"
+                "request": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TradeLine"
+                    }
                 },
-                "xpReward": {
-                    "type": "integer"
+                "resolvedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending, accepted, declined, cancelled",
+                    "type": "string"
                 }
             }
         },
-        "model.Tag": {
+        "model.TradeOffers": {
             "type": "object",
             "properties": {
-                "color": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
+                "incoming": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TradeOffer"
+                    }
                 },
-                "userid": {
-                    "type": "integer"
+                "outgoing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TradeOffer"
+                    }
                 }
             }
         },
-        "model.TagWithCount": {
+        "model.User": {
             "type": "object",
             "properties": {
-                "color": {
+                "createdAt": {
                     "type": "string"
                 },
-                "createdAt": {
+                "farmPrivacy": {
+                    "description": "FarmPrivacy — что друзья видят на ферме: full, level_only, hidden",
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "isActive": {
+                    "type": "boolean"
+                },
+                "lastLogin": {
                     "type": "string"
                 },
-                "task_count": {
+                "maxId": {
                     "type": "integer"
                 },
-                "userid": {
-                    "type": "integer"
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "model.Task": {
+        "model.UserAchievement": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "code": {
                     "type": "string"
                 },
-                "date": {
+                "counter": {
+                    "description": "tasks_completed, plants_harvested, longest_streak",
                     "type": "string"
                 },
                 "description": {
                     "type": "string"
                 },
-                "difficulty": {
+                "event": {
+                    "description": "legendary_harvest, hybrid_bred",
                     "type": "string"
                 },
-                "done": {
-                    "type": "boolean"
+                "icon": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "tag": {
-                    "$ref": "#/definitions/model.Tag"
+                "kind": {
+                    "description": "counter, event",
+                    "type": "string"
                 },
-                "tagId": {
+                "name": {
+                    "type": "string"
+                },
+                "progress": {
+                    "description": "Текущее значение счетчика (не больше порога); для событий 0 или 1",
                     "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "rewardAmount": {
+                    "type": "integer"
                 },
-                "userId": {
+                "rewardItemId": {
                     "type": "integer"
                 },
-                "xpReward": {
+                "rewardSeedId": {
+                    "type": "integer"
+                },
+                "rewardType": {
+                    "description": "gold, seed, item",
+                    "type": "string"
+                },
+                "threshold": {
                     "type": "integer"
+                },
+                "unlocked": {
+                    "type": "boolean"
+                },
+                "unlockedAt": {
+                    "type": "string"
                 }
             }
         },
-        "model.User": {
+        "model.UserItemWithDetails": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "code": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
-                "isActive": {
-                    "type": "boolean"
+                "icon": {
+                    "type": "string"
                 },
-                "lastLogin": {
+                "itemId": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "maxId": {
+                "quantity": {
                     "type": "integer"
                 },
-                "username": {
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
@@ -6531,30 +11211,61 @@ const docTemplate = `{
                 },
                 "userId": {
                     "type": "integer"
+                },
+                "witheredAt": {
+                    "type": "string"
                 }
             }
         },
         "model.UserPlantHarvestResult": {
             "type": "object",
             "properties": {
+                "achievements": {
+                    "description": "Достижения, открытые этим сбором",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementUnlock"
+                    }
+                },
                 "bedId": {
                     "type": "integer"
                 },
+                "completedQuests": {
+                    "description": "Ежедневные задания, выполненные этим сбором",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserQuest"
+                    }
+                },
                 "createdAt": {
                     "type": "string"
                 },
                 "currentGrowth": {
                     "type": "integer"
                 },
-                "goldEarned": {
-                    "type": "integer"
-                },
                 "goldReward": {
                     "type": "integer"
                 },
+                "growthBonus": {
+                    "type": "number"
+                },
+                "growthMultiplier": {
+                    "type": "number"
+                },
                 "growthPercent": {
                     "type": "integer"
                 },
+                "harvestYieldBonus": {
+                    "description": "HarvestYieldBonus — бонус к урожаю от инструментов и соседних декораций, награды за сбор умножаются на 1 + HarvestYieldBonus",
+                    "type": "number"
+                },
+                "hybrids": {
+                    "description": "Гибриды, выведенные скрещиванием с соседними растениями",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HybridBreed"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -6564,6 +11275,28 @@ const docTemplate = `{
                 "isWithered": {
                     "type": "boolean"
                 },
+                "levelUp": {
+                    "$ref": "#/definitions/model.LevelUp"
+                },
+                "modification": {
+                    "description": "Modification — модификатор семени, GrowthBonus — бонус действующих эффектов предметов и соседних декораций,\nGrowthMultiplier = 1 + Modification + GrowthBonus.\nОчки роста за каждое выполнение задачи/привычки умножаются на GrowthMultiplier.",
+                    "type": "number"
+                },
+                "produceEarned": {
+                    "description": "Сколько единиц урожая добавлено в амбар",
+                    "type": "integer"
+                },
+                "produceTotal": {
+                    "description": "Сколько единиц урожая этого семени теперь в амбаре",
+                    "type": "integer"
+                },
+                "seedDrops": {
+                    "description": "Семена, выпавшие при сборе",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SeedDrop"
+                    }
+                },
                 "seedIcon": {
                     "type": "string"
                 },
@@ -6576,12 +11309,25 @@ const docTemplate = `{
                 "seedName": {
                     "type": "string"
                 },
+                "stage": {
+                    "description": "Stage — текущая стадия роста (с 1) из StageCount стадий семени, StageImgPath — картинка стадии",
+                    "type": "integer"
+                },
+                "stageCount": {
+                    "type": "integer"
+                },
+                "stageImgPath": {
+                    "type": "string"
+                },
                 "targetGrowth": {
                     "type": "integer"
                 },
                 "userId": {
                     "type": "integer"
                 },
+                "witheredAt": {
+                    "type": "string"
+                },
                 "xpEarned": {
                     "type": "integer"
                 },
@@ -6605,15 +11351,29 @@ const docTemplate = `{
                 "goldReward": {
                     "type": "integer"
                 },
+                "growthBonus": {
+                    "type": "number"
+                },
+                "growthMultiplier": {
+                    "type": "number"
+                },
                 "growthPercent": {
                     "type": "integer"
                 },
+                "harvestYieldBonus": {
+                    "description": "HarvestYieldBonus — бонус к урожаю от инструментов и соседних декораций, награды за сбор умножаются на 1 + HarvestYieldBonus",
+                    "type": "number"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "isWithered": {
                     "type": "boolean"
                 },
+                "modification": {
+                    "description": "Modification — модификатор семени, GrowthBonus — бонус действующих эффектов предметов и соседних декораций,\nGrowthMultiplier = 1 + Modification + GrowthBonus.\nОчки роста за каждое выполнение задачи/привычки умножаются на GrowthMultiplier.",
+                    "type": "number"
+                },
                 "seedIcon": {
                     "type": "string"
                 },
@@ -6626,17 +11386,89 @@ const docTemplate = `{
                 "seedName": {
                     "type": "string"
                 },
+                "stage": {
+                    "description": "Stage — текущая стадия роста (с 1) из StageCount стадий семени, StageImgPath — картинка стадии",
+                    "type": "integer"
+                },
+                "stageCount": {
+                    "type": "integer"
+                },
+                "stageImgPath": {
+                    "type": "string"
+                },
                 "targetGrowth": {
                     "type": "integer"
                 },
                 "userId": {
                     "type": "integer"
                 },
+                "witheredAt": {
+                    "type": "string"
+                },
                 "xpReward": {
                     "type": "integer"
                 }
             }
         },
+        "model.UserQuest": {
+            "type": "object",
+            "properties": {
+                "claimed": {
+                    "type": "boolean"
+                },
+                "claimedAt": {
+                    "type": "string"
+                },
+                "completed": {
+                    "type": "boolean"
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "questDate": {
+                    "type": "string"
+                },
+                "rewardGold": {
+                    "type": "integer"
+                },
+                "rewardItemAmount": {
+                    "type": "integer"
+                },
+                "rewardItemId": {
+                    "type": "integer"
+                },
+                "rewardXp": {
+                    "type": "integer"
+                },
+                "seedId": {
+                    "type": "integer"
+                },
+                "tagName": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "integer"
+                },
+                "templateId": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
         "model.UserSeed": {
             "type": "object",
             "properties": {
@@ -6698,6 +11530,12 @@ const docTemplate = `{
                 "currentStreak": {
                     "type": "integer"
                 },
+                "droughtDays": {
+                    "type": "integer"
+                },
+                "droughtSeverity": {
+                    "type": "integer"
+                },
                 "experience": {
                     "type": "integer"
                 },
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/joho/godotenv"
 )
 
@@ -16,6 +19,22 @@ type Config struct {
     DBPass   string
     DBName   string
     BotToken string
+
+    // Через сколько дней засохшее растение погибает
+    WitheredPlantLifetimeDays int
+
+    // Ограничения наград за выполнение задач и привычек
+    DailyXPSoftCap               int
+    DailyGrowthSoftCap           int
+    DiminishingReturnsAfter      int
+    CompletionRateLimitPerMinute int
+
+    // Сколько подарков и предложений обмена пользователь может отправить и получить за день
+    DailyGiftSendLimit    int
+    DailyGiftReceiveLimit int
+
+    // Награды за выполнение задач и привычек по сложности
+    DifficultyRewards map[string]constants.DifficultyReward
 }
 
 func LoadConfig() *Config {
@@ -31,6 +50,23 @@ func LoadConfig() *Config {
         DBPass:   os.Getenv("DB_PASSWORD"),
         DBName:   os.Getenv("DB_NAME"),
         BotToken: os.Getenv("MAX_BOT_TOKEN"),
+
+        WitheredPlantLifetimeDays: getEnvInt("WITHERED_PLANT_LIFETIME_DAYS", constants.WitheredPlantLifetimeDays),
+
+        DailyXPSoftCap:               getEnvInt("DAILY_XP_SOFT_CAP", constants.DailyXPSoftCap),
+        DailyGrowthSoftCap:           getEnvInt("DAILY_GROWTH_SOFT_CAP", constants.DailyGrowthSoftCap),
+        DiminishingReturnsAfter:      getEnvInt("DIMINISHING_RETURNS_AFTER", constants.DiminishingReturnsAfter),
+        CompletionRateLimitPerMinute: getEnvInt("COMPLETION_RATE_LIMIT_PER_MINUTE", constants.CompletionRateLimitPerMinute),
+
+        DailyGiftSendLimit:    getEnvInt("DAILY_GIFT_SEND_LIMIT", constants.DailyGiftSendLimit),
+        DailyGiftReceiveLimit: getEnvInt("DAILY_GIFT_RECEIVE_LIMIT", constants.DailyGiftReceiveLimit),
+
+        DifficultyRewards: map[string]constants.DifficultyReward{},
+    }
+
+    for difficulty, reward := range constants.DefaultDifficultyRewards {
+        key := "DIFFICULTY_REWARD_" + strings.ToUpper(difficulty)
+        cfg.DifficultyRewards[difficulty] = getEnvDifficultyReward(key, reward)
     }
 
     return cfg
@@ -44,4 +80,41 @@ func (c *Config) GetPostgresDSN() string {
         c.DBPort,
         c.DBName,
     )
-}
\ No newline at end of file
+}
+
+// getEnvInt читает целое число из переменной окружения, возвращая значение по умолчанию при ошибке
+func getEnvInt(key string, defaultValue int) int {
+    value, err := strconv.Atoi(os.Getenv(key))
+    if err != nil || value <= 0 {
+        return defaultValue
+    }
+    return value
+}
+
+// getEnvDifficultyReward читает награду сложности из переменной окружения в формате
+// "базовый опыт,коэф сложности,золото,очки роста" (например, "30,0.2,2,2").
+// При ошибке или отрицательных значениях возвращает значение по умолчанию.
+func getEnvDifficultyReward(key string, defaultValue constants.DifficultyReward) constants.DifficultyReward {
+    value := os.Getenv(key)
+    if value == "" {
+        return defaultValue
+    }
+
+    parts := strings.Split(value, ",")
+    if len(parts) != 4 {
+        log.Printf("Invalid %s=%q: expected 4 comma-separated values, using default", key, value)
+        return defaultValue
+    }
+
+    baseXP, errXP := strconv.Atoi(strings.TrimSpace(parts[0]))
+    xpBonus, errBonus := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+    gold, errGold := strconv.Atoi(strings.TrimSpace(parts[2]))
+    growth, errGrowth := strconv.Atoi(strings.TrimSpace(parts[3]))
+    if errXP != nil || errBonus != nil || errGold != nil || errGrowth != nil ||
+        baseXP <= 0 || xpBonus < 0 || gold < 0 || growth < 0 {
+        log.Printf("Invalid %s=%q, using default", key, value)
+        return defaultValue
+    }
+
+    return constants.DifficultyReward{BaseXP: baseXP, XPBonus: xpBonus, Gold: gold, Growth: growth}
+}
@@ -0,0 +1,27 @@
+package constants
+
+// Виды достижений
+const (
+	AchievementKindCounter = "counter" // Открывается, когда счетчик достигает порога
+	AchievementKindEvent   = "event"   // Открывается при первом событии
+)
+
+// Счетчики пользователя, по которым открываются достижения
+const (
+	AchievementCounterTasksCompleted  = "tasks_completed"  // user_stat.total_tasks_completed
+	AchievementCounterPlantsHarvested = "plants_harvested" // user_stat.total_plant_harvested
+	AchievementCounterLongestStreak   = "longest_streak"   // user_stat.longest_streak
+)
+
+// События, по которым открываются достижения
+const (
+	AchievementEventLegendaryHarvest = "legendary_harvest" // Собрано растение легендарной редкости
+	AchievementEventHybridBred       = "hybrid_bred"       // Выведено гибридное семя
+)
+
+// Типы наград за достижения
+const (
+	AchievementRewardGold = "gold"
+	AchievementRewardSeed = "seed"
+	AchievementRewardItem = "item"
+)
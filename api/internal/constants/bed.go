@@ -0,0 +1,23 @@
+package constants
+
+// Размеры поля. Грядка имеет координаты (x, y): x — столбец, y — ряд, оба с нуля.
+// Соседними считаются грядки слева, справа, сверху и снизу.
+const (
+	InitialFarmWidth  = 3
+	InitialFarmHeight = 3
+	MaxFarmWidth      = 6
+	MaxFarmHeight     = 6
+)
+
+// Расширение поля: n-е расширение (с нуля) стоит FarmExpansionBaseCost + n * FarmExpansionCostStep золота.
+// Новые грядки добавляются закрытыми и открываются так же, как начальные.
+const (
+	FarmExpansionBaseCost = 50
+	FarmExpansionCostStep = 25
+)
+
+// Направления расширения поля
+const (
+	FarmExpandRow    = "row"
+	FarmExpandColumn = "column"
+)
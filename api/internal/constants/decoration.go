@@ -0,0 +1,12 @@
+package constants
+
+// Коды декораций
+const (
+	DecorationFence     = "fence"     // Забор: без бонуса
+	DecorationPath      = "path"      // Дорожка: без бонуса
+	DecorationScarecrow = "scarecrow" // Пугало: бонус к урожаю соседних грядок
+	DecorationWell      = "well"      // Колодец: бонус к росту на соседних грядках
+)
+
+// DecorationRefundPercent — сколько процентов стоимости возвращается при удалении декорации с поля
+const DecorationRefundPercent = 50
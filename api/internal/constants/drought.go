@@ -0,0 +1,24 @@
+package constants
+
+// Тяжесть засухи. Растет на 1 за каждый пропущенный день и снижается на 1
+// за каждый день, в который пользователь выполнил хотя бы одну задачу или привычку.
+const (
+	DroughtSeverityNone      = 0 // Засухи нет
+	DroughtSeverityDry       = 1 // Растения растут медленнее
+	DroughtSeverityWithering = 2 // Растения засыхают
+	DroughtSeverityDeadly    = 3 // Засохшие растения погибают
+	DroughtSeverityMax       = DroughtSeverityDeadly
+)
+
+// DroughtGrowthMultipliers — множитель роста растений в зависимости от тяжести засухи
+var DroughtGrowthMultipliers = map[int]float64{
+	DroughtSeverityNone:      1.0,
+	DroughtSeverityDry:       0.5,
+	DroughtSeverityWithering: 0.25,
+	DroughtSeverityDeadly:    0.25,
+}
+
+// Причины окончания засухи
+const (
+	DroughtEndRecovered = "recovered" // Пользователь снова выполняет задачи
+)
@@ -0,0 +1,31 @@
+package constants
+
+// Что можно подарить или обменять
+const (
+	ExchangeKindSeed    = "seed"    // Семена из user_seed
+	ExchangeKindProduce = "produce" // Урожай из амбара (user_produce)
+)
+
+var ValidExchangeKinds = []string{ExchangeKindSeed, ExchangeKindProduce}
+
+// Статусы подарков и предложений обмена
+const (
+	ExchangePending   = "pending"
+	ExchangeAccepted  = "accepted"
+	ExchangeDeclined  = "declined"  // Отклонено получателем
+	ExchangeCancelled = "cancelled" // Отменено отправителем
+)
+
+// Стороны обмена
+const (
+	TradeSideOffer   = "offer"   // Отдает автор предложения
+	TradeSideRequest = "request" // Отдает получатель
+)
+
+// Ограничения подарков и обменов против дублирования и прокачки вторых аккаунтов
+const (
+	DailyGiftSendLimit    = 5  // Подарков и предложений обмена, которые пользователь может отправить за день
+	DailyGiftReceiveLimit = 5  // Подарков и предложений обмена, которые пользователь может получить за день
+	MaxExchangeAmount     = 50 // Максимальное количество в одной позиции подарка или обмена
+	MaxTradeLines         = 5  // Максимальное число позиций на каждой стороне обмена
+)
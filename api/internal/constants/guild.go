@@ -0,0 +1,16 @@
+package constants
+
+// Роли участников гильдии
+const (
+	GuildRoleOwner  = "owner"
+	GuildRoleMember = "member"
+)
+
+// Ограничения гильдий
+const (
+	GuildMaxMembers    = 10 // Гильдии — небольшие группы
+	GuildNameMinLength = 3
+	GuildNameMaxLength = 50
+
+	GuildMinContributionPercent = 10 // Минимальный вклад в цель (процент от цели) для получения награды
+)
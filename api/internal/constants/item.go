@@ -0,0 +1,52 @@
+package constants
+
+// Типы предметов
+const (
+	ItemTypeConsumable = "consumable" // Расходуется при использовании
+	ItemTypeFertilizer = "fertilizer" // Ускоряет рост растения
+	ItemTypeTool       = "tool"       // Улучшает сбор урожая
+)
+
+// Коды предметов
+const (
+	ItemWateringCan     = "watering_can"     // Лейка: оживляет одно засохшее растение
+	ItemFertilizer      = "fertilizer"       // Удобрение: сразу добавляет очки роста
+	ItemGrowthStimulant = "growth_stimulant" // Стимулятор роста: временно ускоряет рост
+	ItemPruner          = "pruner"           // Секатор: увеличивает урожай
+)
+
+// ShopItemGood — предмет в ежедневном ассортименте магазина пользователя.
+// Предмет ищется по коду; тип товара совпадает с типом предмета.
+type ShopItemGood struct {
+	Code     string
+	Quantity int
+	Cost     int
+}
+
+// ShopItemGoods — предметы, которые магазин выставляет каждый день
+var ShopItemGoods = []ShopItemGood{
+	{Code: ItemWateringCan, Quantity: 3, Cost: 15},
+	{Code: ItemFertilizer, Quantity: 5, Cost: 5},
+	{Code: ItemGrowthStimulant, Quantity: 2, Cost: 20},
+	{Code: ItemPruner, Quantity: 1, Cost: 40},
+}
+
+// Эффекты предметов, применяемых к растениям
+const (
+	EffectRevive        = "revive"         // Оживляет засохшее растение
+	EffectInstantGrowth = "instant_growth" // Сразу добавляет effect_value очков роста
+	EffectGrowthBoost   = "growth_boost"   // Добавляет effect_value к множителю роста, складывается
+	EffectHarvestYield  = "harvest_yield"  // Добавляет effect_value к множителю урожая, складывается
+)
+
+// Лейки за стрик: каждые WateringCanStreakInterval дней подряд выдается StreakWateringCanReward леек
+const (
+	WateringCanStreakInterval = 7
+	StreakWateringCanReward   = 1
+)
+
+// Способы оживления засохших растений
+const (
+	RevivalMethodWateringCan = "watering_can" // Одно растение за лейку
+	RevivalMethodBulk        = "bulk"         // Все растения бесплатно, один раз за засуху
+)
@@ -0,0 +1,25 @@
+package constants
+
+import "time"
+
+// Метрики рейтингов
+const (
+	LeaderboardWeeklyXP      = "weekly_xp"      // Опыт за сезон из progress_log
+	LeaderboardLongestStreak = "longest_streak" // Самая длинная серия дней с выполнениями внутри сезона из progress_log
+	LeaderboardTotalHarvests = "total_harvests" // Собранные за сезон растения из plant_history
+)
+
+var ValidLeaderboardMetrics = []string{LeaderboardWeeklyXP, LeaderboardLongestStreak, LeaderboardTotalHarvests}
+
+// Области рейтингов
+const (
+	LeaderboardScopeGlobal  = "global"
+	LeaderboardScopeFriends = "friends"
+)
+
+var ValidLeaderboardScopes = []string{LeaderboardScopeGlobal, LeaderboardScopeFriends}
+
+const (
+	LeaderboardSize            = 50               // Количество мест в ответе
+	LeaderboardRefreshInterval = 15 * time.Minute // Как часто планировщик пересчитывает снимки текущего сезона
+)
@@ -0,0 +1,31 @@
+package constants
+
+// Урожай и рынок
+const (
+	// HarvestBaseYield — сколько единиц урожая дает сбор одного растения без бонусов
+	HarvestBaseYield = 4
+
+	// Дневная цена урожая = базовая цена * множитель из диапазона [MarketMinMultiplier, MarketMaxMultiplier].
+	// Множитель выбирается генератором случайных чисел, инициализированным датой и семенем.
+	MarketMinMultiplier = 0.7
+	MarketMaxMultiplier = 1.3
+)
+
+// Источники изменений золота в журнале gold_ledger
+const (
+	GoldSourceTask             = "task"
+	GoldSourceHabit            = "habit"
+	GoldSourceTaskUndo         = "task_undo"
+	GoldSourceHabitUndo        = "habit_undo"
+	GoldSourceLevelReward      = "level_reward"
+	GoldSourceShopPurchase     = "shop_purchase"
+	GoldSourceMarketSale       = "market_sale"
+	GoldSourceFarmExpansion    = "farm_expansion"
+	GoldSourceDecoration       = "decoration_purchase"
+	GoldSourceDecorationRefund = "decoration_refund"
+	GoldSourceManual           = "manual"
+	GoldSourceAdmin            = "admin_override"
+	GoldSourceAchievement      = "achievement"
+	GoldSourceQuest            = "quest"
+	GoldSourceGuildGoal        = "guild_goal"
+)
@@ -0,0 +1,26 @@
+package constants
+
+// Жизненный цикл засохшего растения
+const (
+	// WitheredPlantLifetimeDays — через сколько дней засохшее растение погибает (по умолчанию)
+	WitheredPlantLifetimeDays = 3
+	// WitheredGrowthDecayPercent — сколько процентов от целевого роста засохшее растение теряет за день
+	WitheredGrowthDecayPercent = 10
+)
+
+// События истории растений
+const (
+	PlantEventHarvested = "harvested" // Растение собрано
+	PlantEventDied      = "died"      // Растение погибло
+	PlantEventRemoved   = "removed"   // Растение удалено пользователем
+)
+
+// Причины гибели растений
+const (
+	PlantDeathWithered = "withered" // Истек срок жизни засохшего растения
+	PlantDeathDrought  = "drought"  // Погибло от сильной засухи
+)
+
+// DefaultStageThresholds — стадии роста по умолчанию (проценты роста, с которых начинается каждая стадия):
+// три стадии роста и четвертая — готово к сбору
+var DefaultStageThresholds = []int{0, 34, 67, 100}
@@ -0,0 +1,21 @@
+package constants
+
+// Источники записей в логе прогресса
+const (
+	ProgressSourceTask         = "task"
+	ProgressSourceHabit        = "habit"
+	ProgressSourceLevelReward  = "level_reward"
+	ProgressSourceStreakReward = "streak_reward"
+	ProgressSourceAchievement  = "achievement"
+	ProgressSourceQuest        = "quest"
+	ProgressSourceGuildGoal    = "guild_goal"
+	ProgressSourceHarvest      = "harvest"
+)
+
+// Типы наград за уровень
+const (
+	LevelRewardGold = "gold"
+	LevelRewardSeed = "seed"
+	LevelRewardBed  = "bed"
+	LevelRewardItem = "item"
+)
@@ -0,0 +1,12 @@
+package constants
+
+// Количество ежедневных заданий, выдаваемых пользователю
+const DailyQuestCount = 3
+
+// Виды ежедневных заданий
+const (
+	QuestKindCompleteTasks        = "complete_tasks"         // Выполнить задачи (опционально указанной сложности)
+	QuestKindCompleteHabits       = "complete_habits"        // Выполнить привычки (опционально указанной сложности)
+	QuestKindCompleteTaggedHabits = "complete_tagged_habits" // Выполнить все привычки с тегом
+	QuestKindHarvestPlants        = "harvest_plants"         // Собрать растения (опционально указанного семени)
+)
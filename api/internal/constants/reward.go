@@ -0,0 +1,21 @@
+package constants
+
+// DifficultyReward описывает награду за выполнение задачи или привычки заданной сложности
+type DifficultyReward struct {
+	BaseXP  int     // Базовый опыт
+	XPBonus float64 // Коэффициент сложности, добавляется к коэффициенту уровня
+	Gold    int     // Золото за выполнение
+	Growth  int     // Очки роста для каждого растения пользователя
+}
+
+// DefaultDifficultyRewards — таблица наград по сложности по умолчанию.
+// Награда каждой сложности переопределяется переменной окружения DIFFICULTY_REWARD_<СЛОЖНОСТЬ>.
+var DefaultDifficultyRewards = map[string]DifficultyReward{
+	DifficultyTrifle: {BaseXP: 10, XPBonus: 0.0, Gold: 0, Growth: 1},
+	DifficultyEasy:   {BaseXP: 15, XPBonus: 0.1, Gold: 1, Growth: 1},
+	DifficultyNormal: {BaseXP: 30, XPBonus: 0.2, Gold: 2, Growth: 2},
+	DifficultyHard:   {BaseXP: 50, XPBonus: 0.5, Gold: 5, Growth: 3},
+}
+
+// DefaultDifficulty используется, если сложность не указана или неизвестна
+const DefaultDifficulty = DifficultyNormal
@@ -0,0 +1,19 @@
+package constants
+
+// Ограничения наград за выполнение задач и привычек (защита от спама простыми задачами).
+// Целые значения можно переопределить переменными окружения, см. config.
+const (
+	// Мягкие дневные лимиты: опыт и очки роста сверх лимита начисляются с множителем OverSoftCapMultiplier
+	DailyXPSoftCap        = 800
+	DailyGrowthSoftCap    = 30
+	OverSoftCapMultiplier = 0.25
+
+	// Убывающая отдача: первые DiminishingReturnsAfter выполнений за день дают полную награду,
+	// каждое следующее — в DiminishingReturnsFactor раз меньше предыдущего, но не меньше DiminishingReturnsMinMultiplier
+	DiminishingReturnsAfter         = 10
+	DiminishingReturnsFactor        = 0.85
+	DiminishingReturnsMinMultiplier = 0.2
+
+	// Сколько раз в минуту пользователь может отмечать выполнение и отмену выполнения
+	CompletionRateLimitPerMinute = 10
+)
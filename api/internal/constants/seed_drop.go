@@ -0,0 +1,11 @@
+package constants
+
+// Выпадение семян при сборе урожая.
+// Шанс получить семя того же вида за один бросок = SeedDropChance / RarityMultipliers[редкость семени],
+// бросков SeedDropRolls, каждый удачный бросок дает одно семя.
+// Шанс получить семя следующей редкости = UpgradeSeedDropChance / RarityMultipliers[следующая редкость].
+const (
+	SeedDropChance        = 0.5
+	SeedDropRolls         = 2
+	UpgradeSeedDropChance = 0.1
+)
@@ -0,0 +1,28 @@
+package constants
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Действия администратора, записываемые в admin_audit_log
+const (
+	AdminActionExperience = "experience"
+	AdminActionGold       = "gold"
+	AdminActionStreak     = "streak"
+)
+
+// Настройки приватности фермы: что друзья видят на ферме пользователя
+const (
+	FarmPrivacyFull      = "full"       // Грядки, растения, уровень и статистика
+	FarmPrivacyLevelOnly = "level_only" // Только уровень и статистика
+	FarmPrivacyHidden    = "hidden"     // Ферма скрыта от друзей
+)
+
+var ValidFarmPrivacy = []string{FarmPrivacyFull, FarmPrivacyLevelOnly, FarmPrivacyHidden}
+
+// Статусы дружбы
+const (
+	FriendshipPending  = "pending"
+	FriendshipAccepted = "accepted"
+)
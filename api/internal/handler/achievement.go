@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type AchievementHandler struct {
+	BaseHandler
+	achievementService *service.AchievementService
+}
+
+func NewAchievementHandler(achievementService *service.AchievementService) *AchievementHandler {
+	return &AchievementHandler{achievementService: achievementService}
+}
+
+// GetAchievements godoc
+// @Summary Получить достижения
+// @Description Возвращает все достижения с прогрессом текущего пользователя: для счетчиков progress — текущее значение, ограниченное порогом threshold; для событий — 0 или 1. Открытые достижения помечены unlocked и unlockedAt, награда за них выдается один раз при открытии
+// @Tags achievements
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} model.UserAchievement
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /achievements [get]
+func (h *AchievementHandler) GetAchievements(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	achievements, err := h.achievementService.GetForUser(context.Background(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, achievements)
+}
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultAdminAuditLimit = 50
+	maxAdminAuditLimit     = 200
+)
+
+type AdminHandler struct {
+	BaseHandler
+	adminService *service.AdminService
+	auditRepo    *repository.AdminAuditRepo
+}
+
+func NewAdminHandler(adminService *service.AdminService, auditRepo *repository.AdminAuditRepo) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+		auditRepo:    auditRepo,
+	}
+}
+
+// AdminAdjustRequest — изменение опыта или золота пользователя на amount (может быть отрицательным)
+type AdminAdjustRequest struct {
+	Amount int64  `json:"amount" example:"100"`
+	Reason string `json:"reason" example:"Компенсация за сбой"`
+}
+
+// AdminSetStreakRequest — новое значение стрика пользователя
+type AdminSetStreakRequest struct {
+	Streak int    `json:"streak" example:"7"`
+	Reason string `json:"reason" example:"Стрик сброшен из-за сбоя"`
+}
+
+// AdjustExperience godoc
+// @Summary Изменить опыт пользователя
+// @Description Изменяет опыт пользователя на amount (опыт не опускается ниже нуля). При повышении уровня выдаются награды за уровень. Изменение записывается в журнал действий администраторов с указанием причины
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "ID пользователя"
+// @Param request body AdminAdjustRequest true "Изменение опыта и причина"
+// @Success 200 {object} model.AdminOverrideResult
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users/{id}/experience [post]
+func (h *AdminHandler) AdjustExperience(c echo.Context) error {
+	adminID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	targetID, req, err := h.bindAdjust(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	result, err := h.adminService.AdjustExperience(context.Background(), adminID, targetID, req.Amount, req.Reason)
+	if err != nil {
+		return h.overrideError(c, err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// AdjustGold godoc
+// @Summary Изменить золото пользователя
+// @Description Изменяет золото пользователя на amount (баланс не опускается ниже нуля). Изменение записывается в журнал золота с источником admin_override и в журнал действий администраторов с указанием причины
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "ID пользователя"
+// @Param request body AdminAdjustRequest true "Изменение золота и причина"
+// @Success 200 {object} model.AdminOverrideResult
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users/{id}/gold [post]
+func (h *AdminHandler) AdjustGold(c echo.Context) error {
+	adminID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	targetID, req, err := h.bindAdjust(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	result, err := h.adminService.AdjustGold(context.Background(), adminID, targetID, req.Amount, req.Reason)
+	if err != nil {
+		return h.overrideError(c, err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// SetStreak godoc
+// @Summary Установить стрик пользователя
+// @Description Устанавливает текущий стрик пользователя; рекорд стрика не уменьшается. Изменение записывается в журнал действий администраторов с указанием причины
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "ID пользователя"
+// @Param request body AdminSetStreakRequest true "Новый стрик и причина"
+// @Success 200 {object} model.AdminOverrideResult
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users/{id}/streak [put]
+func (h *AdminHandler) SetStreak(c echo.Context) error {
+	adminID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	targetID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user ID"})
+	}
+
+	var req AdminSetStreakRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	if req.Streak < 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "streak must not be negative"})
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "reason is required"})
+	}
+
+	result, err := h.adminService.SetStreak(context.Background(), adminID, targetID, req.Streak, req.Reason)
+	if err != nil {
+		return h.overrideError(c, err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// GetAuditLog godoc
+// @Summary Журнал действий администраторов
+// @Description Возвращает ручные изменения прогресса пользователей, начиная с последних. Параметр userId фильтрует записи по пользователю
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param userId query int false "ID пользователя"
+// @Param limit query int false "Количество записей (по умолчанию 50, максимум 200)"
+// @Success 200 {array} model.AdminAuditEntry
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/audit-log [get]
+func (h *AdminHandler) GetAuditLog(c echo.Context) error {
+	var targetID int64
+	if raw := c.QueryParam("userId"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user ID"})
+		}
+		targetID = id
+	}
+
+	limit := defaultAdminAuditLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		var err error
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+		}
+		if limit > maxAdminAuditLimit {
+			limit = maxAdminAuditLimit
+		}
+	}
+
+	entries, err := h.auditRepo.GetEntries(context.Background(), targetID, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, entries)
+}
+
+// bindAdjust разбирает ID пользователя и тело запроса на изменение опыта или золота.
+// Возвращаемая ошибка — текст ответа 400.
+func (h *AdminHandler) bindAdjust(c echo.Context) (int64, AdminAdjustRequest, error) {
+	var req AdminAdjustRequest
+
+	targetID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, req, errors.New("invalid user ID")
+	}
+	if err := c.Bind(&req); err != nil {
+		return 0, req, errors.New("invalid request body")
+	}
+	if req.Amount == 0 {
+		return 0, req, errors.New("amount must not be zero")
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return 0, req, errors.New("reason is required")
+	}
+	return targetID, req, nil
+}
+
+func (h *AdminHandler) overrideError(c echo.Context, err error) error {
+	if strings.Contains(err.Error(), "not found") {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
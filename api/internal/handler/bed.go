@@ -6,18 +6,23 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
 	"github.com/labstack/echo/v4"
 )
 
 type BedHandler struct {
 	BaseHandler
-	repo *repository.BedRepo
+	repo        *repository.BedRepo
+	farmService *service.FarmService
 }
 
-func NewBedHandler(repo *repository.BedRepo) *BedHandler {
-	return &BedHandler{repo: repo}
+func NewBedHandler(repo *repository.BedRepo, farmService *service.FarmService) *BedHandler {
+	return &BedHandler{
+		repo:        repo,
+		farmService: farmService,
+	}
 }
 
 // GetUserBeds godoc
@@ -125,91 +130,6 @@ func (h *BedHandler) GetBedByCellNumber(c echo.Context) error {
 	return c.JSON(http.StatusOK, bed)
 }
 
-// CreateBed godoc
-// @Summary Создать новую грядку
-// @Description Создает новую грядку для текущего пользователя
-// @Tags beds
-// @Accept json
-// @Produce json
-// @Security ApiKeyAuth
-// @Param X-User-ID header string true "User ID"
-// @Param request body BedCreateRequest true "Данные для создания грядки"
-// @Success 200 {object} model.Bed
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /beds [post]
-func (h *BedHandler) CreateBed(c echo.Context) error {
-	userID, err := h.GetUserIDFromContext(c)
-	if err != nil {
-		return err
-	}
-
-	var req BedCreateRequest
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
-	}
-
-	bed := &model.Bed{
-		UserID:     userID,
-		CellNumber: req.CellNumber,
-		IsLocked:   req.IsLocked,
-	}
-
-	if err := h.repo.Create(context.Background(), bed); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-	return c.JSON(http.StatusOK, bed)
-}
-
-// UnlockBed godoc
-// @Summary Разблокировать грядку
-// @Description Разблокирует указанную грядку
-// @Tags beds
-// @Accept json
-// @Produce json
-// @Security ApiKeyAuth
-// @Param X-User-ID header string true "User ID"
-// @Param id path int true "Bed ID"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 403 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /beds/{id}/unlock [post]
-func (h *BedHandler) UnlockBed(c echo.Context) error {
-	userID, err := h.GetUserIDFromContext(c)
-	if err != nil {
-		return err
-	}
-
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid bed ID"})
-	}
-
-	// Проверяем, что грядка принадлежит пользователю
-	bed, err := h.repo.GetByID(context.Background(), id)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	if bed.UserID != userID {
-		return c.JSON(http.StatusForbidden, map[string]string{"error": "access denied"})
-	}
-
-	err = h.repo.Unlock(context.Background(), id)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	return c.JSON(http.StatusOK, map[string]string{"message": "Bed unlocked successfully"})
-}
-
 // LockBed godoc
 // @Summary Заблокировать грядку
 // @Description Блокирует указанную грядку
@@ -333,52 +253,73 @@ func (h *BedHandler) GetBedsWithPlants(c echo.Context) error {
 	return c.JSON(http.StatusOK, beds)
 }
 
-// CreateInitialBeds godoc
-// @Summary Создать начальные грядки
-// @Description Создает начальный набор грядок для пользователя
+// GetLayout godoc
+// @Summary Сетка поля
+// @Description Возвращает поле пользователя в виде сетки width x height: все грядки с координатами, включая закрытые и пустые, с посаженными растениями, а также стоимость следующего расширения
 // @Tags beds
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param X-User-ID header string true "User ID"
-// @Param request body BedInitialRequest true "Данные для создания начальных грядок"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} model.FarmLayout
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /beds/layout [get]
+func (h *BedHandler) GetLayout(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	layout, err := h.farmService.Layout(context.Background(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, layout)
+}
+
+// ExpandFarm godoc
+// @Summary Расширить поле
+// @Description Покупает расширение поля за золото: добавляет ряд закрытых грядок снизу (direction=row) или столбец справа (direction=column). Каждое следующее расширение дороже; размер поля ограничен
+// @Tags beds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param request body FarmExpandRequest true "Направление расширения"
+// @Success 200 {object} model.FarmExpansion
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /beds/init [post]
-func (h *BedHandler) CreateInitialBeds(c echo.Context) error {
+// @Router /beds/expand [post]
+func (h *BedHandler) ExpandFarm(c echo.Context) error {
 	userID, err := h.GetUserIDFromContext(c)
 	if err != nil {
 		return err
 	}
 
-	var req BedInitialRequest
+	var req FarmExpandRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
-	if req.Count <= 0 {
-		req.Count = 5 // значение по умолчанию
+	if req.Direction != constants.FarmExpandRow && req.Direction != constants.FarmExpandColumn {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "direction must be 'row' or 'column'"})
 	}
 
-	err = h.repo.CreateInitialBeds(context.Background(), userID, req.Count)
+	expansion, err := h.farmService.Expand(context.Background(), userID, req.Direction)
 	if err != nil {
+		if strings.Contains(err.Error(), "not enough gold") || strings.Contains(err.Error(), "maximum") {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-
-	return c.JSON(http.StatusOK, map[string]string{"message": "Initial beds created successfully"})
+	return c.JSON(http.StatusOK, expansion)
 }
 
 // DTO для запросов
 
-// BedCreateRequest представляет запрос на создание грядки
-type BedCreateRequest struct {
-	CellNumber int  `json:"cellNumber" example:"1"`
-	IsLocked   bool `json:"isLocked" example:"false"`
-}
-
-// BedInitialRequest представляет запрос на создание начальных грядок
-type BedInitialRequest struct {
-	Count int `json:"count" example:"5"`
+// FarmExpandRequest представляет запрос на расширение поля
+type FarmExpandRequest struct {
+	Direction string `json:"direction" example:"row"`
 }
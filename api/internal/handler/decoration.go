@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/labstack/echo/v4"
+)
+
+type DecorationHandler struct {
+	BaseHandler
+	repo *repository.DecorationRepo
+}
+
+func NewDecorationHandler(repo *repository.DecorationRepo) *DecorationHandler {
+	return &DecorationHandler{repo: repo}
+}
+
+// PlaceDecorationRequest представляет запрос на покупку и установку декорации
+type PlaceDecorationRequest struct {
+	DecorationID int `json:"decorationId" example:"3"`
+	X            int `json:"x" example:"1"`
+	Y            int `json:"y" example:"0"`
+}
+
+// MoveDecorationRequest представляет запрос на перемещение декорации
+type MoveDecorationRequest struct {
+	X int `json:"x" example:"2"`
+	Y int `json:"y" example:"1"`
+}
+
+// GetCatalog godoc
+// @Summary Каталог декораций
+// @Description Возвращает декорации, которые можно купить и поставить на поле. Некоторые дают пассивный бонус соседним грядкам (bonusType: growth_boost или harvest_yield)
+// @Tags decorations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} model.Decoration
+// @Failure 500 {object} map[string]string
+// @Router /decorations [get]
+func (h *DecorationHandler) GetCatalog(c echo.Context) error {
+	decorations, err := h.repo.GetCatalog(context.Background())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, decorations)
+}
+
+// GetPlaced godoc
+// @Summary Декорации на поле
+// @Description Возвращает декорации, поставленные текущим пользователем на поле, с координатами
+// @Tags decorations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} model.PlacedDecoration
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /decorations/placed [get]
+func (h *DecorationHandler) GetPlaced(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	placed, err := h.repo.GetPlaced(context.Background(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, placed)
+}
+
+// Place godoc
+// @Summary Поставить декорацию
+// @Description Покупает декорацию за золото и ставит ее на свободную клетку поля (x, y): на грядке не должно быть растения и другой декорации
+// @Tags decorations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param request body PlaceDecorationRequest true "Декорация и координаты"
+// @Success 200 {object} model.DecorationChange
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /decorations/placed [post]
+func (h *DecorationHandler) Place(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req PlaceDecorationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	if req.DecorationID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "decorationId is required"})
+	}
+	if req.X < 0 || req.Y < 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid coordinates"})
+	}
+
+	change, err := h.repo.Place(context.Background(), userID, req.DecorationID, req.X, req.Y)
+	if err != nil {
+		return decorationError(c, err)
+	}
+	return c.JSON(http.StatusOK, change)
+}
+
+// Move godoc
+// @Summary Переместить декорацию
+// @Description Переносит поставленную декорацию на другую свободную клетку поля (x, y)
+// @Tags decorations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Placed decoration ID"
+// @Param request body MoveDecorationRequest true "Новые координаты"
+// @Success 200 {object} model.DecorationChange
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /decorations/placed/{id} [put]
+func (h *DecorationHandler) Move(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid decoration ID"})
+	}
+
+	var req MoveDecorationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	if req.X < 0 || req.Y < 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid coordinates"})
+	}
+
+	change, err := h.repo.Move(context.Background(), userID, id, req.X, req.Y)
+	if err != nil {
+		return decorationError(c, err)
+	}
+	return c.JSON(http.StatusOK, change)
+}
+
+// Remove godoc
+// @Summary Убрать декорацию
+// @Description Убирает декорацию с поля и возвращает половину ее стоимости
+// @Tags decorations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Placed decoration ID"
+// @Success 200 {object} model.DecorationChange
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /decorations/placed/{id} [delete]
+func (h *DecorationHandler) Remove(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid decoration ID"})
+	}
+
+	change, err := h.repo.Remove(context.Background(), userID, id)
+	if err != nil {
+		return decorationError(c, err)
+	}
+	return c.JSON(http.StatusOK, change)
+}
+
+// decorationError преобразует ошибку репозитория декораций в HTTP-ответ
+func decorationError(c echo.Context, err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": msg})
+	case strings.Contains(msg, "outside the farm"), strings.Contains(msg, "occupied"), strings.Contains(msg, "not enough gold"):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": msg})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": msg})
+	}
+}
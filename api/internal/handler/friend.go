@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type FriendHandler struct {
+	BaseHandler
+	friendRepo    *repository.FriendRepo
+	friendService *service.FriendService
+}
+
+func NewFriendHandler(friendRepo *repository.FriendRepo, friendService *service.FriendService) *FriendHandler {
+	return &FriendHandler{
+		friendRepo:    friendRepo,
+		friendService: friendService,
+	}
+}
+
+// GetFriends godoc
+// @Summary Получить список друзей
+// @Description Возвращает друзей текущего пользователя с их уровнем
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} model.Friend
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /friends [get]
+func (h *FriendHandler) GetFriends(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	friends, err := h.friendRepo.GetFriends(context.Background(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, friends)
+}
+
+// GetRequests godoc
+// @Summary Получить заявки в друзья
+// @Description Возвращает входящие и исходящие заявки в друзья, ожидающие ответа
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {object} model.FriendRequests
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /friends/requests [get]
+func (h *FriendHandler) GetRequests(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	requests, err := h.friendRepo.GetRequests(context.Background(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, requests)
+}
+
+// SendRequest godoc
+// @Summary Отправить заявку в друзья
+// @Description Отправляет заявку в друзья пользователю по его Max ID. Если этот пользователь уже отправил встречную заявку, пользователи сразу становятся друзьями (accepted = true). Повторная заявка или заявка существующему другу — 409
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param request body FriendRequestCreateRequest true "Max ID пользователя"
+// @Success 201 {object} FriendRequestResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /friends/requests [post]
+func (h *FriendHandler) SendRequest(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req FriendRequestCreateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if req.MaxID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "maxId is required"})
+	}
+
+	request, accepted, err := h.friendService.SendRequest(context.Background(), userID, req.MaxID)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		case strings.Contains(err.Error(), "yourself"):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case strings.Contains(err.Error(), "already"):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, FriendRequestResponse{
+		Request:  request,
+		Accepted: accepted,
+	})
+}
+
+// AcceptRequest godoc
+// @Summary Принять заявку в друзья
+// @Description Принимает входящую заявку в друзья
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Request ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /friends/requests/{id}/accept [post]
+func (h *FriendHandler) AcceptRequest(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request ID"})
+	}
+
+	if err := h.friendRepo.Accept(context.Background(), userID, id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeclineRequest godoc
+// @Summary Отклонить заявку в друзья
+// @Description Отклоняет входящую или отменяет исходящую заявку в друзья
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Request ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /friends/requests/{id}/decline [post]
+func (h *FriendHandler) DeclineRequest(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request ID"})
+	}
+
+	if err := h.friendRepo.Decline(context.Background(), userID, id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RemoveFriend godoc
+// @Summary Удалить из друзей
+// @Description Удаляет пользователя из друзей
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Friend user ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /friends/{id} [delete]
+func (h *FriendHandler) RemoveFriend(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	friendID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid friend ID"})
+	}
+
+	if err := h.friendRepo.Remove(context.Background(), userID, friendID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetFriendFarm godoc
+// @Summary Посмотреть ферму друга
+// @Description Возвращает ферму друга только для просмотра с учетом его настройки приватности: при full — уровень, статистику, грядки и растения; при level_only — только уровень и статистику; при hidden — 403
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Friend user ID"
+// @Success 200 {object} model.FriendFarm
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /friends/{id}/farm [get]
+func (h *FriendHandler) GetFriendFarm(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	friendID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid friend ID"})
+	}
+
+	farm, err := h.friendService.GetFarm(context.Background(), userID, friendID)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		case strings.Contains(err.Error(), "privacy"):
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, farm)
+}
+
+// DTO для запросов
+
+// FriendRequestCreateRequest представляет запрос на отправку заявки в друзья
+type FriendRequestCreateRequest struct {
+	MaxID int64 `json:"maxId" example:"123456789"`
+}
+
+// FriendRequestResponse представляет ответ на отправку заявки в друзья
+type FriendRequestResponse struct {
+	Request  *model.FriendRequest `json:"request"`
+	Accepted bool                 `json:"accepted"` // Встречная заявка принята, пользователи стали друзьями
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type GiftHandler struct {
+	BaseHandler
+	giftRepo    *repository.GiftRepo
+	giftService *service.GiftService
+}
+
+func NewGiftHandler(giftRepo *repository.GiftRepo, giftService *service.GiftService) *GiftHandler {
+	return &GiftHandler{
+		giftRepo:    giftRepo,
+		giftService: giftService,
+	}
+}
+
+// GetGifts godoc
+// @Summary Получить подарки
+// @Description Возвращает входящие и исходящие подарки, ожидающие ответа
+// @Tags gifts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {object} model.Gifts
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /gifts [get]
+func (h *GiftHandler) GetGifts(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	gifts, err := h.giftRepo.GetPending(context.Background(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, gifts)
+}
+
+// SendGift godoc
+// @Summary Отправить подарок
+// @Description Дарит другу семена (kind = seed) или урожай из амбара (kind = produce). Подарок сразу списывается у отправителя и возвращается ему при отклонении или отмене. Дарить можно только друзьям; число подарков и предложений обмена за день ограничено для отправителя и получателя (429)
+// @Tags gifts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param request body GiftCreateRequest true "Данные подарка"
+// @Success 201 {object} model.Gift
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /gifts [post]
+func (h *GiftHandler) SendGift(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req GiftCreateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if req.RecipientID <= 0 || req.SeedID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "recipientId and seedId are required"})
+	}
+
+	gift, err := h.giftService.SendGift(context.Background(), userID, model.Gift{
+		RecipientID: req.RecipientID,
+		Kind:        req.Kind,
+		SeedID:      req.SeedID,
+		Amount:      req.Amount,
+	})
+	if err != nil {
+		return exchangeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, gift)
+}
+
+// AcceptGift godoc
+// @Summary Принять подарок
+// @Description Принимает входящий подарок и добавляет семена или урожай в инвентарь
+// @Tags gifts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Gift ID"
+// @Success 200 {object} model.Gift
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /gifts/{id}/accept [post]
+func (h *GiftHandler) AcceptGift(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid gift ID"})
+	}
+
+	gift, err := h.giftRepo.Accept(context.Background(), userID, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, gift)
+}
+
+// DeclineGift godoc
+// @Summary Отклонить подарок
+// @Description Отклоняет входящий или отменяет исходящий подарок; подарок возвращается отправителю
+// @Tags gifts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Gift ID"
+// @Success 200 {object} model.Gift
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /gifts/{id}/decline [post]
+func (h *GiftHandler) DeclineGift(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid gift ID"})
+	}
+
+	gift, err := h.giftRepo.Decline(context.Background(), userID, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, gift)
+}
+
+// exchangeError преобразует ошибку отправки подарка или обмена в ответ с подходящим статусом
+func exchangeError(c echo.Context, err error) error {
+	switch {
+	case strings.Contains(err.Error(), "invalid"):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case strings.Contains(err.Error(), "not a friend"):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	case strings.Contains(err.Error(), "limit reached"):
+		return c.JSON(http.StatusTooManyRequests, map[string]string{"error": err.Error()})
+	case strings.Contains(err.Error(), "not enough"):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
+
+// DTO для запросов
+
+// GiftCreateRequest представляет запрос на отправку подарка
+type GiftCreateRequest struct {
+	RecipientID int64  `json:"recipientId" example:"2"`
+	Kind        string `json:"kind" example:"seed"` // seed, produce
+	SeedID      int    `json:"seedId" example:"1"`
+	Amount      int    `json:"amount" example:"3"`
+}
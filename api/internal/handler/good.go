@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/RinatHar/FarmFocus/api/internal/repository"
@@ -12,26 +13,20 @@ import (
 
 type GoodHandler struct {
 	BaseHandler
-	repo         *repository.GoodRepo
-	userStatRepo *repository.UserStatRepo
-	userSeedRepo *repository.UserSeedRepo
-	bedRepo      *repository.BedRepo
-	seedRepo     *repository.SeedRepo
+	repo     *repository.GoodRepo
+	seedRepo *repository.SeedRepo
+	itemRepo *repository.ItemRepo
 }
 
 func NewGoodHandler(
 	repo *repository.GoodRepo,
-	userStatRepo *repository.UserStatRepo,
-	userSeedRepo *repository.UserSeedRepo,
-	bedRepo *repository.BedRepo,
 	seedRepo *repository.SeedRepo,
+	itemRepo *repository.ItemRepo,
 ) *GoodHandler {
 	return &GoodHandler{
-		repo:         repo,
-		userStatRepo: userStatRepo,
-		userSeedRepo: userSeedRepo,
-		bedRepo:      bedRepo,
-		seedRepo:     seedRepo,
+		repo:     repo,
+		seedRepo: seedRepo,
+		itemRepo: itemRepo,
 	}
 }
 
@@ -106,8 +101,9 @@ func (h *GoodHandler) GetUserGoodsByType(c echo.Context) error {
 // @Success 201 {object} model.Good
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /goods [post]
+// @Router /admin/goods [post]
 func (h *GoodHandler) CreateGood(c echo.Context) error {
 	userID, err := h.GetUserIDFromContext(c)
 	if err != nil {
@@ -153,9 +149,10 @@ func (h *GoodHandler) CreateGood(c echo.Context) error {
 // @Success 200 {object} model.Good
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /goods/{id}/quantity [put]
+// @Router /admin/goods/{id}/quantity [put]
 func (h *GoodHandler) UpdateGoodQuantity(c echo.Context) error {
 	userID, err := h.GetUserIDFromContext(c)
 	if err != nil {
@@ -203,9 +200,10 @@ func (h *GoodHandler) UpdateGoodQuantity(c echo.Context) error {
 // @Success 200 {object} model.Good
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /goods/{id}/cost [put]
+// @Router /admin/goods/{id}/cost [put]
 func (h *GoodHandler) UpdateGoodCost(c echo.Context) error {
 	userID, err := h.GetUserIDFromContext(c)
 	if err != nil {
@@ -240,56 +238,6 @@ func (h *GoodHandler) UpdateGoodCost(c echo.Context) error {
 	return c.JSON(http.StatusOK, updatedGood)
 }
 
-// AddQuantity godoc
-// @Summary Добавить количество товара
-// @Description Добавляет указанное количество к существующему товару
-// @Tags goods
-// @Accept json
-// @Produce json
-// @Security ApiKeyAuth
-// @Param X-User-ID header string true "User ID"
-// @Param id path int true "Good ID"
-// @Param request body AddQuantityRequest true "Количество для добавления"
-// @Success 200 {object} model.Good
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /goods/{id}/add-quantity [patch]
-func (h *GoodHandler) AddQuantity(c echo.Context) error {
-	userID, err := h.GetUserIDFromContext(c)
-	if err != nil {
-		return err
-	}
-
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid good ID"})
-	}
-
-	var req AddQuantityRequest
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
-	}
-
-	// Проверяем, что товар принадлежит пользователю
-	good, err := h.repo.GetByID(context.Background(), id)
-	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
-	}
-
-	if good.UserID != userID {
-		return c.JSON(http.StatusForbidden, map[string]string{"error": "access denied"})
-	}
-
-	if err := h.repo.AddQuantity(context.Background(), id, req.Amount); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	updatedGood, _ := h.repo.GetByID(context.Background(), id)
-	return c.JSON(http.StatusOK, updatedGood)
-}
-
 // DeleteGood godoc
 // @Summary Удалить товар
 // @Description Удаляет товар по ID
@@ -302,9 +250,10 @@ func (h *GoodHandler) AddQuantity(c echo.Context) error {
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /goods/{id} [delete]
+// @Router /admin/goods/{id} [delete]
 func (h *GoodHandler) DeleteGood(c echo.Context) error {
 	userID, err := h.GetUserIDFromContext(c)
 	if err != nil {
@@ -345,8 +294,9 @@ func (h *GoodHandler) DeleteGood(c echo.Context) error {
 // @Success 201 {array} model.Good
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /goods/batch [post]
+// @Router /admin/goods/batch [post]
 func (h *GoodHandler) CreateBatchGoods(c echo.Context) error {
 	userID, err := h.GetUserIDFromContext(c)
 	if err != nil {
@@ -412,62 +362,29 @@ func (h *GoodHandler) BuyGood(c echo.Context) error {
 
 	ctx := context.Background()
 
-	// Получаем товар
-	good, err := h.repo.GetByID(ctx, goodID)
-	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{"error": "good not found"})
-	}
-
-	// Проверяем, что товар принадлежит пользователю (магазин пользователя)
-	if good.UserID != userID {
-		return c.JSON(http.StatusForbidden, map[string]string{"error": "access denied"})
-	}
-
-	// Проверяем доступное количество
-	if good.Quantity < 1 {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "good is out of stock",
-		})
-	}
-
-	// Получаем статистику пользователя для проверки золота
-	stat, err := h.userStatRepo.GetByUserID(ctx, userID)
+	// Списание золота, остаток товара и инвентарь меняются в одной транзакции
+	purchase, err := h.repo.Buy(ctx, userID, goodID)
 	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "good not found"})
+		case strings.Contains(err.Error(), "access denied"):
+			return c.JSON(http.StatusForbidden, map[string]string{"error": "access denied"})
+		case strings.Contains(err.Error(), "out of stock"),
+			strings.Contains(err.Error(), "not enough gold"),
+			strings.Contains(err.Error(), "no locked beds"),
+			strings.Contains(err.Error(), "unsupported good type"):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-
-	// Проверяем достаточно ли золота
-	if stat.Gold < int64(good.Cost) {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "not enough gold"})
-	}
-
-	// Списываем золото
-	if err := h.userStatRepo.AddGold(ctx, userID, -int64(good.Cost)); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to deduct gold"})
-	}
-
-	// Уменьшаем количество товара в магазине на 1
-	newQuantity := good.Quantity - 1
-	if err := h.repo.UpdateQuantity(ctx, goodID, newQuantity); err != nil {
-		// Если не удалось обновить количество, возвращаем золото
-		h.userStatRepo.AddGold(ctx, userID, int64(good.Cost))
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to update good quantity"})
-	}
+	good := purchase.Good
 
 	var purchasedItem interface{}
 
-	// Добавляем товар в инвентарь пользователя в зависимости от типа
+	// Детали купленного предмета для ответа
 	switch good.Type {
 	case "seed":
-		// Добавляем семена в инвентарь
-		if err := h.userSeedRepo.AddOrUpdateQuantity(ctx, userID, good.IDGood, 1); err != nil {
-			// Если не удалось добавить в инвентарь, возвращаем товар и золото
-			h.repo.UpdateQuantity(ctx, goodID, good.Quantity)
-			h.userStatRepo.AddGold(ctx, userID, int64(good.Cost))
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to add seeds to inventory"})
-		}
-
-		// Получаем детали семени для ответа
 		seed, err := h.seedRepo.GetByID(ctx, good.IDGood)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to get seed details"})
@@ -483,33 +400,29 @@ func (h *GoodHandler) BuyGood(c echo.Context) error {
 		}
 
 	case "bed":
-		// Разблокируем грядку
-		unlockedBed, err := h.bedRepo.UnlockNextBed(ctx, userID)
-		if err != nil {
-			// Если не удалось разблокировать, возвращаем товар и золото
-			h.repo.UpdateQuantity(ctx, goodID, good.Quantity)
-			h.userStatRepo.AddGold(ctx, userID, int64(good.Cost))
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to unlock bed"})
-		}
-
+		unlockedBed := purchase.UnlockedBed
 		purchasedItem = UnlockedBedInfo{
 			ID:         unlockedBed.ID,
 			CellNumber: unlockedBed.CellNumber,
+			X:          unlockedBed.X,
+			Y:          unlockedBed.Y,
 			IsLocked:   unlockedBed.IsLocked,
 		}
 
-	default:
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported good type"})
-	}
+	case "consumable", "fertilizer", "tool":
+		item, err := h.itemRepo.GetByID(ctx, good.IDGood)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to get item details"})
+		}
 
-	// Получаем обновленный товар
-	updatedGood, _ := h.repo.GetByID(ctx, goodID)
+		purchasedItem = newItemInfo(item)
+	}
 
 	return c.JSON(http.StatusOK, BuyGoodResponse{
 		GoodID:        goodID,
 		Quantity:      1,
 		TotalCost:     good.Cost,
-		Remaining:     updatedGood.Quantity,
+		Remaining:     good.Quantity,
 		Message:       "Purchase successful",
 		ItemType:      good.Type,
 		ItemID:        good.IDGood,
@@ -521,7 +434,7 @@ func (h *GoodHandler) BuyGood(c echo.Context) error {
 
 func isValidGoodType(goodType string) bool {
 	validTypes := map[string]bool{
-		"seed": true, "bed": true, "tool": true, "fertilizer": true,
+		"seed": true, "bed": true, "consumable": true, "tool": true, "fertilizer": true,
 	}
 	return validTypes[goodType]
 }
@@ -540,6 +453,8 @@ type BuyGoodResponse struct {
 type UnlockedBedInfo struct {
 	ID         int  `json:"id"`
 	CellNumber int  `json:"cellNumber"`
+	X          int  `json:"x"`
+	Y          int  `json:"y"`
 	IsLocked   bool `json:"isLocked"`
 }
 
@@ -557,7 +472,3 @@ type UpdateQuantityRequest struct {
 type UpdateCostRequest struct {
 	Cost int `json:"cost" binding:"required,min=0"`
 }
-
-type AddQuantityRequest struct {
-	Amount int `json:"amount" binding:"required,min=1"`
-}
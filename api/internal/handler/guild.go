@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type GuildHandler struct {
+	BaseHandler
+	guildService *service.GuildService
+}
+
+func NewGuildHandler(guildService *service.GuildService) *GuildHandler {
+	return &GuildHandler{guildService: guildService}
+}
+
+// CreateGuild godoc
+// @Summary Создать гильдию
+// @Description Создает гильдию, владельцем которой становится текущий пользователь, и выдает ей цель на текущую неделю. Пользователь может состоять только в одной гильдии (409); название должно быть уникальным (409)
+// @Tags guilds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param request body GuildCreateRequest true "Название гильдии"
+// @Success 201 {object} model.GuildInfo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /guilds [post]
+func (h *GuildHandler) CreateGuild(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req GuildCreateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	guild, err := h.guildService.Create(context.Background(), userID, req.Name)
+	if err != nil {
+		return guildError(c, err)
+	}
+	return c.JSON(http.StatusCreated, guild)
+}
+
+// GetMyGuild godoc
+// @Summary Получить свою гильдию
+// @Description Возвращает гильдию текущего пользователя с участниками, их вкладом и целью текущей недели
+// @Tags guilds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {object} model.GuildInfo
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /guilds/me [get]
+func (h *GuildHandler) GetMyGuild(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	guild, err := h.guildService.GetMine(context.Background(), userID)
+	if err != nil {
+		return guildError(c, err)
+	}
+	return c.JSON(http.StatusOK, guild)
+}
+
+// GetGuild godoc
+// @Summary Получить гильдию
+// @Description Возвращает гильдию с участниками, их вкладом и целью текущей недели
+// @Tags guilds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Guild ID"
+// @Success 200 {object} model.GuildInfo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /guilds/{id} [get]
+func (h *GuildHandler) GetGuild(c echo.Context) error {
+	if _, err := h.GetUserIDFromContext(c); err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid guild ID"})
+	}
+
+	guild, err := h.guildService.Get(context.Background(), id)
+	if err != nil {
+		return guildError(c, err)
+	}
+	return c.JSON(http.StatusOK, guild)
+}
+
+// JoinGuild godoc
+// @Summary Вступить в гильдию
+// @Description Добавляет текущего пользователя в гильдию. Нельзя вступить, если пользователь уже состоит в гильдии или гильдия заполнена (409). Вклад в цель текущей недели новый участник может вносить только со следующей недели
+// @Tags guilds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Guild ID"
+// @Success 200 {object} model.GuildInfo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /guilds/{id}/join [post]
+func (h *GuildHandler) JoinGuild(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid guild ID"})
+	}
+
+	guild, err := h.guildService.Join(context.Background(), userID, id)
+	if err != nil {
+		return guildError(c, err)
+	}
+	return c.JSON(http.StatusOK, guild)
+}
+
+// LeaveGuild godoc
+// @Summary Покинуть гильдию
+// @Description Удаляет текущего пользователя из гильдии. Если уходит владелец, владельцем становится участник, вступивший раньше остальных; гильдия без участников удаляется. Вклад ушедшего участника остается в цели, но награду за нее он не получит
+// @Tags guilds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /guilds/leave [post]
+func (h *GuildHandler) LeaveGuild(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.guildService.Leave(context.Background(), userID); err != nil {
+		return guildError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Contribute godoc
+// @Summary Внести вклад в цель гильдии
+// @Description Сдает урожай из амбара в недельную цель гильдии. Принимается не больше, чем осталось до цели (amount в ответе). Вносить вклад могут только участники, вступившие в гильдию до выдачи цели текущей недели; вступившие позже вносят вклад со следующей недели (409). Когда цель выполнена, награду вместе с наградами за достигнутые уровни получает каждый такой участник, внесший не меньше 10% цели; при повышении уровня текущего пользователя возвращает блок levelUp. Нехватка урожая или уже выполненная цель — 409
+// @Tags guilds
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param request body GuildContributeRequest true "Количество урожая"
+// @Success 200 {object} model.GuildContribution
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /guilds/contribute [post]
+func (h *GuildHandler) Contribute(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req GuildContributeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	contribution, err := h.guildService.Contribute(context.Background(), userID, req.Amount)
+	if err != nil {
+		return guildError(c, err)
+	}
+	return c.JSON(http.StatusOK, contribution)
+}
+
+// guildError преобразует ошибку гильдии в ответ с подходящим статусом
+func guildError(c echo.Context, err error) error {
+	switch {
+	case strings.Contains(err.Error(), "invalid"):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case strings.Contains(err.Error(), "not found"), strings.Contains(err.Error(), "not in a guild"):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case strings.Contains(err.Error(), "already"), strings.Contains(err.Error(), "full"),
+		strings.Contains(err.Error(), "not enough"), strings.Contains(err.Error(), "next week"):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
+
+// DTO для запросов
+
+// GuildCreateRequest представляет запрос на создание гильдии
+type GuildCreateRequest struct {
+	Name string `json:"name" example:"Зеленые грядки"`
+}
+
+// GuildContributeRequest представляет запрос на вклад в цель гильдии
+type GuildContributeRequest struct {
+	Amount int `json:"amount" example:"20"`
+}
@@ -2,41 +2,56 @@ package handler
 
 import (
 	"context"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
 	"github.com/RinatHar/FarmFocus/api/internal/utils"
 	"github.com/labstack/echo/v4"
 )
 
 type HabitHandler struct {
 	BaseHandler
-	repo          *repository.HabitRepo
-	progressRepo  *repository.ProgressLogRepo
-	userStatRepo  *repository.UserStatRepo
-	userPlantRepo *repository.UserPlantRepo
+	repo         *repository.HabitRepo
+	progressRepo *repository.ProgressLogRepo
+	userStatRepo *repository.UserStatRepo
+	tagRepo      *repository.TagRepo
+	levelService *service.LevelService
+	rewardLimits model.RewardLimits
+	achievements *service.AchievementService
+	rewards      map[string]constants.DifficultyReward
 }
 
 func NewHabitHandler(
 	repo *repository.HabitRepo,
 	progressRepo *repository.ProgressLogRepo,
 	userStatRepo *repository.UserStatRepo,
-	userPlantRepo *repository.UserPlantRepo) *HabitHandler {
+	tagRepo *repository.TagRepo,
+	levelService *service.LevelService,
+	rewardLimits model.RewardLimits,
+	achievements *service.AchievementService,
+	rewards map[string]constants.DifficultyReward) *HabitHandler {
 	return &HabitHandler{
-		repo:          repo,
-		progressRepo:  progressRepo,
-		userStatRepo:  userStatRepo,
-		userPlantRepo: userPlantRepo,
+		repo:         repo,
+		progressRepo: progressRepo,
+		userStatRepo: userStatRepo,
+		tagRepo:      tagRepo,
+		levelService: levelService,
+		rewardLimits: rewardLimits,
+		achievements: achievements,
+		rewards:      rewards,
 	}
 }
 
 // Create godoc
 // @Summary Создать новую привычку
-// @Description Создает новую привычку для текущего пользователя с базовым количеством опыта с учетом count
+// @Description Создает новую привычку для текущего пользователя с базовым количеством опыта по сложности; счетчик начинается с 0
 // @Tags habits
 // @Accept json
 // @Produce json
@@ -59,8 +74,8 @@ func (h *HabitHandler) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
-	// Устанавливаем базовое количество опыта с учетом сложности и count
-	baseXP := utils.GetBaseXPForHabit(req.Difficulty, req.Count)
+	// Устанавливаем базовое количество опыта по сложности; счетчик новой привычки начинается с 0
+	baseXP := utils.GetBaseXPForHabit(utils.GetDifficultyReward(h.rewards, req.Difficulty), 0)
 
 	habit := model.Habit{
 		UserID:      userID,
@@ -68,15 +83,22 @@ func (h *HabitHandler) Create(c echo.Context) error {
 		Description: req.Description,
 		Difficulty:  req.Difficulty,
 		Done:        false,
-		Count:       req.Count,
+		Count:       0,
 		Period:      req.Period,
 		Every:       req.Every,
 		StartDate:   req.StartDate,
 		XPReward:    baseXP,
-		TagID:       req.TagID,
+		TagIDs:      mergeTagIDs(req.TagIDs, req.TagID),
 		CreatedAt:   time.Now(),
 	}
 
+	if err := validateTagOwnership(context.Background(), h.tagRepo, userID, habit.TagIDs); err != nil {
+		if strings.Contains(err.Error(), "invalid tag IDs") {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
 	if err := h.repo.Create(context.Background(), &habit); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -93,8 +115,11 @@ func (h *HabitHandler) Create(c echo.Context) error {
 // @Param X-User-ID header string true "User ID"
 // @Param done query boolean false "Фильтр по статусу выполнения"
 // @Param period query string false "Фильтр по периоду (day/week/month)"
-// @Param tag_id query integer false "Фильтр по ID тега"
+// @Param tag_id query integer false "Фильтр по ID тега (устаревший, используйте tag_ids)"
+// @Param tag_ids query string false "Фильтр по списку ID тегов через запятую (например: 1,2,3)"
+// @Param tag_match query string false "Режим фильтра по тегам: any (хотя бы один тег, по умолчанию) или all (все теги)" Enums(any, all)
 // @Success 200 {array} model.Habit
+// @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /habits [get]
@@ -107,21 +132,20 @@ func (h *HabitHandler) GetAll(c echo.Context) error {
 	// Обработка query параметров
 	doneStr := c.QueryParam("done")
 	period := c.QueryParam("period")
-	tagIDStr := c.QueryParam("tag_id")
+	tagIDs, matchAll, err := parseTagFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 
 	var habits []model.Habit
 
-	if tagIDStr != "" {
-		tagID, err := strconv.Atoi(tagIDStr)
-		if err != nil {
-			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid tag ID"})
-		}
-		habits, err = h.repo.GetByTag(context.Background(), userID, tagID)
+	if len(tagIDs) > 0 {
+		habits, err = h.repo.GetByTags(context.Background(), userID, tagIDs, matchAll)
 	} else if period != "" {
 		habits, err = h.repo.GetByPeriod(context.Background(), userID, period)
 	} else if doneStr != "" {
-		done, err := strconv.ParseBool(doneStr)
-		if err != nil {
+		done, parseErr := strconv.ParseBool(doneStr)
+		if parseErr != nil {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid done value"})
 		}
 		habits, err = h.repo.GetByStatus(context.Background(), userID, done)
@@ -173,7 +197,7 @@ func (h *HabitHandler) GetByID(c echo.Context) error {
 
 // Update godoc
 // @Summary Обновить привычку
-// @Description Обновляет информацию о привычке
+// @Description Обновляет информацию о привычке. Отметка выполнения и счетчик меняются только выполнением и его отменой; базовый опыт пересчитывается по сложности и счетчику
 // @Tags habits
 // @Accept json
 // @Produce json
@@ -203,19 +227,33 @@ func (h *HabitHandler) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
+	// Отметка выполнения и счетчик меняются только выполнением и его отменой
+	current, err := h.repo.GetByID(context.Background(), id, userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
 	habit := model.Habit{
 		ID:          id,
 		UserID:      userID,
 		Title:       req.Title,
 		Description: req.Description,
 		Difficulty:  req.Difficulty,
-		Done:        req.Done,
-		Count:       req.Count,
 		Period:      req.Period,
 		Every:       req.Every,
 		StartDate:   req.StartDate,
-		XPReward:    req.XPReward,
-		TagID:       req.TagID,
+		XPReward:    utils.GetBaseXPForHabit(utils.GetDifficultyReward(h.rewards, req.Difficulty), current.Count), // Базовый опыт задается только сервером
+		TagIDs:      mergeTagIDs(req.TagIDs, req.TagID),
+	}
+
+	if err := validateTagOwnership(context.Background(), h.tagRepo, userID, habit.TagIDs); err != nil {
+		if strings.Contains(err.Error(), "invalid tag IDs") {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
 	if err := h.repo.Update(context.Background(), &habit); err != nil {
@@ -229,7 +267,7 @@ func (h *HabitHandler) Update(c echo.Context) error {
 
 // Delete godoc
 // @Summary Удалить привычку
-// @Description Удаляет привычку по ID, предварительно удаляя связанные записи в логе прогресса
+// @Description Удаляет привычку по ID. История ее выполнений сохраняется и продолжает учитываться в дневных ограничениях наград, стрике и рейтингах
 // @Tags habits
 // @Accept json
 // @Produce json
@@ -261,11 +299,6 @@ func (h *HabitHandler) Delete(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	// Удаляем связанные записи в progress_log
-	if err := h.progressRepo.DeleteByHabitID(context.Background(), id); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
 	// Удаляем привычку
 	err = h.repo.Delete(context.Background(), id, userID)
 	if err != nil {
@@ -277,7 +310,7 @@ func (h *HabitHandler) Delete(c echo.Context) error {
 
 // MarkAsDone godoc
 // @Summary Пометить привычку как выполненную
-// @Description Помечает привычку как выполненную, увеличивает счетчик, добавляет опыт пользователю по формуле, создает запись в логе прогресса, увеличивает рост всех активных растений пользователя на 1 и увеличивает стрик если это первое выполнение сегодня
+// @Description Помечает привычку как выполненную, увеличивает счетчик, начисляет опыт и золото по таблице наград сложности, создает запись в логе прогресса, увеличивает рост всех активных растений пользователя на очки роста сложности с учетом множителя роста семени и засухи, увеличивает стрик (каждые 7 дней подряд выдается лейка) и ослабляет засуху на одну ступень если это первое выполнение сегодня и возвращает блок levelUp при повышении уровня. После нескольких выполнений за день награды убывают, а опыт и очки роста сверх мягкого дневного лимита начисляются частично — в этом случае возвращается блок rewardCap. Открытые выполнением достижения возвращаются в achievements (награды за них уже выданы), выполненные ежедневные задания — в completedQuests. Отмечать выполнение и отмену можно ограниченное число раз в минуту (429)
 // @Tags habits
 // @Accept json
 // @Produce json
@@ -288,6 +321,7 @@ func (h *HabitHandler) Delete(c echo.Context) error {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /habits/{id}/done [patch]
 func (h *HabitHandler) MarkAsDone(c echo.Context) error {
@@ -323,77 +357,64 @@ func (h *HabitHandler) MarkAsDone(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	// Рассчитываем опыт по формуле: базовый опыт * (коэф уровня + коэф сложности)
+	// Опыт, золото и очки роста берутся из таблицы наград по сложности.
+	// Опыт рассчитывается по формуле: базовый опыт * (коэф уровня + коэф сложности)
+	reward := utils.GetDifficultyReward(h.rewards, habit.Difficulty)
 	userLevel := stats.Level()
-	calculatedXP := utils.CalculateTaskXP(habit.XPReward, userLevel, habit.Difficulty)
-
-	// Проверяем, выполнены ли сегодня задачи или привычки
-	hasCompletedTaskToday, err := h.progressRepo.HasUserCompletedTaskToday(context.Background(), userID)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	hasCompletedHabitToday, err := h.progressRepo.HasUserCompletedHabitToday(context.Background(), userID)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	// Если сегодня еще не выполнено ни задач, ни привычек - увеличиваем стрик
-	shouldIncrementStreak := !hasCompletedTaskToday && !hasCompletedHabitToday
-
-	// Если это первое выполнение сегодня и пользователь в засухе - сбрасываем засуху
-	if shouldIncrementStreak && stats.IsDrought {
-		h.userStatRepo.ResetDrought(context.Background(), userID)
-	}
-
-	// Помечаем привычку как выполненную и увеличиваем счетчик
-	err = h.repo.MarkAsDoneAndIncrementCount(context.Background(), id, userID)
+	calculatedXP := utils.CalculateTaskXP(habit.XPReward, userLevel, reward)
+
+	// Привычка захватывается условным обновлением, а опыт, золото, рост растений, стрик и засуха
+	// применяются и записываются в одной транзакции. Убывающая отдача и мягкие дневные лимиты
+	// против спама простыми задачами считаются там же, под блокировкой пользователя
+	result, err := h.progressRepo.Complete(context.Background(), &model.Completion{
+		UserID:          userID,
+		HabitID:         &id,
+		Reward:          model.CompletionReward{XP: calculatedXP, Gold: reward.Gold, Growth: reward.Growth},
+		Limits:          h.rewardLimits,
+		GoldDescription: "Выполнение привычки «" + habit.Title + "»",
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	// Увеличиваем стрик если это первое выполнение сегодня
-	if shouldIncrementStreak {
-		if err := h.userStatRepo.IncrementStreak(context.Background(), userID); err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		if strings.Contains(err.Error(), "already marked as done") {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Habit is already marked as done",
+			})
+		}
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
 		}
-	}
-
-	// Создаем запись в progress_log
-	progressLog := &model.ProgressLog{
-		UserID:     userID,
-		HabitID:    &id,
-		XPEarned:   calculatedXP,
-		GoldEarned: 0,
-		CreatedAt:  time.Now(),
-	}
-
-	if err := h.progressRepo.Create(context.Background(), progressLog); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	// Добавляем опыт пользователю
-	if calculatedXP > 0 {
-		if err := h.userStatRepo.AddExperience(context.Background(), userID, int64(calculatedXP)); err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-		}
+	// Награды за новые уровни уже выданы в транзакции выполнения, здесь только собираем их описание.
+	// Выполнение уже сохранено, поэтому ошибки ниже не превращают ответ в 500, а только логируются
+	levelUp, err := h.levelService.LevelUp(context.Background(), result.Experience)
+	if err != nil {
+		log.Printf("Error describing level up for user %d after completing habit %d: %v", userID, id, err)
 	}
 
-	// Увеличиваем рост всех активных растений пользователя на 1
-	plantsGrown, err := h.growUserPlants(context.Background(), userID, 1)
+	// Проверяем достижения по обновленным счетчикам выполнений и стрика
+	achievements, err := h.achievements.Evaluate(context.Background(), userID)
 	if err != nil {
-		// Логируем ошибку, но не прерываем выполнение основной операции
+		log.Printf("Error evaluating achievements for user %d after completing habit %d: %v", userID, id, err)
 	}
 
 	return c.JSON(http.StatusOK, HabitCompletionResponse{
-		XPEarned:    calculatedXP,
-		PlantsGrown: plantsGrown,
+		XPEarned:        result.Reward.XP,
+		GoldEarned:      result.Reward.Gold,
+		GrowthPoints:    result.Reward.Growth,
+		PlantsGrown:     len(result.PlantGrowth),
+		LevelUp:         levelUp,
+		Drought:         result.Drought,
+		StreakReward:    result.StreakReward,
+		RewardCap:       result.RewardCap,
+		Achievements:    achievements,
+		CompletedQuests: result.CompletedQuests,
 	})
 }
 
 // MarkAsUndone godoc
 // @Summary Пометить привычку как невыполненную
-// @Description Помечает привычку как невыполненную, уменьшает счетчик и возвращает опыт обратно на основе последней записи в логе
+// @Description Помечает привычку как невыполненную, уменьшает счетчик и в одной транзакции откатывает все эффекты последнего выполнения: опыт, золото, рост растений, увеличение стрика с наградой за стрик, ослабление засухи (если засуха с тех пор не менялась) и прогресс еще не полученных ежедневных заданий. Повторная отмена возвращает 400. Выполнение, выросшее растение которого уже собрано, не отменяется (400): урожай и опыт за сбор уже получены. Также не отменяется выполнение, засчитанное в ежедневное задание, награда за которое уже получена (400): награда за задание обратно не забирается
 // @Tags habits
 // @Accept json
 // @Produce json
@@ -404,6 +425,7 @@ func (h *HabitHandler) MarkAsDone(c echo.Context) error {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /habits/{id}/undone [patch]
 func (h *HabitHandler) MarkAsUndone(c echo.Context) error {
@@ -440,186 +462,58 @@ func (h *HabitHandler) MarkAsUndone(c echo.Context) error {
 		})
 	}
 
-	// Ищем последнюю запись в progress_log для этой привычки
-	lastProgress, err := h.progressRepo.GetLastByHabitID(context.Background(), id)
+	// Снимаем отметку выполнения и откатываем все эффекты последнего выполнения в одной транзакции
+	undo, err := h.progressRepo.UndoHabitCompletion(context.Background(), userID, id,
+		"Отмена выполнения привычки «"+habit.Title+"»")
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if strings.Contains(err.Error(), "cannot be undone") {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if strings.Contains(err.Error(), "completion for") {
 			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "No completion record found for this habit",
+				"error": "Habit was not completed or already undone",
 			})
 		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	// Проверяем, что запись содержит положительный опыт (привычка была выполнена)
-	if lastProgress.XPEarned <= 0 {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Habit was not completed or already undone",
-		})
-	}
-
-	xpToReturn := lastProgress.XPEarned
-
-	// Помечаем привычку как невыполненную и уменьшаем счетчик
-	err = h.repo.MarkAsUndoneAndDecrementCount(context.Background(), id, userID)
-	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
 		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	// Возвращаем опыт (вычитаем)
-	if xpToReturn > 0 {
-		if err := h.userStatRepo.RemoveExperience(context.Background(), userID, int64(xpToReturn)); err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-		}
-	}
-
-	// Создаем запись в progress_log о возврате опыта
-	progressLog := &model.ProgressLog{
-		UserID:     userID,
-		HabitID:    &id,
-		XPEarned:   -xpToReturn, // Отрицательное значение для возврата
-		GoldEarned: 0,
-		CreatedAt:  time.Now(),
-	}
-
-	if err := h.progressRepo.Create(context.Background(), progressLog); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
 	return c.JSON(http.StatusOK, HabitUndoResponse{
-		XPEarned: -xpToReturn,
-	})
-}
-
-// IncrementCount godoc
-// @Summary Увеличить счетчик привычки
-// @Description Увеличивает счетчик привычки на 1
-// @Tags habits
-// @Accept json
-// @Produce json
-// @Security ApiKeyAuth
-// @Param X-User-ID header string true "User ID"
-// @Param id path int true "Habit ID"
-// @Success 200 {object} HabitIncrementResponse
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /habits/{id}/increment [patch]
-func (h *HabitHandler) IncrementCount(c echo.Context) error {
-	userID, err := h.GetUserIDFromContext(c)
-	if err != nil {
-		return err
-	}
-
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid habit ID"})
-	}
-
-	// Увеличиваем счетчик
-	err = h.repo.IncrementCount(context.Background(), id, userID)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	// Получаем обновленную привычку для ответа
-	habit, err := h.repo.GetByID(context.Background(), id, userID)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	return c.JSON(http.StatusOK, HabitIncrementResponse{
-		Count: habit.Count,
+		XPEarned:             -undo.XPReturned,
+		GoldEarned:           -undo.GoldReturned,
+		PlantsReverted:       undo.PlantsReverted,
+		StreakReverted:       undo.StreakReverted,
+		StreakRewardReturned: undo.StreakRewardReturned,
+		DroughtRestored:      undo.DroughtRestored,
 	})
 }
 
-// ResetCount godoc
-// @Summary Сбросить счетчик привычки
-// @Description Сбрасывает счетчик привычки до 0
-// @Tags habits
-// @Accept json
-// @Produce json
-// @Security ApiKeyAuth
-// @Param X-User-ID header string true "User ID"
-// @Param id path int true "Habit ID"
-// @Success 200 {object} HabitResetResponse
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /habits/{id}/reset [patch]
-func (h *HabitHandler) ResetCount(c echo.Context) error {
-	userID, err := h.GetUserIDFromContext(c)
-	if err != nil {
-		return err
-	}
-
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid habit ID"})
-	}
-
-	// Сбрасываем счетчик
-	err = h.repo.ResetCount(context.Background(), id, userID)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	return c.JSON(http.StatusOK, HabitResetResponse{
-		Message: "Habit count reset successfully",
-	})
-}
-
-// growUserPlants увеличивает рост всех активных растений пользователя
-func (h *HabitHandler) growUserPlants(ctx context.Context, userID int64, growthAmount int) (int, error) {
-	userPlants, err := h.userPlantRepo.GetByUser(ctx, userID)
-	if err != nil {
-		return 0, err
-	}
-
-	grownCount := 0
-	for _, plant := range userPlants {
-		_, err := h.userPlantRepo.AddGrowth(ctx, plant.ID, growthAmount)
-		if err != nil {
-			continue
-		}
-		grownCount++
-	}
-
-	return grownCount, nil
-}
-
 // DTO для запросов
 
 // HabitCompletionResponse представляет ответ при завершении привычки
 type HabitCompletionResponse struct {
-	XPEarned    int `json:"xpEarned" example:"150"`
-	PlantsGrown int `json:"plantsGrown" example:"3"`
+	XPEarned        int                       `json:"xpEarned" example:"150"`
+	GoldEarned      int                       `json:"goldEarned" example:"5"`
+	GrowthPoints    int                       `json:"growthPoints" example:"3"`
+	PlantsGrown     int                       `json:"plantsGrown" example:"3"`
+	LevelUp         *model.LevelUp            `json:"levelUp,omitempty"`
+	Drought         *model.DroughtState       `json:"drought"`
+	StreakReward    *model.ItemGrant          `json:"streakReward,omitempty"`
+	RewardCap       *model.RewardCap          `json:"rewardCap,omitempty"`       // Заполняется, если награда уменьшена дневными ограничениями
+	Achievements    []model.AchievementUnlock `json:"achievements,omitempty"`    // Достижения, открытые этим выполнением
+	CompletedQuests []model.UserQuest         `json:"completedQuests,omitempty"` // Ежедневные задания, выполненные этим выполнением (награду нужно забрать)
 }
 
 // HabitUndoResponse представляет ответ при отмене выполнения привычки
 type HabitUndoResponse struct {
-	XPEarned int `json:"xpEarned" example:"150"`
-}
-
-// HabitIncrementResponse представляет ответ при увеличении счетчика
-type HabitIncrementResponse struct {
-	Count int `json:"count" example:"5"`
-}
-
-// HabitResetResponse представляет ответ при сбросе счетчика
-type HabitResetResponse struct {
-	Message string `json:"message" example:"Habit count reset successfully"`
+	XPEarned             int  `json:"xpEarned" example:"-150"`
+	GoldEarned           int  `json:"goldEarned" example:"-5"`
+	PlantsReverted       int  `json:"plantsReverted" example:"3"`       // У скольких растений откачен рост
+	StreakReverted       bool `json:"streakReverted" example:"true"`    // Стрик уменьшен на 1
+	StreakRewardReturned int  `json:"streakRewardReturned" example:"0"` // Сколько предметов награды за стрик списано
+	DroughtRestored      bool `json:"droughtRestored" example:"false"`  // Засуха возвращена к состоянию до выполнения
 }
 
 // HabitCreateRequest представляет запрос на создание привычки
@@ -627,12 +521,11 @@ type HabitCreateRequest struct {
 	Title       string    `json:"title" example:"Утренняя зарядка"`
 	Description *string   `json:"description,omitempty" example:"Ежедневная утренняя зарядка 15 минут"`
 	Difficulty  string    `json:"difficulty" example:"medium"`
-	Count       int       `json:"count" example:"0"`
 	Period      string    `json:"period" example:"day"`
 	Every       int       `json:"every" example:"1"`
 	StartDate   time.Time `json:"startDate" example:"2024-01-15T00:00:00Z"`
-	XPReward    int       `json:"xpReward" example:"50"`
-	TagID       *int      `json:"tagId,omitempty" example:"1"`
+	TagIDs      []int     `json:"tagIds,omitempty" example:"1,2"`
+	TagID       *int      `json:"tagId,omitempty" example:"1"` // устаревшее поле, используйте tagIds
 }
 
 // HabitUpdateRequest представляет запрос на обновление привычки
@@ -640,11 +533,9 @@ type HabitUpdateRequest struct {
 	Title       string    `json:"title" example:"Утренняя зарядка - обновлено"`
 	Description *string   `json:"description,omitempty" example:"Обновленное описание привычки"`
 	Difficulty  string    `json:"difficulty" example:"hard"`
-	Done        bool      `json:"done" example:"false"`
-	Count       int       `json:"count" example:"5"`
 	Period      string    `json:"period" example:"week"`
 	Every       int       `json:"every" example:"3"`
 	StartDate   time.Time `json:"startDate" example:"2024-01-20T00:00:00Z"`
-	XPReward    int       `json:"xpReward" example:"75"`
-	TagID       *int      `json:"tagId,omitempty" example:"2"`
+	TagIDs      []int     `json:"tagIds,omitempty" example:"2,3"`
+	TagID       *int      `json:"tagId,omitempty" example:"2"` // устаревшее поле, используйте tagIds
 }
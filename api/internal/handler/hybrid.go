@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/labstack/echo/v4"
+)
+
+type HybridHandler struct {
+	BaseHandler
+	repo *repository.HybridRepo
+}
+
+func NewHybridHandler(repo *repository.HybridRepo) *HybridHandler {
+	return &HybridHandler{repo: repo}
+}
+
+// GetDiscoveries godoc
+// @Summary Открытые гибриды
+// @Description Возвращает гибридные семена, которые пользователь вывел скрещиванием, с количеством выведений
+// @Tags hybrids
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} model.HybridDiscovery
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hybrids [get]
+func (h *HybridHandler) GetDiscoveries(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	discoveries, err := h.repo.GetDiscoveries(context.Background(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, discoveries)
+}
+
+// GetRecipes godoc
+// @Summary Рецепты скрещивания
+// @Description Возвращает рецепты скрещивания: пары семян, которые на соседних грядках могут дать гибрид при сборе зрелого растения. Результат рецепта (resultSeed) виден только после открытия гибрида
+// @Tags hybrids
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} model.HybridRecipeInfo
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hybrids/recipes [get]
+func (h *HybridHandler) GetRecipes(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	recipes, err := h.repo.GetRecipeInfos(context.Background(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, recipes)
+}
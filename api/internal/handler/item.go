@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/labstack/echo/v4"
+)
+
+type ItemHandler struct {
+	BaseHandler
+	repo         *repository.ItemRepo
+	userItemRepo *repository.UserItemRepo
+}
+
+func NewItemHandler(repo *repository.ItemRepo, userItemRepo *repository.UserItemRepo) *ItemHandler {
+	return &ItemHandler{
+		repo:         repo,
+		userItemRepo: userItemRepo,
+	}
+}
+
+// GetAll godoc
+// @Summary Получить все предметы
+// @Description Возвращает справочник предметов (например, лейка для оживления засохших растений)
+// @Tags items
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} model.Item
+// @Failure 500 {object} map[string]string
+// @Router /items [get]
+func (h *ItemHandler) GetAll(c echo.Context) error {
+	items, err := h.repo.GetAll(context.Background())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, items)
+}
+
+// GetUserItems godoc
+// @Summary Получить инвентарь предметов
+// @Description Возвращает предметы, которые есть у текущего пользователя, с их количеством
+// @Tags items
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} model.UserItemWithDetails
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user-items [get]
+func (h *ItemHandler) GetUserItems(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	items, err := h.userItemRepo.GetByUser(context.Background(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, items)
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type LeaderboardHandler struct {
+	BaseHandler
+	leaderboardService *service.LeaderboardService
+}
+
+func NewLeaderboardHandler(leaderboardService *service.LeaderboardService) *LeaderboardHandler {
+	return &LeaderboardHandler{leaderboardService: leaderboardService}
+}
+
+// GetLeaderboard godoc
+// @Summary Получить рейтинг
+// @Description Возвращает рейтинг за недельный сезон (с понедельника по воскресенье) по метрике: weekly_xp — опыт за сезон (выполнения, сбор урожая, задания и цели гильдии), longest_streak — самая длинная серия дней подряд с выполнениями внутри сезона, total_harvests — растения, собранные за сезон. Область global — все пользователи, friends — друзья и сам пользователь с местами внутри этого круга. Рейтинг строится по снимку, который пересчитывается планировщиком каждые 15 минут; время снимка — computedAt. Параметр season (любая дата сезона) позволяет посмотреть итоги прошлых сезонов
+// @Tags leaderboards
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param metric query string false "Метрика: weekly_xp, longest_streak, total_harvests" default(weekly_xp)
+// @Param scope query string false "Область: global, friends" default(global)
+// @Param season query string false "Дата внутри сезона (YYYY-MM-DD), по умолчанию текущий сезон"
+// @Success 200 {object} model.Leaderboard
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /leaderboards [get]
+func (h *LeaderboardHandler) GetLeaderboard(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	metric := c.QueryParam("metric")
+	if metric == "" {
+		metric = constants.LeaderboardWeeklyXP
+	}
+	scope := c.QueryParam("scope")
+	if scope == "" {
+		scope = constants.LeaderboardScopeGlobal
+	}
+
+	day := time.Now()
+	if raw := c.QueryParam("season"); raw != "" {
+		day, err = time.ParseInLocation("2006-01-02", raw, time.Local)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid season date, expected YYYY-MM-DD"})
+		}
+	}
+
+	leaderboard, err := h.leaderboardService.Get(context.Background(), userID, metric, scope, day)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, leaderboard)
+}
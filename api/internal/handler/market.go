@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultGoldLedgerLimit = 50
+	maxGoldLedgerLimit     = 200
+)
+
+type MarketHandler struct {
+	BaseHandler
+	marketService  *service.MarketService
+	produceRepo    *repository.ProduceRepo
+	goldLedgerRepo *repository.GoldLedgerRepo
+}
+
+func NewMarketHandler(
+	marketService *service.MarketService,
+	produceRepo *repository.ProduceRepo,
+	goldLedgerRepo *repository.GoldLedgerRepo,
+) *MarketHandler {
+	return &MarketHandler{
+		marketService:  marketService,
+		produceRepo:    produceRepo,
+		goldLedgerRepo: goldLedgerRepo,
+	}
+}
+
+type SellProduceRequest struct {
+	SeedID   int `json:"seedId" example:"1"`
+	Quantity int `json:"quantity" example:"4"`
+}
+
+// GetPrices godoc
+// @Summary Цены на рынке
+// @Description Возвращает сегодняшние цены на урожай всех семян, вчерашние цены, изменение в процентах и количество урожая в амбаре. Цены меняются раз в день и одинаковы для всех пользователей
+// @Tags market
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} model.MarketPrice
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /market [get]
+func (h *MarketHandler) GetPrices(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	prices, err := h.marketService.Prices(context.Background(), userID, time.Now())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, prices)
+}
+
+// SellProduce godoc
+// @Summary Продать урожай
+// @Description Продает урожай из амбара по сегодняшней цене. Золото (дробная часть отбрасывается) начисляется на баланс и записывается в журнал золота
+// @Tags market
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param request body SellProduceRequest true "Семя и количество урожая"
+// @Success 200 {object} model.MarketSale
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /market/sell [post]
+func (h *MarketHandler) SellProduce(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req SellProduceRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	if req.SeedID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "seedId is required"})
+	}
+	if req.Quantity <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "quantity must be positive"})
+	}
+
+	sale, err := h.marketService.Sell(context.Background(), userID, req.SeedID, req.Quantity)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		if strings.Contains(err.Error(), "not enough produce") {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, sale)
+}
+
+// GetBarn godoc
+// @Summary Амбар
+// @Description Возвращает урожай, собранный пользователем и еще не проданный
+// @Tags market
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} model.Produce
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /barn [get]
+func (h *MarketHandler) GetBarn(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	produce, err := h.produceRepo.GetByUser(context.Background(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, produce)
+}
+
+// GetGoldLedger godoc
+// @Summary Журнал золота
+// @Description Возвращает журнал изменений золота пользователя (награды, покупки, продажи на рынке и т.д.), начиная с последних
+// @Tags market
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param limit query int false "Количество записей (по умолчанию 50, максимум 200)"
+// @Success 200 {array} model.GoldLedgerEntry
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user-stats/gold-ledger [get]
+func (h *MarketHandler) GetGoldLedger(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	limit := defaultGoldLedgerLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+		}
+		if limit > maxGoldLedgerLimit {
+			limit = maxGoldLedgerLimit
+		}
+	}
+
+	entries, err := h.goldLedgerRepo.GetByUser(context.Background(), userID, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, entries)
+}
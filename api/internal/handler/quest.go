@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type QuestHandler struct {
+	BaseHandler
+	questService *service.QuestService
+}
+
+func NewQuestHandler(questService *service.QuestService) *QuestHandler {
+	return &QuestHandler{questService: questService}
+}
+
+// GetToday godoc
+// @Summary Получить ежедневные задания
+// @Description Возвращает ежедневные задания пользователя на сегодня с прогрессом. Задания выдаются планировщиком раз в день по шаблонам, доступным на уровне пользователя; если задания на сегодня еще не выданы, они выдаются при первом запросе. Прогресс засчитывается при выполнении задач и привычек и при сборе урожая, отмена выполнения откатывает прогресс еще не полученного задания
+// @Tags quests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} model.UserQuest
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /quests [get]
+func (h *QuestHandler) GetToday(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	quests, err := h.questService.GetToday(context.Background(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, quests)
+}
+
+// Claim godoc
+// @Summary Получить награду за задание
+// @Description Выдает награду за выполненное ежедневное задание: золото, опыт и предмет. Награду можно получить один раз (повторный запрос — 409), за невыполненное задание — 400. Награды за достигнутые уровни выдаются в той же транзакции, при повышении уровня возвращается блок levelUp. Награда обратно не забирается, поэтому выполнения задач и привычек, засчитанные в задание, после получения награды нельзя отменить
+// @Tags quests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Quest ID"
+// @Success 200 {object} model.QuestClaim
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /quests/{id}/claim [post]
+func (h *QuestHandler) Claim(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid quest ID"})
+	}
+
+	claim, err := h.questService.Claim(context.Background(), userID, id)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		case strings.Contains(err.Error(), "already claimed"):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		case strings.Contains(err.Error(), "not completed"):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, claim)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
 	"github.com/labstack/echo/v4"
 )
 
@@ -123,7 +124,7 @@ func (h *SeedHandler) GetByRarity(c echo.Context) error {
 
 // Create godoc
 // @Summary Создать новое семя
-// @Description Создает новое семя (только для администраторов)
+// @Description Создает новое семя (только для администраторов). stageThresholds — проценты роста, с которых начинаются стадии роста: первая 0, далее по возрастанию до 100 (по умолчанию [0, 34, 67, 100])
 // @Tags seeds
 // @Accept json
 // @Produce json
@@ -133,14 +134,19 @@ func (h *SeedHandler) GetByRarity(c echo.Context) error {
 // @Success 200 {object} model.Seed
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /seeds [post]
+// @Router /admin/seeds [post]
 func (h *SeedHandler) Create(c echo.Context) error {
 	var seed model.Seed
 	if err := c.Bind(&seed); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
+	if !utils.ValidStageThresholds(seed.StageThresholds) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid stage thresholds"})
+	}
+
 	if err := h.repo.Create(context.Background(), &seed); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -149,7 +155,7 @@ func (h *SeedHandler) Create(c echo.Context) error {
 
 // Update godoc
 // @Summary Обновить семя
-// @Description Обновляет информацию о семени (только для администраторов)
+// @Description Обновляет информацию о семени (только для администраторов), включая пороги стадий роста stageThresholds
 // @Tags seeds
 // @Accept json
 // @Produce json
@@ -160,9 +166,10 @@ func (h *SeedHandler) Create(c echo.Context) error {
 // @Success 200 {object} model.Seed
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /seeds/{id} [put]
+// @Router /admin/seeds/{id} [put]
 func (h *SeedHandler) Update(c echo.Context) error {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -174,6 +181,10 @@ func (h *SeedHandler) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
+	if !utils.ValidStageThresholds(seed.StageThresholds) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid stage thresholds"})
+	}
+
 	seed.ID = id
 
 	if err := h.repo.Update(context.Background(), &seed); err != nil {
@@ -197,9 +208,10 @@ func (h *SeedHandler) Update(c echo.Context) error {
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /seeds/{id} [delete]
+// @Router /admin/seeds/{id} [delete]
 func (h *SeedHandler) Delete(c echo.Context) error {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
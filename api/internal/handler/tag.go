@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -241,8 +242,8 @@ func (h *TagHandler) Delete(c echo.Context) error {
 }
 
 // GetWithTaskCount godoc
-// @Summary Получить теги с количеством задач
-// @Description Возвращает теги с информацией о количестве привязанных задач
+// @Summary Получить теги с количеством задач и привычек
+// @Description Возвращает теги с информацией о количестве привязанных задач, привычек и их сумме
 // @Tags tags
 // @Accept json
 // @Produce json
@@ -265,6 +266,62 @@ func (h *TagHandler) GetWithTaskCount(c echo.Context) error {
 	return c.JSON(http.StatusOK, tags)
 }
 
+// mergeTagIDs объединяет список тегов из запроса с устаревшим полем tagId
+func mergeTagIDs(tagIDs []int, legacyTagID *int) []int {
+	ids := append([]int{}, tagIDs...)
+	if legacyTagID != nil {
+		ids = append(ids, *legacyTagID)
+	}
+	return ids
+}
+
+// validateTagOwnership проверяет, что все теги существуют и принадлежат пользователю
+func validateTagOwnership(ctx context.Context, tagRepo *repository.TagRepo, userID int64, tagIDs []int) error {
+	missing, err := tagRepo.GetMissingIDs(ctx, userID, tagIDs)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("invalid tag IDs: %v", missing)
+	}
+	return nil
+}
+
+// parseTagFilter разбирает параметры фильтрации по тегам:
+// tag_ids=1,2,3 (или устаревший tag_id=1) и tag_match=any|all
+func parseTagFilter(c echo.Context) (tagIDs []int, matchAll bool, err error) {
+	raw := c.QueryParam("tag_ids")
+	if legacy := c.QueryParam("tag_id"); legacy != "" {
+		if raw != "" {
+			raw += ","
+		}
+		raw += legacy
+	}
+
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid tag ID: %s", part)
+		}
+		tagIDs = append(tagIDs, id)
+	}
+
+	switch c.QueryParam("tag_match") {
+	case "", "any":
+		matchAll = false
+	case "all":
+		matchAll = true
+	default:
+		return nil, false, fmt.Errorf("invalid tag_match value, use any or all")
+	}
+
+	return tagIDs, matchAll, nil
+}
+
 // DTO для запросов
 
 // TagCreateRequest представляет запрос на создание тега
@@ -2,35 +2,50 @@ package handler
 
 import (
 	"context"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
 	"github.com/RinatHar/FarmFocus/api/internal/utils"
 	"github.com/labstack/echo/v4"
 )
 
 type TaskHandler struct {
 	BaseHandler
-	repo          *repository.TaskRepo
-	progressRepo  *repository.ProgressLogRepo
-	userStatRepo  *repository.UserStatRepo
-	userPlantRepo *repository.UserPlantRepo
+	repo         *repository.TaskRepo
+	progressRepo *repository.ProgressLogRepo
+	userStatRepo *repository.UserStatRepo
+	tagRepo      *repository.TagRepo
+	levelService *service.LevelService
+	rewardLimits model.RewardLimits
+	achievements *service.AchievementService
+	rewards      map[string]constants.DifficultyReward
 }
 
 func NewTaskHandler(
 	repo *repository.TaskRepo,
 	progressRepo *repository.ProgressLogRepo,
 	userStatRepo *repository.UserStatRepo,
-	userPlantRepo *repository.UserPlantRepo) *TaskHandler {
+	tagRepo *repository.TagRepo,
+	levelService *service.LevelService,
+	rewardLimits model.RewardLimits,
+	achievements *service.AchievementService,
+	rewards map[string]constants.DifficultyReward) *TaskHandler {
 	return &TaskHandler{
-		repo:          repo,
-		progressRepo:  progressRepo,
-		userStatRepo:  userStatRepo,
-		userPlantRepo: userPlantRepo,
+		repo:         repo,
+		progressRepo: progressRepo,
+		userStatRepo: userStatRepo,
+		tagRepo:      tagRepo,
+		levelService: levelService,
+		rewardLimits: rewardLimits,
+		achievements: achievements,
+		rewards:      rewards,
 	}
 }
 
@@ -60,7 +75,7 @@ func (h *TaskHandler) Create(c echo.Context) error {
 	}
 
 	// Устанавливаем базовое количество опыта в зависимости от сложности
-	calcXP := utils.GetBaseXPForDifficulty(req.Difficulty)
+	calcXP := utils.GetDifficultyReward(h.rewards, req.Difficulty).BaseXP
 
 	task := model.Task{
 		UserID:      userID,
@@ -70,10 +85,17 @@ func (h *TaskHandler) Create(c echo.Context) error {
 		Date:        req.Date,
 		Done:        false,
 		XPReward:    calcXP,
-		TagID:       req.TagID,
+		TagIDs:      mergeTagIDs(req.TagIDs, req.TagID),
 		CreatedAt:   time.Now(),
 	}
 
+	if err := validateTagOwnership(context.Background(), h.tagRepo, userID, task.TagIDs); err != nil {
+		if strings.Contains(err.Error(), "invalid tag IDs") {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
 	if err := h.repo.Create(context.Background(), &task); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -90,8 +112,11 @@ func (h *TaskHandler) Create(c echo.Context) error {
 // @Param X-User-ID header string true "User ID"
 // @Param done query boolean false "Фильтр по статусу выполнения"
 // @Param date query string false "Фильтр по дате (формат: 2006-01-02)"
-// @Param tag_id query integer false "Фильтр по ID тега"
+// @Param tag_id query integer false "Фильтр по ID тега (устаревший, используйте tag_ids)"
+// @Param tag_ids query string false "Фильтр по списку ID тегов через запятую (например: 1,2,3)"
+// @Param tag_match query string false "Режим фильтра по тегам: any (хотя бы один тег, по умолчанию) или all (все теги)" Enums(any, all)
 // @Success 200 {array} model.Task
+// @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /tasks [get]
@@ -104,25 +129,24 @@ func (h *TaskHandler) GetAll(c echo.Context) error {
 	// Обработка query параметров
 	doneStr := c.QueryParam("done")
 	dateStr := c.QueryParam("date")
-	tagIDStr := c.QueryParam("tag_id")
+	tagIDs, matchAll, err := parseTagFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 
 	var tasks []model.Task
 
-	if tagIDStr != "" {
-		tagID, err := strconv.Atoi(tagIDStr)
-		if err != nil {
-			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid tag ID"})
-		}
-		tasks, err = h.repo.GetByTag(context.Background(), userID, tagID)
+	if len(tagIDs) > 0 {
+		tasks, err = h.repo.GetByTags(context.Background(), userID, tagIDs, matchAll)
 	} else if dateStr != "" {
-		date, err := time.Parse("2006-01-02", dateStr)
-		if err != nil {
+		date, parseErr := time.Parse("2006-01-02", dateStr)
+		if parseErr != nil {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid date format, use YYYY-MM-DD"})
 		}
 		tasks, err = h.repo.GetByDate(context.Background(), userID, date)
 	} else if doneStr != "" {
-		done, err := strconv.ParseBool(doneStr)
-		if err != nil {
+		done, parseErr := strconv.ParseBool(doneStr)
+		if parseErr != nil {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid done value"})
 		}
 		tasks, err = h.repo.GetByStatus(context.Background(), userID, done)
@@ -174,7 +198,7 @@ func (h *TaskHandler) GetByID(c echo.Context) error {
 
 // Update godoc
 // @Summary Обновить задачу
-// @Description Обновляет информацию о задаче
+// @Description Обновляет информацию о задаче. Отметка выполнения меняется только выполнением и его отменой; базовый опыт пересчитывается по сложности
 // @Tags tasks
 // @Accept json
 // @Produce json
@@ -211,9 +235,15 @@ func (h *TaskHandler) Update(c echo.Context) error {
 		Description: req.Description,
 		Difficulty:  req.Difficulty,
 		Date:        req.Date,
-		Done:        req.Done,
-		XPReward:    req.XPReward,
-		TagID:       req.TagID,
+		XPReward:    utils.GetDifficultyReward(h.rewards, req.Difficulty).BaseXP, // Базовый опыт задается только сервером
+		TagIDs:      mergeTagIDs(req.TagIDs, req.TagID),
+	}
+
+	if err := validateTagOwnership(context.Background(), h.tagRepo, userID, task.TagIDs); err != nil {
+		if strings.Contains(err.Error(), "invalid tag IDs") {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
 	if err := h.repo.Update(context.Background(), &task); err != nil {
@@ -227,7 +257,7 @@ func (h *TaskHandler) Update(c echo.Context) error {
 
 // Delete godoc
 // @Summary Удалить задачу
-// @Description Удаляет задачу по ID, предварительно удаляя связанные записи в логе прогресса
+// @Description Удаляет задачу по ID. История ее выполнений сохраняется и продолжает учитываться в дневных ограничениях наград, стрике и рейтингах
 // @Tags tasks
 // @Accept json
 // @Produce json
@@ -259,11 +289,6 @@ func (h *TaskHandler) Delete(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	// Удаляем связанные записи в progress_log
-	if err := h.progressRepo.DeleteByTaskID(context.Background(), id); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
 	// Удаляем задачу
 	err = h.repo.Delete(context.Background(), id, userID)
 	if err != nil {
@@ -273,28 +298,9 @@ func (h *TaskHandler) Delete(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
-// growUserPlants увеличивает рост всех активных растений пользователя
-func (h *TaskHandler) growUserPlants(ctx context.Context, userID int64, growthAmount int) (int, error) {
-	userPlants, err := h.userPlantRepo.GetByUser(ctx, userID)
-	if err != nil {
-		return 0, err
-	}
-
-	grownCount := 0
-	for _, plant := range userPlants {
-		_, err := h.userPlantRepo.AddGrowth(ctx, plant.ID, growthAmount)
-		if err != nil {
-			continue
-		}
-		grownCount++
-	}
-
-	return grownCount, nil
-}
-
 // MarkAsDone godoc
 // @Summary Пометить задачу как выполненную
-// @Description Помечает задачу как выполненную, добавляет опыт пользователю по формуле, создает запись в логе прогресса, увеличивает рост всех активных растений пользователя на 1 и увеличивает стрик если это первое выполнение сегодня
+// @Description Помечает задачу как выполненную, начисляет опыт и золото по таблице наград сложности, создает запись в логе прогресса, увеличивает рост всех активных растений пользователя на очки роста сложности с учетом множителя роста семени и засухи, увеличивает стрик (каждые 7 дней подряд выдается лейка) и ослабляет засуху на одну ступень если это первое выполнение сегодня и возвращает блок levelUp при повышении уровня. После нескольких выполнений за день награды убывают, а опыт и очки роста сверх мягкого дневного лимита начисляются частично — в этом случае возвращается блок rewardCap. Открытые выполнением достижения возвращаются в achievements (награды за них уже выданы), выполненные ежедневные задания — в completedQuests. Отмечать выполнение и отмену можно ограниченное число раз в минуту (429)
 // @Tags tasks
 // @Accept json
 // @Produce json
@@ -305,6 +311,7 @@ func (h *TaskHandler) growUserPlants(ctx context.Context, userID int64, growthAm
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /tasks/{id}/done [patch]
 func (h *TaskHandler) MarkAsDone(c echo.Context) error {
@@ -340,77 +347,64 @@ func (h *TaskHandler) MarkAsDone(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	// Рассчитываем опыт по формуле: базовый опыт * (коэф уровня + коэф сложности)
+	// Опыт, золото и очки роста берутся из таблицы наград по сложности.
+	// Опыт рассчитывается по формуле: базовый опыт * (коэф уровня + коэф сложности)
+	reward := utils.GetDifficultyReward(h.rewards, task.Difficulty)
 	userLevel := stats.Level()
-	calculatedXP := utils.CalculateTaskXP(task.XPReward, userLevel, task.Difficulty)
-
-	// Проверяем, выполнены ли сегодня задачи или привычки
-	hasCompletedTaskToday, err := h.progressRepo.HasUserCompletedTaskToday(context.Background(), userID)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	hasCompletedHabitToday, err := h.progressRepo.HasUserCompletedHabitToday(context.Background(), userID)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	// Если сегодня еще не выполнено ни задач, ни привычек - увеличиваем стрик
-	shouldIncrementStreak := !hasCompletedTaskToday && !hasCompletedHabitToday
-
-	// Если это первое выполнение сегодня и пользователь в засухе - сбрасываем засуху
-	if shouldIncrementStreak && stats.IsDrought {
-		h.userStatRepo.ResetDrought(context.Background(), userID)
-	}
-
-	// Помечаем задачу как выполненную
-	err = h.repo.MarkAsDone(context.Background(), id, userID)
+	calculatedXP := utils.CalculateTaskXP(task.XPReward, userLevel, reward)
+
+	// Задача захватывается условным обновлением, а опыт, золото, рост растений, стрик и засуха
+	// применяются и записываются в одной транзакции. Убывающая отдача и мягкие дневные лимиты
+	// против спама простыми задачами считаются там же, под блокировкой пользователя
+	result, err := h.progressRepo.Complete(context.Background(), &model.Completion{
+		UserID:          userID,
+		TaskID:          &id,
+		Reward:          model.CompletionReward{XP: calculatedXP, Gold: reward.Gold, Growth: reward.Growth},
+		Limits:          h.rewardLimits,
+		GoldDescription: "Выполнение задачи «" + task.Title + "»",
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	// Увеличиваем стрик если это первое выполнение сегодня
-	if shouldIncrementStreak {
-		if err := h.userStatRepo.IncrementStreak(context.Background(), userID); err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		if strings.Contains(err.Error(), "already marked as done") {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Task is already marked as done",
+			})
+		}
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
 		}
-	}
-
-	// Создаем запись в progress_log
-	progressLog := &model.ProgressLog{
-		UserID:     userID,
-		TaskID:     &id,
-		XPEarned:   calculatedXP,
-		GoldEarned: 0,
-		CreatedAt:  time.Now(),
-	}
-
-	if err := h.progressRepo.Create(context.Background(), progressLog); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	// Добавляем опыт пользователю
-	if calculatedXP > 0 {
-		if err := h.userStatRepo.AddExperience(context.Background(), userID, int64(calculatedXP)); err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-		}
+	// Награды за новые уровни уже выданы в транзакции выполнения, здесь только собираем их описание.
+	// Выполнение уже сохранено, поэтому ошибки ниже не превращают ответ в 500, а только логируются
+	levelUp, err := h.levelService.LevelUp(context.Background(), result.Experience)
+	if err != nil {
+		log.Printf("Error describing level up for user %d after completing task %d: %v", userID, id, err)
 	}
 
-	// Увеличиваем рост всех активных растений пользователя на 1
-	plantsGrown, err := h.growUserPlants(context.Background(), userID, 1)
+	// Проверяем достижения по обновленным счетчикам выполнений и стрика
+	achievements, err := h.achievements.Evaluate(context.Background(), userID)
 	if err != nil {
-		// Логируем ошибку, но не прерываем выполнение основной операции
+		log.Printf("Error evaluating achievements for user %d after completing task %d: %v", userID, id, err)
 	}
 
 	return c.JSON(http.StatusOK, TaskCompletionResponse{
-		XPEarned:    calculatedXP,
-		PlantsGrown: plantsGrown,
+		XPEarned:        result.Reward.XP,
+		GoldEarned:      result.Reward.Gold,
+		GrowthPoints:    result.Reward.Growth,
+		PlantsGrown:     len(result.PlantGrowth),
+		LevelUp:         levelUp,
+		Drought:         result.Drought,
+		StreakReward:    result.StreakReward,
+		RewardCap:       result.RewardCap,
+		Achievements:    achievements,
+		CompletedQuests: result.CompletedQuests,
 	})
 }
 
 // MarkAsUndone godoc
 // @Summary Пометить задачу как невыполненную
-// @Description Помечает задачу как невыполненную и возвращает опыт обратно на основе последней записи в логе
+// @Description Помечает задачу как невыполненную и в одной транзакции откатывает все эффекты последнего выполнения: опыт, золото, рост растений, увеличение стрика с наградой за стрик, ослабление засухи (если засуха с тех пор не менялась) и прогресс еще не полученных ежедневных заданий. Повторная отмена возвращает 400. Выполнение, выросшее растение которого уже собрано, не отменяется (400): урожай и опыт за сбор уже получены. Также не отменяется выполнение, засчитанное в ежедневное задание, награда за которое уже получена (400): награда за задание обратно не забирается
 // @Tags tasks
 // @Accept json
 // @Produce json
@@ -421,6 +415,7 @@ func (h *TaskHandler) MarkAsDone(c echo.Context) error {
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /tasks/{id}/undone [patch]
 func (h *TaskHandler) MarkAsUndone(c echo.Context) error {
@@ -435,65 +430,39 @@ func (h *TaskHandler) MarkAsUndone(c echo.Context) error {
 	}
 
 	// Получаем задачу для проверки существования
-
-	if _, err := h.repo.GetByID(context.Background(), id, userID); err != nil {
+	task, err := h.repo.GetByID(context.Background(), id, userID)
+	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
 		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	// Ищем последнюю запись в progress_log для этой задачи
-	lastProgress, err := h.progressRepo.GetLastByTaskID(context.Background(), id)
+	// Снимаем отметку выполнения и откатываем все эффекты последнего выполнения в одной транзакции
+	undo, err := h.progressRepo.UndoTaskCompletion(context.Background(), userID, id,
+		"Отмена выполнения задачи «"+task.Title+"»")
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if strings.Contains(err.Error(), "cannot be undone") {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if strings.Contains(err.Error(), "completion for") {
 			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "No completion record found for this task",
+				"error": "Task was not completed or already undone",
 			})
 		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	// Проверяем, что запись содержит положительный опыт (задача была выполнена)
-	if lastProgress.XPEarned <= 0 {
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Task was not completed or already undone",
-		})
-	}
-
-	xpToReturn := lastProgress.XPEarned
-
-	// Помечаем задачу как невыполненную
-	err = h.repo.MarkAsUndone(context.Background(), id, userID)
-	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
 		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	// Возвращаем опыт (вычитаем)
-	if xpToReturn > 0 {
-		if err := h.userStatRepo.RemoveExperience(context.Background(), userID, int64(xpToReturn)); err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-		}
-	}
-
-	// Создаем запись в progress_log о возврате опыта
-	progressLog := &model.ProgressLog{
-		UserID:     userID,
-		TaskID:     &id,
-		XPEarned:   -xpToReturn, // Отрицательное значение для возврата
-		GoldEarned: 0,
-		CreatedAt:  time.Now(),
-	}
-
-	if err := h.progressRepo.Create(context.Background(), progressLog); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
 	return c.JSON(http.StatusOK, TaskUndoResponse{
-		XPEarned: -xpToReturn,
+		XPEarned:             -undo.XPReturned,
+		GoldEarned:           -undo.GoldReturned,
+		PlantsReverted:       undo.PlantsReverted,
+		StreakReverted:       undo.StreakReverted,
+		StreakRewardReturned: undo.StreakRewardReturned,
+		DroughtRestored:      undo.DroughtRestored,
 	})
 }
 
@@ -501,13 +470,26 @@ func (h *TaskHandler) MarkAsUndone(c echo.Context) error {
 
 // TaskCompletionResponse представляет ответ при завершении задачи
 type TaskCompletionResponse struct {
-	XPEarned    int `json:"xpEarned" example:"150"`
-	PlantsGrown int `json:"plantsGrown" example:"3"`
+	XPEarned        int                       `json:"xpEarned" example:"150"`
+	GoldEarned      int                       `json:"goldEarned" example:"5"`
+	GrowthPoints    int                       `json:"growthPoints" example:"3"`
+	PlantsGrown     int                       `json:"plantsGrown" example:"3"`
+	LevelUp         *model.LevelUp            `json:"levelUp,omitempty"`
+	Drought         *model.DroughtState       `json:"drought"`
+	StreakReward    *model.ItemGrant          `json:"streakReward,omitempty"`
+	RewardCap       *model.RewardCap          `json:"rewardCap,omitempty"`       // Заполняется, если награда уменьшена дневными ограничениями
+	Achievements    []model.AchievementUnlock `json:"achievements,omitempty"`    // Достижения, открытые этим выполнением
+	CompletedQuests []model.UserQuest         `json:"completedQuests,omitempty"` // Ежедневные задания, выполненные этим выполнением (награду нужно забрать)
 }
 
 // TaskUndoResponse представляет ответ при отмене выполнения задачи
 type TaskUndoResponse struct {
-	XPEarned int `json:"xpEarned" example:"150"`
+	XPEarned             int  `json:"xpEarned" example:"-150"`
+	GoldEarned           int  `json:"goldEarned" example:"-5"`
+	PlantsReverted       int  `json:"plantsReverted" example:"3"`       // У скольких растений откачен рост
+	StreakReverted       bool `json:"streakReverted" example:"true"`    // Стрик уменьшен на 1
+	StreakRewardReturned int  `json:"streakRewardReturned" example:"0"` // Сколько предметов награды за стрик списано
+	DroughtRestored      bool `json:"droughtRestored" example:"false"`  // Засуха возвращена к состоянию до выполнения
 }
 
 // TaskCreateRequest представляет запрос на создание задачи
@@ -516,7 +498,8 @@ type TaskCreateRequest struct {
 	Description *string    `json:"description,omitempty" example:"Нужно завершить все задачи по проекту до конца недели"`
 	Difficulty  string     `json:"difficulty" example:"medium"`
 	Date        *time.Time `json:"date,omitempty" example:"2024-01-15T00:00:00Z"`
-	TagID       *int       `json:"tagId,omitempty" example:"1"`
+	TagIDs      []int      `json:"tagIds,omitempty" example:"1,2"`
+	TagID       *int       `json:"tagId,omitempty" example:"1"` // устаревшее поле, используйте tagIds
 }
 
 // TaskUpdateRequest представляет запрос на обновление задачи
@@ -525,7 +508,6 @@ type TaskUpdateRequest struct {
 	Description *string    `json:"description,omitempty" example:"Обновленное описание задачи"`
 	Difficulty  string     `json:"difficulty" example:"hard"`
 	Date        *time.Time `json:"date,omitempty" example:"2024-01-20T00:00:00Z"`
-	Done        bool       `json:"done" example:"false"`
-	XPReward    int        `json:"xpReward" example:"150"`
-	TagID       *int       `json:"tagId,omitempty" example:"2"`
+	TagIDs      []int      `json:"tagIds,omitempty" example:"2,3"`
+	TagID       *int       `json:"tagId,omitempty" example:"2"` // устаревшее поле, используйте tagIds
 }
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type TradeHandler struct {
+	BaseHandler
+	tradeRepo   *repository.TradeRepo
+	giftService *service.GiftService
+}
+
+func NewTradeHandler(tradeRepo *repository.TradeRepo, giftService *service.GiftService) *TradeHandler {
+	return &TradeHandler{
+		tradeRepo:   tradeRepo,
+		giftService: giftService,
+	}
+}
+
+// GetTrades godoc
+// @Summary Получить предложения обмена
+// @Description Возвращает входящие и исходящие предложения обмена, ожидающие ответа
+// @Tags trades
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {object} model.TradeOffers
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /trades [get]
+func (h *TradeHandler) GetTrades(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	trades, err := h.tradeRepo.GetPending(context.Background(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, trades)
+}
+
+// ProposeTrade godoc
+// @Summary Предложить обмен
+// @Description Предлагает другу обмен: позиции offer отдает автор, позиции request — получатель. Ничего не списывается до принятия. Обмен возможен только с друзьями и учитывается в дневных лимитах подарков (429)
+// @Tags trades
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param request body TradeCreateRequest true "Данные обмена"
+// @Success 201 {object} model.TradeOffer
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /trades [post]
+func (h *TradeHandler) ProposeTrade(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req TradeCreateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if req.RecipientID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "recipientId is required"})
+	}
+
+	trade, err := h.giftService.ProposeTrade(context.Background(), userID, model.TradeOffer{
+		RecipientID: req.RecipientID,
+		Offer:       req.Offer,
+		Request:     req.Request,
+	})
+	if err != nil {
+		return exchangeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, trade)
+}
+
+// AcceptTrade godoc
+// @Summary Принять обмен
+// @Description Принимает входящее предложение обмена: обе стороны обмениваются позициями атомарно. Если у кого-то не хватает семян или урожая, обмен не выполняется (409), а предложение остается ожидающим ответа
+// @Tags trades
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Trade offer ID"
+// @Success 200 {object} model.TradeOffer
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /trades/{id}/accept [post]
+func (h *TradeHandler) AcceptTrade(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid trade offer ID"})
+	}
+
+	trade, err := h.tradeRepo.Accept(context.Background(), userID, id)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		case strings.Contains(err.Error(), "not enough"):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, trade)
+}
+
+// DeclineTrade godoc
+// @Summary Отклонить обмен
+// @Description Отклоняет входящее или отменяет исходящее предложение обмена
+// @Tags trades
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Trade offer ID"
+// @Success 200 {object} model.TradeOffer
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /trades/{id}/decline [post]
+func (h *TradeHandler) DeclineTrade(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid trade offer ID"})
+	}
+
+	trade, err := h.tradeRepo.Decline(context.Background(), userID, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, trade)
+}
+
+// DTO для запросов
+
+// TradeCreateRequest представляет запрос на предложение обмена
+type TradeCreateRequest struct {
+	RecipientID int64             `json:"recipientId" example:"2"`
+	Offer       []model.TradeLine `json:"offer"`   // Что отдает автор предложения
+	Request     []model.TradeLine `json:"request"` // Что отдает получатель
+}
@@ -4,14 +4,24 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	defaultDroughtEventsLimit = 20
+	maxDroughtEventsLimit     = 100
+	syncDroughtEventsLimit    = 10
+)
+
 type UserHandler struct {
 	BaseHandler
 	repo          *repository.UserRepo
@@ -25,6 +35,15 @@ type UserHandler struct {
 	userPlantRepo *repository.UserPlantRepo
 	goodRepo      *repository.GoodRepo
 	progressRepo  *repository.ProgressLogRepo
+
+	droughtService   *service.DroughtService
+	droughtEventRepo *repository.DroughtEventRepo
+	itemRepo         *repository.ItemRepo
+	userItemRepo     *repository.UserItemRepo
+	plantRevivalRepo *repository.PlantRevivalRepo
+	plantEffectRepo  *repository.PlantEffectRepo
+	produceRepo      *repository.ProduceRepo
+	decorationRepo   *repository.DecorationRepo
 }
 
 func NewUserHandler(
@@ -39,6 +58,14 @@ func NewUserHandler(
 	userPlantRepo *repository.UserPlantRepo,
 	goodRepo *repository.GoodRepo,
 	progressRepo *repository.ProgressLogRepo,
+	droughtService *service.DroughtService,
+	droughtEventRepo *repository.DroughtEventRepo,
+	itemRepo *repository.ItemRepo,
+	userItemRepo *repository.UserItemRepo,
+	plantRevivalRepo *repository.PlantRevivalRepo,
+	plantEffectRepo *repository.PlantEffectRepo,
+	produceRepo *repository.ProduceRepo,
+	decorationRepo *repository.DecorationRepo,
 ) *UserHandler {
 	return &UserHandler{
 		repo:          repo,
@@ -52,18 +79,66 @@ func NewUserHandler(
 		userPlantRepo: userPlantRepo,
 		goodRepo:      goodRepo,
 		progressRepo:  progressRepo,
+
+		droughtService:   droughtService,
+		droughtEventRepo: droughtEventRepo,
+		itemRepo:         itemRepo,
+		userItemRepo:     userItemRepo,
+		plantRevivalRepo: plantRevivalRepo,
+		plantEffectRepo:  plantEffectRepo,
+		produceRepo:      produceRepo,
+		decorationRepo:   decorationRepo,
+	}
+}
+
+// GetDroughtEvents godoc
+// @Summary История засух
+// @Description Возвращает историю засух пользователя (последние сначала): длительность, максимальную тяжесть, число засохших и погибших растений
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param limit query int false "Количество записей (по умолчанию 20, максимум 100)"
+// @Success 200 {array} model.DroughtEvent
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/drought-events [get]
+func (h *UserHandler) GetDroughtEvents(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	limit := defaultDroughtEventsLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+		}
+		if limit > maxDroughtEventsLimit {
+			limit = maxDroughtEventsLimit
+		}
 	}
+
+	events, err := h.droughtEventRepo.GetByUser(context.Background(), userID, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, events)
 }
 
 // RecoverPlants godoc
 // @Summary Восстановить засохшие растения
-// @Description Восстанавливает засохшие растения после выполнения ежедневного задания
+// @Description Бесплатно оживляет все засохшие растения после выполнения ежедневного задания. Доступно один раз за засуху; каждое оживление записывается в журнал. Отдельные растения можно оживить лейкой через POST /user-plants/{id}/revive
 // @Tags users
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param X-User-ID header string true "User ID"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} RecoverPlantsResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -88,13 +163,19 @@ func (h *UserHandler) RecoverPlants(c echo.Context) error {
 		})
 	}
 
-	// Восстанавливаем растения
-	if err := h.userPlantRepo.ResetWitheredStatus(ctx, userID); err != nil {
+	// Восстанавливаем растения (один раз за засуху)
+	revivals, err := h.plantRevivalRepo.ReviveAll(ctx, userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not available") {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{
-		"message": "Растения успешно восстановлены",
+	return c.JSON(http.StatusOK, RecoverPlantsResponse{
+		Message:       "Растения успешно восстановлены",
+		PlantsRevived: len(revivals),
+		Revivals:      revivals,
 	})
 }
 
@@ -141,7 +222,13 @@ func (h *UserHandler) SyncUserData(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	isDrought := stats.IsDrought
+	drought := h.droughtService.State(stats)
+
+	droughtEvents, err := h.droughtEventRepo.GetByUser(ctx, userID, syncDroughtEventsLimit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
 	tasks, err := h.taskRepo.GetAll(ctx, userID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
@@ -182,6 +269,31 @@ func (h *UserHandler) SyncUserData(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
+	items, err := h.userItemRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	canRecoverPlants, err := h.plantRevivalRepo.CanReviveAll(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	plantEffects, err := h.plantEffectRepo.GetActiveByUser(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	barn, err := h.produceRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	decorations, err := h.decorationRepo.GetPlaced(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
 	plantMap := make(map[int]IPlant)
 	for _, plant := range plants {
 		plantMap[plant.BedID] = IPlant{
@@ -190,6 +302,9 @@ func (h *UserHandler) SyncUserData(c echo.Context) error {
 			CurrentGrowth: plant.CurrentGrowth,
 			TargetGrowth:  plant.TargetGrowth,
 			ImgPath:       plant.SeedImgPlant,
+			Stage:         plant.Stage,
+			StageCount:    plant.StageCount,
+			StageImgPath:  plant.StageImgPath,
 		}
 	}
 
@@ -202,6 +317,8 @@ func (h *UserHandler) SyncUserData(c echo.Context) error {
 
 		field[i] = IBed{
 			ID:     bed.ID,
+			X:      bed.X,
+			Y:      bed.Y,
 			Plant:  plant,
 			IsLock: bed.IsLocked,
 		}
@@ -220,15 +337,27 @@ func (h *UserHandler) SyncUserData(c echo.Context) error {
 			TargetGrowth:  plant.TargetGrowth,
 			Icon:          plant.SeedIcon,
 			ImgPath:       plant.SeedImgPlant,
+			Stage:         plant.Stage,
+			StageCount:    plant.StageCount,
+			StageImgPath:  plant.StageImgPath,
+			Effects:       plantEffects[plant.ID],
+		}
+		if seeds[i].Effects == nil {
+			seeds[i].Effects = []model.PlantEffect{}
 		}
 	}
 
 	response := ServerFarmData{
 		CurrentXp:      int(stats.Experience),
+		Level:          stats.Level(),
+		XpToNextLevel:  int(stats.ExperienceForNextLevel()),
 		Coins:          int(stats.Gold),
 		Strick:         stats.CurrentStreak,
 		DidTaskToday:   didTaskToday,
-		IsDrought:      isDrought,
+		IsDrought:      drought.IsDrought,
+		Drought:        drought,
+		DroughtEvents:  droughtEvents,
+		CanRecover:     canRecoverPlants,
 		Tasks:          tasks,
 		Habits:         habits,
 		Tags:           tags,
@@ -236,6 +365,9 @@ func (h *UserHandler) SyncUserData(c echo.Context) error {
 		Seeds:          seeds,
 		InventorySeeds: inventorySeeds,
 		ShopStorage:    shopStorage,
+		Items:          items,
+		Barn:           barn,
+		Decorations:    decorations,
 	}
 
 	return c.JSON(http.StatusOK, response)
@@ -272,7 +404,7 @@ func (h *UserHandler) createUserWithDefaults(ctx context.Context, userID int64)
 	}
 
 	// Создаем начальные грядки
-	if err := h.bedRepo.CreateInitialBeds(ctx, user.ID, 9); err != nil {
+	if err := h.bedRepo.CreateInitialBeds(ctx, user.ID, constants.InitialFarmWidth, constants.InitialFarmHeight); err != nil {
 		return fmt.Errorf("failed to create initial beds: %w", err)
 	}
 
@@ -319,6 +451,14 @@ func (h *UserHandler) transformShopGoods(ctx context.Context, goods []model.Good
 				Rarity:       seed.Rarity,
 			}
 
+		case "consumable", "fertilizer", "tool":
+			// Для предметов получаем их описание
+			item, err := h.itemRepo.GetByID(ctx, good.IDGood)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get item details for ID %d: %w", good.IDGood, err)
+			}
+			shopItem.Item = newItemInfo(item)
+
 		default:
 			// Для других типов товаров можно добавить соответствующую логику
 			shopItem.Item = nil
@@ -449,7 +589,7 @@ func (h *UserHandler) CreateOrUpdateUser(c echo.Context) error {
 	}
 
 	// Создаем начальные грядки
-	if err := h.bedRepo.CreateInitialBeds(ctx, user.ID, 9); err != nil {
+	if err := h.bedRepo.CreateInitialBeds(ctx, user.ID, constants.InitialFarmWidth, constants.InitialFarmHeight); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
@@ -508,6 +648,25 @@ func (h *UserHandler) createInitialShopGoods(ctx context.Context, userID int64)
 		return fmt.Errorf("failed to create bed good: %w", err)
 	}
 
+	// Лейки, удобрения и инструменты (id_good — идентификатор предмета, найденного по коду)
+	for _, shopItem := range constants.ShopItemGoods {
+		item, err := h.itemRepo.GetByCode(ctx, shopItem.Code)
+		if err != nil {
+			return fmt.Errorf("failed to create item good %s: %w", shopItem.Code, err)
+		}
+
+		good := &model.Good{
+			UserID:   userID,
+			Type:     item.Type,
+			IDGood:   item.ID,
+			Quantity: shopItem.Quantity,
+			Cost:     shopItem.Cost,
+		}
+		if err := h.goodRepo.CreateOrUpdate(ctx, good); err != nil {
+			return fmt.Errorf("failed to create item good %s: %w", shopItem.Code, err)
+		}
+	}
+
 	return nil
 }
 
@@ -556,6 +715,53 @@ func (h *UserHandler) UpdateUser(c echo.Context) error {
 	return c.JSON(http.StatusOK, user)
 }
 
+// UpdatePrivacy godoc
+// @Summary Изменить приватность фермы
+// @Description Задает, что друзья видят на ферме пользователя (GET /friends/{id}/farm): full — грядки, растения, уровень и статистику; level_only — только уровень и статистику; hidden — ферма скрыта
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param request body UserPrivacyRequest true "Настройка приватности"
+// @Success 200 {object} model.User
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/me/privacy [put]
+func (h *UserHandler) UpdatePrivacy(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var req UserPrivacyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	if !slices.Contains(constants.ValidFarmPrivacy, req.FarmPrivacy) {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "farmPrivacy must be one of: " + strings.Join(constants.ValidFarmPrivacy, ", "),
+		})
+	}
+
+	ctx := context.Background()
+	if err := h.repo.SetFarmPrivacy(ctx, userID, req.FarmPrivacy); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "User not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	user, err := h.repo.GetByID(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, user)
+}
+
 // DTO для запросов
 
 // UserCreateRequest представляет запрос на создание/обновление пользователя
@@ -569,18 +775,28 @@ type UserUpdateRequest struct {
 	Username string `json:"username" example:"john_doe_updated"`
 }
 
+// UserPrivacyRequest представляет запрос на изменение приватности фермы
+type UserPrivacyRequest struct {
+	FarmPrivacy string `json:"farmPrivacy" example:"level_only"`
+}
+
 // IPlant представляет растение на грядке
 type IPlant struct {
 	ID            int    `json:"id"`
 	Name          string `json:"name"`
 	CurrentGrowth int    `json:"currentGrowth"`
 	TargetGrowth  int    `json:"targetGrowth"`
-	ImgPath       string `json:"imgPath"`
+	ImgPath       string `json:"imgPath"`      // Папка с картинками стадий растения
+	Stage         int    `json:"stage"`        // Текущая стадия роста, с 1
+	StageCount    int    `json:"stageCount"`   // Количество стадий роста семени
+	StageImgPath  string `json:"stageImgPath"` // Картинка текущей стадии
 }
 
 // IBed представляет грядку с растением
 type IBed struct {
 	ID     int     `json:"id"`
+	X      int     `json:"x"`
+	Y      int     `json:"y"`
 	Plant  *IPlant `json:"plant"`
 	IsLock bool    `json:"isLock"`
 }
@@ -588,10 +804,15 @@ type IBed struct {
 // ServerFarmData основной формат ответа для синхронизации
 type ServerFarmData struct {
 	CurrentXp      int                         `json:"currentXp"`
+	Level          int                         `json:"level"`
+	XpToNextLevel  int                         `json:"xpToNextLevel"`
 	Coins          int                         `json:"coins"`
 	Strick         int                         `json:"strick"`
 	DidTaskToday   bool                        `json:"didTaskToday"`
 	IsDrought      bool                        `json:"isDrought"`
+	Drought        *model.DroughtState         `json:"drought"`
+	DroughtEvents  []model.DroughtEvent        `json:"droughtEvents"`
+	CanRecover     bool                        `json:"canRecover"` // Доступно бесплатное массовое восстановление
 	Tasks          []model.Task                `json:"tasks"`
 	Habits         []model.Habit               `json:"habits"`
 	Tags           []model.Tag                 `json:"tags"`
@@ -599,6 +820,9 @@ type ServerFarmData struct {
 	Seeds          []SeedStorage               `json:"seeds"`
 	InventorySeeds []model.UserSeedWithDetails `json:"inventorySeeds"`
 	ShopStorage    []ShopItem                  `json:"shopItem"`
+	Items          []model.UserItemWithDetails `json:"items"`
+	Barn           []model.Produce             `json:"barn"` // Урожай в амбаре, который можно продать на рынке
+	Decorations    []model.PlacedDecoration    `json:"decorations"`
 }
 
 // ShopItem представляет товар в магазине
@@ -620,6 +844,34 @@ type SeedItem struct {
 	Rarity       string `json:"rarity"`
 }
 
+// ItemInfo представляет предмет (расходник) в магазине
+type ItemInfo struct {
+	ID          int     `json:"id"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	Icon        *string `json:"icon,omitempty"`
+	Type        string  `json:"type"`
+}
+
+func newItemInfo(item *model.Item) ItemInfo {
+	return ItemInfo{
+		ID:          item.ID,
+		Code:        item.Code,
+		Name:        item.Name,
+		Description: item.Description,
+		Icon:        item.Icon,
+		Type:        item.Type,
+	}
+}
+
+// RecoverPlantsResponse представляет ответ при массовом восстановлении растений
+type RecoverPlantsResponse struct {
+	Message       string               `json:"message"`
+	PlantsRevived int                  `json:"plantsRevived" example:"3"`
+	Revivals      []model.PlantRevival `json:"revivals"`
+}
+
 // SeedStorage представляет растение на грядке (для отдельного списка)
 type SeedStorage struct {
 	ID            int       `json:"id"`
@@ -632,4 +884,9 @@ type SeedStorage struct {
 	TargetGrowth  int       `json:"targetGrowth,omitempty"`
 	Icon          string    `json:"icon,omitempty"`
 	ImgPath       string    `json:"imgPath,omitempty"`
+	Stage         int       `json:"stage"`
+	StageCount    int       `json:"stageCount"`
+	StageImgPath  string    `json:"stageImgPath,omitempty"`
+	// Effects — действующие эффекты предметов (удобрения, инструменты)
+	Effects []model.PlantEffect `json:"effects"`
 }
@@ -2,37 +2,66 @@ package handler
 
 import (
 	"context"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	defaultPlantHistoryLimit = 50
+	maxPlantHistoryLimit     = 200
+
+	defaultPlantRevivalsLimit = 50
+	maxPlantRevivalsLimit     = 200
+)
+
 type UserPlantHandler struct {
 	BaseHandler
 	repo         *repository.UserPlantRepo
-	userSeedRepo *repository.UserSeedRepo
 	userStatRepo *repository.UserStatRepo
-	bedRepo      *repository.BedRepo
 	seedRepo     *repository.SeedRepo
+	levelService *service.LevelService
+
+	plantHistoryRepo *repository.PlantHistoryRepo
+	plantRevivalRepo *repository.PlantRevivalRepo
+	itemService      *service.ItemService
+	seedDropService  *service.SeedDropService
+	breedingService  *service.BreedingService
+	achievements     *service.AchievementService
 }
 
 func NewUserPlantHandler(
 	repo *repository.UserPlantRepo,
-	userSeedRepo *repository.UserSeedRepo,
 	userStatRepo *repository.UserStatRepo,
-	bedRepo *repository.BedRepo,
 	seedRepo *repository.SeedRepo,
+	levelService *service.LevelService,
+	plantHistoryRepo *repository.PlantHistoryRepo,
+	plantRevivalRepo *repository.PlantRevivalRepo,
+	itemService *service.ItemService,
+	seedDropService *service.SeedDropService,
+	breedingService *service.BreedingService,
+	achievements *service.AchievementService,
 ) *UserPlantHandler {
 	return &UserPlantHandler{
 		repo:         repo,
-		userSeedRepo: userSeedRepo,
 		userStatRepo: userStatRepo,
-		bedRepo:      bedRepo,
 		seedRepo:     seedRepo,
+		levelService: levelService,
+
+		plantHistoryRepo: plantHistoryRepo,
+		plantRevivalRepo: plantRevivalRepo,
+		itemService:      itemService,
+		seedDropService:  seedDropService,
+		breedingService:  breedingService,
+		achievements:     achievements,
 	}
 }
 
@@ -106,7 +135,7 @@ func (h *UserPlantHandler) GetUserPlantByID(c echo.Context) error {
 
 // CreateUserPlant godoc
 // @Summary Посадить новое растение
-// @Description Сажает новое растение на свободную грядку по cellNumber, используя семя из инвентаря
+// @Description Сажает новое растение на свободную открытую грядку с координатами (x, y), используя семя из инвентаря. Координаты должны быть в пределах поля (см. GET /beds/layout)
 // @Tags user-plants
 // @Accept json
 // @Produce json
@@ -131,61 +160,30 @@ func (h *UserPlantHandler) CreateUserPlant(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
-	ctx := context.Background()
-
-	// Ищем грядку по cellNumber у пользователя
-	bed, err := h.bedRepo.GetByCellNumber(ctx, userID, req.CellNumber)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{"error": "Bed not found for this cell number"})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	// Проверяем, что грядка не заблокирована
-	if bed.IsLocked {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Bed is locked"})
+	if req.X == nil || req.Y == nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "x and y are required"})
 	}
-
-	// Проверяем, что грядка свободна
-	existingPlant, err := h.repo.GetByBed(ctx, bed.ID)
-	if err != nil && !strings.Contains(err.Error(), "not found") {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	if existingPlant != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Bed is already occupied"})
+	if *req.X < 0 || *req.Y < 0 || *req.X >= constants.MaxFarmWidth || *req.Y >= constants.MaxFarmHeight {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid coordinates"})
 	}
 
-	// Проверяем, что у пользователя есть семена
-	userSeed, err := h.userSeedRepo.GetByUserAndSeed(ctx, userID, req.SeedID)
-	if err != nil && !strings.Contains(err.Error(), "not found") {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	if userSeed == nil || userSeed.Quantity < 1 {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Not enough seeds"})
-	}
+	ctx := context.Background()
 
-	// Используем одно семя
-	success, err := h.userSeedRepo.SubtractQuantity(ctx, userID, req.SeedID, 1)
+	// Грядка блокируется на время посадки, чтобы на клетку одновременно не поставили декорацию
+	plant, err := h.repo.Plant(ctx, userID, req.SeedID, *req.X, *req.Y)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	if !success {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Failed to use seed"})
-	}
-
-	// Создаем растение
-	plant := &model.UserPlant{
-		UserID:        userID,
-		SeedID:        req.SeedID,
-		BedID:         bed.ID, // Используем найденный bed.ID
-		CurrentGrowth: 0,
-	}
-
-	if err := h.repo.Create(ctx, plant); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Bed not found at these coordinates"})
+		case strings.Contains(err.Error(), "bed is locked"):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Bed is locked"})
+		case strings.Contains(err.Error(), "already occupied"):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Bed is already occupied"})
+		case strings.Contains(err.Error(), "decoration"):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Cell is occupied by a decoration"})
+		case strings.Contains(err.Error(), "not enough"):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Not enough seeds"})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
@@ -203,69 +201,16 @@ func (h *UserPlantHandler) CreateUserPlant(c echo.Context) error {
 		TargetGrowth:   seed.TargetGrowth,
 		ImgPath:      seed.ImgPlant, // Используем поле img_plant из семени
 	}
+	iPlant.Stage, iPlant.StageCount, iPlant.StageImgPath = utils.PlantStage(
+		seed.ImgPlant, plant.CurrentGrowth, seed.TargetGrowth, seed.StageThresholds,
+	)
 
 	return c.JSON(http.StatusOK, iPlant)
 }
 
-// AddGrowth godoc
-// @Summary Добавить рост растению
-// @Description Увеличивает текущий рост растения на указанное количество
-// @Tags user-plants
-// @Accept json
-// @Produce json
-// @Security ApiKeyAuth
-// @Param X-User-ID header string true "User ID"
-// @Param id path int true "Plant ID"
-// @Param request body UserPlantGrowthRequest true "Количество роста для добавления"
-// @Success 200 {object} UserPlantGrowthResponse
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 403 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /user-plants/{id}/add-growth [post]
-func (h *UserPlantHandler) AddGrowth(c echo.Context) error {
-	userID, err := h.GetUserIDFromContext(c)
-	if err != nil {
-		return err
-	}
-
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid plant ID"})
-	}
-
-	var req UserPlantGrowthRequest
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
-	}
-
-	// Проверяем, что растение принадлежит пользователю
-	plant, err := h.repo.GetByID(context.Background(), id)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	if plant.UserID != userID {
-		return c.JSON(http.StatusForbidden, map[string]string{"error": "access denied"})
-	}
-
-	newGrowth, err := h.repo.AddGrowth(context.Background(), id, req.GrowthAmount)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	return c.JSON(http.StatusOK, UserPlantGrowthResponse{
-		NewGrowth: newGrowth,
-	})
-}
-
 // HarvestPlant godoc
 // @Summary Собрать растение
-// @Description Собирает готовое к сбору растение и начисляет награды
+// @Description Собирает готовое к сбору растение: добавляет урожай в амбар (produceEarned единиц, его можно продать на рынке /market) и начисляет опыт. Урожай и опыт увеличиваются на harvestYieldBonus от инструментов и соседних декораций; дробная часть бонуса выпадает дополнительной единицей с соответствующим шансом. С шансом, зависящим от редкости семени, выпадают семена того же вида и иногда семя следующей редкости (seedDrops). Зрелые растения на соседних грядках скрещиваются с собираемым: если для пары семян есть рецепт, с его шансом выводится гибридное семя (hybrids). Сбор записывается в историю растений. Засохшие растения собрать нельзя (400); при повышении уровня возвращает блок levelUp. Открытые сбором достижения возвращаются в achievements, выполненные ежедневные задания — в completedQuests. Поле growthMultiplier (1 + modification семени) показывает множитель, с которым растение получало очки роста
 // @Tags user-plants
 // @Accept json
 // @Produce json
@@ -310,49 +255,96 @@ func (h *UserPlantHandler) HarvestPlant(c echo.Context) error {
 		return c.JSON(http.StatusNotFound, map[string]string{"error": "Plant not found"})
 	}
 
+	// Засохшее растение собрать нельзя — его нужно сначала оживить
+	if plantToHarvest.IsWithered {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Plant is withered and cannot be harvested"})
+	}
+
 	// Проверяем, готово ли растение к сбору
 	if plantToHarvest.CurrentGrowth < plantToHarvest.TargetGrowth {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Plant is not ready for harvest"})
 	}
 
-	// Вычисляем награды
-	goldEarned := (plantToHarvest.GoldReward * plantToHarvest.GrowthPercent) / 100
+	// Вычисляем награды: урожай отправляется в амбар, золото за него можно получить на рынке
 	xpEarned := (plantToHarvest.XPReward * plantToHarvest.GrowthPercent) / 100
 
-	// Добавляем награды пользователю
-	if goldEarned > 0 {
-		if err := h.userStatRepo.AddGold(ctx, userID, int64(goldEarned)); err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-		}
+	// Инструменты и соседние декорации увеличивают урожай, дробная часть бонуса выпадает с шансом
+	produceEarned := h.seedDropService.RollYield(constants.HarvestBaseYield, plantToHarvest.HarvestYieldBonus)
+	xpEarned = h.seedDropService.RollYield(xpEarned, plantToHarvest.HarvestYieldBonus)
+
+	stats, err := h.userStatRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	if xpEarned > 0 {
-		if err := h.userStatRepo.AddExperience(ctx, userID, int64(xpEarned)); err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-		}
+	seed, err := h.seedRepo.GetByID(ctx, plantToHarvest.SeedID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	// Увеличиваем счетчик собранных растений
-	stats, err := h.userStatRepo.GetByUserID(ctx, userID)
+	// Бросаем кости на выпадение семян
+	seedDrops, err := h.seedDropService.RollHarvest(ctx, plantToHarvest.SeedID, stats.Level())
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	stats.TotalPlantHarvested++
-	if err := h.userStatRepo.Update(ctx, stats); err != nil {
+	// Скрещиваем с зрелыми растениями на соседних грядках
+	hybrids, err := h.breedingService.BreedOnHarvest(ctx, userID, plantToHarvest, plants)
+	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	// Удаляем растение
-	if err := h.repo.Delete(ctx, id); err != nil {
+	// Убираем растение с грядки и начисляем все награды в одной транзакции
+	record, err := h.repo.Harvest(ctx, &model.Harvest{
+		UserID:    userID,
+		PlantID:   id,
+		SeedID:    plantToHarvest.SeedID,
+		Produce:   produceEarned,
+		XP:        xpEarned,
+		SeedDrops: seedDrops,
+		Hybrids:   hybrids,
+	})
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Plant not found"})
+		case strings.Contains(err.Error(), "withered"), strings.Contains(err.Error(), "not ready"):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
+	// Сбор уже сохранен, поэтому ошибки ниже не превращают ответ в 500, а только логируются
+	levelUp, err := h.levelService.LevelUp(ctx, record.Experience)
+	if err != nil {
+		log.Printf("Error describing level up for user %d after harvesting plant %d: %v", userID, id, err)
+	}
+
+	// Проверяем достижения: счетчик сбора, первый легендарный урожай и первый гибрид
+	events := []string{}
+	if seed.Rarity == constants.RarityLegendary {
+		events = append(events, constants.AchievementEventLegendaryHarvest)
+	}
+	if len(hybrids) > 0 {
+		events = append(events, constants.AchievementEventHybridBred)
+	}
+
+	achievements, err := h.achievements.Evaluate(ctx, userID, events...)
+	if err != nil {
+		log.Printf("Error evaluating achievements for user %d after harvesting plant %d: %v", userID, id, err)
+	}
+
 	result := model.UserPlantHarvestResult{
 		UserPlantWithSeed: *plantToHarvest,
-		GoldEarned:        goldEarned,
+		ProduceEarned:     produceEarned,
+		ProduceTotal:      record.ProduceTotal,
 		XPEarned:          xpEarned,
 		IsReady:           true,
+		LevelUp:           levelUp,
+		SeedDrops:         seedDrops,
+		Hybrids:           hybrids,
+		Achievements:      achievements,
+		CompletedQuests:   record.CompletedQuests,
 	}
 
 	return c.JSON(http.StatusOK, result)
@@ -360,7 +352,7 @@ func (h *UserPlantHandler) HarvestPlant(c echo.Context) error {
 
 // DeleteUserPlant godoc
 // @Summary Удалить растение
-// @Description Удаляет растение (например, если оно погибло)
+// @Description Удаляет растение с грядки и записывает удаление в историю растений
 // @Tags user-plants
 // @Accept json
 // @Produce json
@@ -398,7 +390,7 @@ func (h *UserPlantHandler) DeleteUserPlant(c echo.Context) error {
 		return c.JSON(http.StatusForbidden, map[string]string{"error": "access denied"})
 	}
 
-	err = h.repo.Delete(context.Background(), id)
+	err = h.repo.Archive(context.Background(), id, constants.PlantEventRemoved, nil)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -485,16 +477,241 @@ func (h *UserPlantHandler) GetGrowingPlants(c echo.Context) error {
 
 // UserPlantCreateRequest представляет запрос на посадку растения
 type UserPlantCreateRequest struct {
-	SeedID     int `json:"seedId" example:"1"`
-	CellNumber int `json:"cellNumber" example:"1"`
+	SeedID int  `json:"seedId" example:"1"`
+	X      *int `json:"x" example:"0"`
+	Y      *int `json:"y" example:"0"`
+}
+
+// GetPlantHistory godoc
+// @Summary История растений
+// @Description Возвращает историю растений пользователя, убранных с грядок: собранные, погибшие (засохли или погибли от засухи) и удаленные, начиная с последних
+// @Tags user-plants
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param event query string false "Тип события: harvested, died или removed"
+// @Param limit query int false "Количество записей (по умолчанию 50, максимум 200)"
+// @Success 200 {array} model.PlantHistory
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user-plants/history [get]
+func (h *UserPlantHandler) GetPlantHistory(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	event := c.QueryParam("event")
+	switch event {
+	case "", constants.PlantEventHarvested, constants.PlantEventDied, constants.PlantEventRemoved:
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid event"})
+	}
+
+	limit := defaultPlantHistoryLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+		}
+		if limit > maxPlantHistoryLimit {
+			limit = maxPlantHistoryLimit
+		}
+	}
+
+	history, err := h.plantHistoryRepo.GetByUser(context.Background(), userID, event, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, history)
 }
 
-// UserPlantGrowthRequest представляет запрос на добавление роста
-type UserPlantGrowthRequest struct {
-	GrowthAmount int `json:"growthAmount" example:"10"`
+// RevivePlant godoc
+// @Summary Оживить засохшее растение
+// @Description Оживляет одно засохшее растение, расходуя одну лейку (watering_can). Лейки продаются в магазине и выдаются за каждые 7 дней стрика. Оживление записывается в журнал
+// @Tags user-plants
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Plant ID"
+// @Success 200 {object} RevivePlantResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user-plants/{id}/revive [post]
+func (h *UserPlantHandler) RevivePlant(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid plant ID"})
+	}
+
+	ctx := context.Background()
+
+	plant, err := h.repo.GetByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	if plant.UserID != userID {
+		return c.JSON(http.StatusForbidden, map[string]string{"error": "access denied"})
+	}
+
+	if !plant.IsWithered {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Plant is not withered"})
+	}
+
+	revival, remaining, err := h.plantRevivalRepo.ReviveWithWateringCan(ctx, userID, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not enough") || strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	plant.IsWithered = false
+	plant.WitheredAt = nil
+
+	return c.JSON(http.StatusOK, RevivePlantResponse{
+		Plant:                 *plant,
+		Revival:               *revival,
+		WateringCansRemaining: remaining,
+	})
+}
+
+// GetPlantRevivals godoc
+// @Summary Журнал оживления растений
+// @Description Возвращает журнал оживления засохших растений пользователя (лейкой или массовым восстановлением), начиная с последних
+// @Tags user-plants
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param limit query int false "Количество записей (по умолчанию 50, максимум 200)"
+// @Success 200 {array} model.PlantRevival
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user-plants/revivals [get]
+func (h *UserPlantHandler) GetPlantRevivals(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	limit := defaultPlantRevivalsLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+		}
+		if limit > maxPlantRevivalsLimit {
+			limit = maxPlantRevivalsLimit
+		}
+	}
+
+	revivals, err := h.plantRevivalRepo.GetByUser(context.Background(), userID, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, revivals)
+}
+
+// RevivePlantResponse представляет ответ при оживлении растения
+type RevivePlantResponse struct {
+	Plant                 model.UserPlant    `json:"plant"`
+	Revival               model.PlantRevival `json:"revival"`
+	WateringCansRemaining int                `json:"wateringCansRemaining" example:"2"`
+}
+
+// ApplyItem godoc
+// @Summary Применить предмет к растению
+// @Description Применяет предмет из инвентаря к растению и списывает его. Удобрение сразу добавляет очки роста, стимулятор роста временно увеличивает множитель роста, секатор увеличивает урожай при сборе, лейка оживляет засохшее растение. Эффекты одного предмета складываются до maxStacks и действуют durationHours часов (или до сбора, если длительность не задана)
+// @Tags user-plants
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-User-ID header string true "User ID"
+// @Param id path int true "Plant ID"
+// @Param request body ApplyItemRequest true "Предмет (itemId или itemCode)"
+// @Success 200 {object} model.ItemApplyResult
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /user-plants/{id}/apply [post]
+func (h *UserPlantHandler) ApplyItem(c echo.Context) error {
+	userID, err := h.GetUserIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid plant ID"})
+	}
+
+	var req ApplyItemRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if req.ItemID <= 0 && req.ItemCode == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "itemId or itemCode is required"})
+	}
+
+	ctx := context.Background()
+
+	plant, err := h.repo.GetByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	if plant.UserID != userID {
+		return c.JSON(http.StatusForbidden, map[string]string{"error": "access denied"})
+	}
+
+	item, err := h.itemService.GetItem(ctx, req.ItemID, req.ItemCode)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	result, err := h.itemService.Apply(ctx, userID, id, item)
+	if err != nil {
+		if strings.Contains(err.Error(), "not enough") ||
+			strings.Contains(err.Error(), "maximum stacks") ||
+			strings.Contains(err.Error(), "cannot be applied") ||
+			strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, result)
 }
 
-// UserPlantGrowthResponse представляет ответ при добавлении роста
-type UserPlantGrowthResponse struct {
-	NewGrowth int `json:"newGrowth" example:"50"`
+// ApplyItemRequest представляет запрос на применение предмета к растению
+type ApplyItemRequest struct {
+	ItemID   int    `json:"itemId" example:"2"`
+	ItemCode string `json:"itemCode" example:"fertilizer"`
 }
@@ -6,7 +6,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/RinatHar/FarmFocus/api/internal/repository"
 	"github.com/labstack/echo/v4"
 )
@@ -98,86 +97,6 @@ func (h *UserSeedHandler) GetAvailableSeeds(c echo.Context) error {
 	return c.JSON(http.StatusOK, seeds)
 }
 
-// AddSeed godoc
-// @Summary Добавить семена пользователю
-// @Description Добавляет новые семена или увеличивает количество существующих семян в инвентаре
-// @Tags user-seeds
-// @Accept json
-// @Produce json
-// @Security ApiKeyAuth
-// @Param X-User-ID header string true "User ID"
-// @Param request body UserSeedAddRequest true "Данные для добавления семян"
-// @Success 200 {object} model.UserSeed
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /user-seeds [post]
-func (h *UserSeedHandler) AddSeed(c echo.Context) error {
-	userID, err := h.GetUserIDFromContext(c)
-	if err != nil {
-		return err
-	}
-
-	var req UserSeedAddRequest
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
-	}
-
-	userSeed := &model.UserSeed{
-		UserID:   userID,
-		SeedID:   req.SeedID,
-		Quantity: req.Quantity,
-	}
-
-	if err := h.repo.CreateOrUpdate(context.Background(), userSeed); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-	return c.JSON(http.StatusOK, userSeed)
-}
-
-// AddQuantity godoc
-// @Summary Добавить количество семян
-// @Description Увеличивает количество существующих семян в инвентаре
-// @Tags user-seeds
-// @Accept json
-// @Produce json
-// @Security ApiKeyAuth
-// @Param X-User-ID header string true "User ID"
-// @Param seedId path int true "Seed ID"
-// @Param request body UserSeedQuantityRequest true "Количество для добавления"
-// @Success 204 "No Content"
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /user-seeds/{seedId}/add [post]
-func (h *UserSeedHandler) AddQuantity(c echo.Context) error {
-	userID, err := h.GetUserIDFromContext(c)
-	if err != nil {
-		return err
-	}
-
-	seedID, err := strconv.Atoi(c.Param("seedId"))
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid seed ID"})
-	}
-
-	var req UserSeedQuantityRequest
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
-	}
-
-	err = h.repo.AddQuantity(context.Background(), userID, seedID, req.Amount)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
-		}
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-
-	return c.NoContent(http.StatusNoContent)
-}
-
 // SubtractQuantity godoc
 // @Summary Уменьшить количество семян
 // @Description Уменьшает количество семян в инвентаре (например, при посадке)
@@ -289,12 +208,6 @@ func (h *UserSeedHandler) GetSeedCount(c echo.Context) error {
 
 // DTO для запросов
 
-// UserSeedAddRequest представляет запрос на добавление семян
-type UserSeedAddRequest struct {
-	SeedID   int   `json:"seedId" example:"1"`
-	Quantity int64 `json:"quantity" example:"5"`
-}
-
 // UserSeedQuantityRequest представляет запрос на изменение количества семян
 type UserSeedQuantityRequest struct {
 	Amount int64 `json:"amount" example:"3"`
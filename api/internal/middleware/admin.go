@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/context"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/labstack/echo/v4"
+)
+
+// AdminMiddleware пропускает запрос только для пользователей с ролью admin.
+// Должен стоять после AuthMiddleware: userID берется из контекста
+func AdminMiddleware(userRepo *repository.UserRepo) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, ok := context.GetUserID(c.Request().Context())
+			if !ok {
+				return c.JSON(http.StatusUnauthorized, map[string]string{
+					"error": "user not authenticated",
+				})
+			}
+
+			user, err := userRepo.GetByID(c.Request().Context(), userID)
+			if err != nil {
+				if strings.Contains(err.Error(), "not found") {
+					return c.JSON(http.StatusForbidden, map[string]string{
+						"error": "admin access required",
+					})
+				}
+				return c.JSON(http.StatusInternalServerError, map[string]string{
+					"error": err.Error(),
+				})
+			}
+
+			if user.Role != constants.RoleAdmin {
+				return c.JSON(http.StatusForbidden, map[string]string{
+					"error": "admin access required",
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/context"
+	"github.com/labstack/echo/v4"
+)
+
+// rateWindow — счетчик запросов пользователя в текущем окне
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// RateLimitMiddleware ограничивает количество запросов пользователя: не больше limit за window.
+// Счетчики общие для всех маршрутов, к которым подключен один экземпляр middleware, и хранятся в памяти.
+// Должен стоять после AuthMiddleware: userID берется из контекста
+func RateLimitMiddleware(limit int, window time.Duration) echo.MiddlewareFunc {
+	var (
+		mu          sync.Mutex
+		windows     = map[int64]*rateWindow{}
+		lastCleanup = time.Now()
+	)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, ok := context.GetUserID(c.Request().Context())
+			if !ok {
+				return next(c)
+			}
+
+			now := time.Now()
+			mu.Lock()
+			// Периодически удаляем истекшие окна, чтобы карта не росла бесконечно
+			if now.Sub(lastCleanup) > window {
+				for id, w := range windows {
+					if now.Sub(w.start) >= window {
+						delete(windows, id)
+					}
+				}
+				lastCleanup = now
+			}
+
+			w, exists := windows[userID]
+			if !exists || now.Sub(w.start) >= window {
+				w = &rateWindow{start: now}
+				windows[userID] = w
+			}
+			w.count++
+			exceeded := w.count > limit
+			retryAfter := w.start.Add(window).Sub(now)
+			mu.Unlock()
+
+			if exceeded {
+				c.Response().Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+				return c.JSON(http.StatusTooManyRequests, map[string]string{
+					"error": "too many requests, try again later",
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// Achievement — определение достижения
+type Achievement struct {
+	ID           int     `json:"id"`
+	Code         string  `json:"code"`
+	Name         string  `json:"name"`
+	Description  *string `json:"description,omitempty"`
+	Icon         *string `json:"icon,omitempty"`
+	Kind         string  `json:"kind"`              // counter, event
+	Counter      *string `json:"counter,omitempty"` // tasks_completed, plants_harvested, longest_streak
+	Event        *string `json:"event,omitempty"`   // legendary_harvest, hybrid_bred
+	Threshold    int     `json:"threshold"`
+	RewardType   *string `json:"rewardType,omitempty"` // gold, seed, item
+	RewardSeedID *int    `json:"rewardSeedId,omitempty"`
+	RewardItemID *int    `json:"rewardItemId,omitempty"`
+	RewardAmount int     `json:"rewardAmount"`
+}
+
+// UserAchievement — достижение с прогрессом пользователя
+type UserAchievement struct {
+	Achievement
+	Progress   int        `json:"progress"` // Текущее значение счетчика (не больше порога); для событий 0 или 1
+	Unlocked   bool       `json:"unlocked"`
+	UnlockedAt *time.Time `json:"unlockedAt,omitempty"`
+}
+
+// AchievementUnlock — только что открытое достижение
+type AchievementUnlock struct {
+	AchievementID int     `json:"achievementId"`
+	Code          string  `json:"code"`
+	Name          string  `json:"name"`
+	RewardType    *string `json:"rewardType,omitempty"`
+	RewardAmount  int     `json:"rewardAmount"`
+}
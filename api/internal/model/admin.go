@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// AdminAuditEntry — запись журнала ручных изменений, сделанных администратором
+type AdminAuditEntry struct {
+	ID           int       `json:"id"`
+	AdminID      *int64    `json:"adminId,omitempty"`
+	TargetUserID int64     `json:"targetUserId"`
+	Action       string    `json:"action"` // experience, gold, streak
+	ValueBefore  int64     `json:"valueBefore"`
+	ValueAfter   int64     `json:"valueAfter"`
+	Reason       string    `json:"reason"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// AdminOverrideResult — результат ручного изменения прогресса
+type AdminOverrideResult struct {
+	Entry   AdminAuditEntry `json:"entry"`
+	LevelUp *LevelUp        `json:"levelUp,omitempty"` // Повышение уровня после начисления опыта
+}
@@ -6,6 +6,8 @@ type Bed struct {
 	ID         int       `json:"id"`
 	UserID     int64     `json:"userId"`
 	CellNumber int       `json:"cellNumber"`
+	X          int       `json:"x"` // Столбец на поле, с нуля
+	Y          int       `json:"y"` // Ряд на поле, с нуля
 	IsLocked   bool      `json:"isLocked"`
 	CreatedAt  time.Time `json:"createdAt"`
 }
@@ -14,3 +16,37 @@ type BedWithUserPlant struct {
 	Bed
 	UserPlant *UserPlantWithSeed `json:"userPlant,omitempty"`
 }
+
+// FarmCell — клетка поля: грядка (закрытая, пустая, с растением или с декорацией)
+type FarmCell struct {
+	Bed
+	IsEmpty    bool               `json:"isEmpty"`
+	Plant      *UserPlantWithSeed `json:"plant,omitempty"`
+	Decoration *PlacedDecoration  `json:"decoration,omitempty"`
+}
+
+// FarmLayout — поле пользователя в виде сетки Width x Height.
+// Cells упорядочены по рядам (y), затем по столбцам (x).
+type FarmLayout struct {
+	Width             int        `json:"width"`
+	Height            int        `json:"height"`
+	MaxWidth          int        `json:"maxWidth"`
+	MaxHeight         int        `json:"maxHeight"`
+	NextExpansionCost int        `json:"nextExpansionCost"`
+	CanExpandRow      bool       `json:"canExpandRow"`
+	CanExpandColumn   bool       `json:"canExpandColumn"`
+	Cells             []FarmCell `json:"cells"`
+}
+
+// FarmExpansion — покупка расширения поля
+type FarmExpansion struct {
+	ID        int       `json:"id"`
+	UserID    int64     `json:"userId"`
+	Direction string    `json:"direction"`
+	Cost      int       `json:"cost"`
+	Width     int       `json:"width"`  // Ширина поля после расширения
+	Height    int       `json:"height"` // Высота поля после расширения
+	Beds      []Bed     `json:"beds"`   // Добавленные грядки (закрытые)
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,80 @@
+package model
+
+// PlantGrowth — на сколько выросло растение при выполнении задачи или привычки
+type PlantGrowth struct {
+	UserPlantID int     `json:"userPlantId"`
+	Growth      int     `json:"growth"`
+	Gain        float64 `json:"-"` // Точные (дробные) очки роста, из которых получен Growth
+}
+
+// CompletionEffects — побочные эффекты выполнения, записываемые вместе с progress_log,
+// чтобы отмена выполнения могла откатить именно их
+type CompletionEffects struct {
+	StreakIncremented     bool
+	LongestStreakBefore   int
+	StreakReward          *ItemGrant
+	DroughtDaysBefore     int
+	DroughtSeverityBefore int
+	DroughtDaysAfter      int
+	DroughtSeverityAfter  int
+	GrowthPoints          int // Базовые очки роста выполнения после ограничений
+	PlantGrowth           []PlantGrowth
+}
+
+// Completion — выполнение задачи или привычки, которое записывается в одной транзакции
+type Completion struct {
+	UserID          int64
+	TaskID          *int
+	HabitID         *int
+	Reward          CompletionReward // Награда до дневных ограничений
+	Limits          RewardLimits     // Дневные ограничения, применяемые к награде под блокировкой пользователя
+	GoldDescription string           // Описание начисления золота в журнале золота
+}
+
+// CompletionResult — изменения, внесенные выполнением
+type CompletionResult struct {
+	Reward          CompletionReward // Начисленная награда после дневных ограничений
+	RewardCap       *RewardCap       // Сведения об ограничении или nil, если награда не изменилась
+	Experience      *ExperienceChange
+	Drought         *DroughtState
+	StreakReward    *ItemGrant    // Награда за стрик, если выполнение ее принесло
+	PlantGrowth     []PlantGrowth // На сколько выросло каждое растение
+	CompletedQuests []UserQuest   // Ежедневные задания, выполненные этим выполнением
+}
+
+// CompletionUndo — результат отмены выполнения
+type CompletionUndo struct {
+	XPReturned           int  `json:"xpReturned"`
+	GoldReturned         int  `json:"goldReturned"`
+	PlantsReverted       int  `json:"plantsReverted"`       // У скольких растений откачен рост
+	StreakReverted       bool `json:"streakReverted"`       // Стрик уменьшен на 1
+	StreakRewardReturned int  `json:"streakRewardReturned"` // Сколько предметов награды за стрик списано
+	DroughtRestored      bool `json:"droughtRestored"`      // Засуха возвращена к состоянию до выполнения
+}
+
+// CompletionReward — опыт, золото и очки роста за одно выполнение
+type CompletionReward struct {
+	XP     int
+	Gold   int
+	Growth int
+}
+
+// RewardLimits — настраиваемые дневные ограничения наград за выполнение
+type RewardLimits struct {
+	DailyXPSoftCap          int
+	DailyGrowthSoftCap      int
+	DiminishingReturnsAfter int
+}
+
+// RewardCap — сведения о сработавших ограничениях наград за выполнение
+type RewardCap struct {
+	CompletionsToday   int     `json:"completionsToday"`   // Сколько выполнений было сегодня до текущего
+	Multiplier         float64 `json:"multiplier"`         // Множитель убывающей отдачи (1 — без снижения)
+	XPCapped           bool    `json:"xpCapped"`           // Сработал дневной лимит опыта
+	GrowthCapped       bool    `json:"growthCapped"`       // Сработал дневной лимит очков роста
+	XPBeforeCap        int     `json:"xpBeforeCap"`        // Опыт без ограничений
+	GoldBeforeCap      int     `json:"goldBeforeCap"`      // Золото без ограничений
+	GrowthBeforeCap    int     `json:"growthBeforeCap"`    // Очки роста без ограничений
+	DailyXPSoftCap     int     `json:"dailyXpSoftCap"`     // Дневной лимит опыта
+	DailyGrowthSoftCap int     `json:"dailyGrowthSoftCap"` // Дневной лимит очков роста
+}
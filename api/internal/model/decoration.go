@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// Decoration — декорация из каталога. BonusType/BonusValue — пассивный бонус соседним грядкам
+type Decoration struct {
+	ID          int       `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	Icon        *string   `json:"icon,omitempty"`
+	Cost        int       `json:"cost"`
+	BonusType   *string   `json:"bonusType,omitempty"`
+	BonusValue  float64   `json:"bonusValue"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// PlacedDecoration — декорация, поставленная пользователем на клетку поля (x, y)
+type PlacedDecoration struct {
+	ID           int        `json:"id"`
+	UserID       int64      `json:"userId"`
+	DecorationID int        `json:"decorationId"`
+	X            int        `json:"x"`
+	Y            int        `json:"y"`
+	Decoration   Decoration `json:"decoration"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+// DecorationChange — результат покупки, перемещения или удаления декорации
+type DecorationChange struct {
+	Decoration PlacedDecoration `json:"decoration"`
+	GoldChange int              `json:"goldChange"` // Списано (отрицательное) или возвращено золото
+	Balance    int64            `json:"balance"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// DroughtEvent — запись истории засухи пользователя
+type DroughtEvent struct {
+	ID             int        `json:"id"`
+	UserID         int64      `json:"userId"`
+	StartedAt      time.Time  `json:"startedAt"`
+	EndedAt        *time.Time `json:"endedAt,omitempty"`
+	Days           int        `json:"days"`
+	Severity       int        `json:"severity"`
+	MaxSeverity    int        `json:"maxSeverity"`
+	PlantsWithered int        `json:"plantsWithered"`
+	PlantsDied     int        `json:"plantsDied"`
+	EndReason      *string    `json:"endReason,omitempty"`
+	IsActive       bool       `json:"isActive"`
+}
+
+// DroughtState — текущее состояние засухи пользователя
+type DroughtState struct {
+	IsDrought        bool    `json:"isDrought"`
+	Days             int     `json:"days"`
+	Severity         int     `json:"severity"`
+	GrowthMultiplier float64 `json:"growthMultiplier"`
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// Friend — друг пользователя
+type Friend struct {
+	UserID   int64     `json:"userId"`
+	MaxID    int64     `json:"maxId"`
+	Username string    `json:"username"`
+	Level    int       `json:"level"`
+	Since    time.Time `json:"since"` // Когда заявка принята
+}
+
+// FriendRequest — заявка в друзья, ожидающая ответа
+type FriendRequest struct {
+	ID           int64     `json:"id"`
+	FromUserID   int64     `json:"fromUserId"`
+	FromMaxID    int64     `json:"fromMaxId"`
+	FromUsername string    `json:"fromUsername"`
+	ToUserID     int64     `json:"toUserId"`
+	ToMaxID      int64     `json:"toMaxId"`
+	ToUsername   string    `json:"toUsername"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// FriendRequests — входящие и исходящие заявки пользователя
+type FriendRequests struct {
+	Incoming []FriendRequest `json:"incoming"`
+	Outgoing []FriendRequest `json:"outgoing"`
+}
+
+// FriendFarmStats — статистика, которую друзья видят на ферме
+type FriendFarmStats struct {
+	CurrentStreak       int `json:"currentStreak"`
+	LongestStreak       int `json:"longestStreak"`
+	TotalPlantHarvested int `json:"totalPlantHarvested"`
+}
+
+// FriendFarm — ферма друга только для просмотра.
+// Beds заполняется только при настройке приватности full.
+type FriendFarm struct {
+	UserID      int64              `json:"userId"`
+	Username    string             `json:"username"`
+	FarmPrivacy string             `json:"farmPrivacy"`
+	Level       int                `json:"level"`
+	Stats       FriendFarmStats    `json:"stats"`
+	Beds        []BedWithUserPlant `json:"beds,omitempty"`
+}
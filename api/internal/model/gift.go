@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+// Gift — подарок семян или урожая другу
+type Gift struct {
+	ID                int64      `json:"id"`
+	SenderID          int64      `json:"senderId"`
+	SenderUsername    string     `json:"senderUsername"`
+	RecipientID       int64      `json:"recipientId"`
+	RecipientUsername string     `json:"recipientUsername"`
+	Kind              string     `json:"kind"` // seed, produce
+	SeedID            int        `json:"seedId"`
+	SeedName          string     `json:"seedName"`
+	Amount            int        `json:"amount"`
+	Status            string     `json:"status"` // pending, accepted, declined, cancelled
+	CreatedAt         time.Time  `json:"createdAt"`
+	ResolvedAt        *time.Time `json:"resolvedAt,omitempty"`
+}
+
+// Gifts — входящие и исходящие подарки пользователя, ожидающие ответа
+type Gifts struct {
+	Incoming []Gift `json:"incoming"`
+	Outgoing []Gift `json:"outgoing"`
+}
+
+// TradeLine — позиция обмена
+type TradeLine struct {
+	Kind     string `json:"kind"` // seed, produce
+	SeedID   int    `json:"seedId"`
+	SeedName string `json:"seedName,omitempty"`
+	Amount   int    `json:"amount"`
+}
+
+// TradeOffer — предложение обмена: Offer отдает автор предложения, Request — получатель
+type TradeOffer struct {
+	ID                int64       `json:"id"`
+	ProposerID        int64       `json:"proposerId"`
+	ProposerUsername  string      `json:"proposerUsername"`
+	RecipientID       int64       `json:"recipientId"`
+	RecipientUsername string      `json:"recipientUsername"`
+	Status            string      `json:"status"` // pending, accepted, declined, cancelled
+	Offer             []TradeLine `json:"offer"`
+	Request           []TradeLine `json:"request"`
+	CreatedAt         time.Time   `json:"createdAt"`
+	ResolvedAt        *time.Time  `json:"resolvedAt,omitempty"`
+}
+
+// TradeOffers — входящие и исходящие предложения обмена, ожидающие ответа
+type TradeOffers struct {
+	Incoming []TradeOffer `json:"incoming"`
+	Outgoing []TradeOffer `json:"outgoing"`
+}
@@ -12,3 +12,9 @@ type Good struct {
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
+
+// GoodPurchase — результат покупки товара из магазина
+type GoodPurchase struct {
+	Good        Good // Товар после покупки (с оставшимся количеством)
+	UnlockedBed *Bed // Разблокированная грядка, если куплена грядка
+}
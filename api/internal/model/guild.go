@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+// Guild — гильдия
+type Guild struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	OwnerID     *int64    `json:"ownerId"` // nil, если аккаунт владельца удален
+	MemberCount int       `json:"memberCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// GuildMember — участник гильдии и его вклад в цель текущей недели
+type GuildMember struct {
+	UserID      int64     `json:"userId"`
+	Username    string    `json:"username"`
+	Role        string    `json:"role"` // owner, member
+	Contributed int       `json:"contributed"`
+	JoinedAt    time.Time `json:"joinedAt"`
+}
+
+// GuildGoalTemplate — шаблон недельной цели гильдии
+type GuildGoalTemplate struct {
+	ID         int    `json:"id"`
+	Code       string `json:"code"`
+	Title      string `json:"title"`
+	SeedID     int    `json:"seedId"`
+	Target     int    `json:"target"`
+	RewardGold int    `json:"rewardGold"`
+	RewardXP   int    `json:"rewardXp"`
+}
+
+// GuildGoal — цель гильдии на неделю: сдать Target урожая семени SeedID
+type GuildGoal struct {
+	ID          int        `json:"id"`
+	TemplateID  int        `json:"templateId"`
+	WeekStart   time.Time  `json:"weekStart"`
+	Title       string     `json:"title"`
+	SeedID      int        `json:"seedId"`
+	SeedName    string     `json:"seedName"`
+	Target      int        `json:"target"`
+	Progress    int        `json:"progress"`
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	RewardGold  int        `json:"rewardGold"` // Награда каждому участнику, внесшему минимальный вклад
+	RewardXP    int        `json:"rewardXp"`
+}
+
+// GuildInfo — гильдия с участниками и целью текущей недели
+type GuildInfo struct {
+	Guild   Guild         `json:"guild"`
+	Members []GuildMember `json:"members"`
+	Goal    *GuildGoal    `json:"goal,omitempty"`
+}
+
+// GuildReward — награда участнику за выполнение цели гильдии
+type GuildReward struct {
+	UserID int64 `json:"userId"`
+	Gold   int   `json:"gold"`
+	XP     int   `json:"xp"`
+
+	Experience *ExperienceChange `json:"-"` // Изменение опыта вместе с уже выданными наградами за уровни
+}
+
+// GuildContribution — результат вклада в цель гильдии
+type GuildContribution struct {
+	Goal    GuildGoal     `json:"goal"`
+	Amount  int           `json:"amount"`            // Принято урожая (не больше, чем осталось до цели)
+	Rewards []GuildReward `json:"rewards,omitempty"` // Награды участникам, если вклад завершил цель
+	LevelUp *LevelUp      `json:"levelUp,omitempty"` // Повышение уровня текущего пользователя за награду
+}
@@ -8,7 +8,7 @@ type Habit struct {
 	Title       string    `json:"title"`
 	Description *string   `json:"description,omitempty"`
 	Difficulty  string    `json:"difficulty"`
-	TagID       *int      `json:"tagId,omitempty"`
+	TagIDs      []int     `json:"tagIds"`
 	Done        bool      `json:"done"`
 	Count       int       `json:"count"`
 	Period      string    `json:"period"`
@@ -16,5 +16,5 @@ type Habit struct {
 	StartDate   time.Time `json:"startDate"`
 	XPReward    int       `json:"xpReward"`
 	CreatedAt   time.Time `json:"createdAt"`
-	Tag         *Tag      `json:"tag,omitempty"`
+	Tags        []Tag     `json:"tags"`
 }
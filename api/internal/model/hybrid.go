@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// SeedRecipe — рецепт скрещивания: два зрелых растения на соседних грядках
+// с шансом Chance дают гибридное семя ResultSeedID
+type SeedRecipe struct {
+	ID            int       `json:"id"`
+	ParentASeedID int       `json:"parentASeedId"`
+	ParentBSeedID int       `json:"parentBSeedId"`
+	ResultSeedID  int       `json:"resultSeedId"`
+	Chance        float64   `json:"chance"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// HybridRecipeInfo — рецепт с точки зрения пользователя: результат виден только после открытия гибрида
+type HybridRecipeInfo struct {
+	ID              int     `json:"id"`
+	ParentASeedID   int     `json:"parentASeedId"`
+	ParentASeedName string  `json:"parentASeedName"`
+	ParentBSeedID   int     `json:"parentBSeedId"`
+	ParentBSeedName string  `json:"parentBSeedName"`
+	Chance          float64 `json:"chance"`
+	Discovered      bool    `json:"discovered"`
+	ResultSeed      *Seed   `json:"resultSeed,omitempty"`
+}
+
+// HybridDiscovery — гибрид, открытый пользователем
+type HybridDiscovery struct {
+	RecipeID      int       `json:"recipeId"`
+	ParentASeedID int       `json:"parentASeedId"`
+	ParentBSeedID int       `json:"parentBSeedId"`
+	Seed          Seed      `json:"seed"`
+	TimesBred     int       `json:"timesBred"`
+	DiscoveredAt  time.Time `json:"discoveredAt"`
+	LastBredAt    time.Time `json:"lastBredAt"`
+}
+
+// HybridBreed — гибридное семя, выведенное при сборе урожая
+type HybridBreed struct {
+	SeedDrop
+	RecipeID       int  `json:"recipeId"`
+	PartnerPlantID int  `json:"partnerPlantId"` // Растение на соседней грядке, с которым произошло скрещивание
+	IsNewDiscovery bool `json:"isNewDiscovery"` // Гибрид открыт впервые
+}
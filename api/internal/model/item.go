@@ -0,0 +1,77 @@
+package model
+
+import "time"
+
+// Item — определение предмета (расходники и т.п.)
+type Item struct {
+	ID          int     `json:"id"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	Icon        *string `json:"icon,omitempty"`
+	Type        string  `json:"type"` // 'consumable', 'fertilizer', 'tool'
+	// Эффект при применении к растению; DurationHours = nil — эффект действует до сбора,
+	// MaxStacks — сколько эффектов предмета одновременно может быть на растении (0 — без ограничений)
+	EffectType    *string   `json:"effectType,omitempty"`
+	EffectValue   float64   `json:"effectValue"`
+	DurationHours *int      `json:"durationHours,omitempty"`
+	MaxStacks     int       `json:"maxStacks"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// UserItemWithDetails — предмет в инвентаре пользователя
+type UserItemWithDetails struct {
+	ItemID      int       `json:"itemId"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	Icon        *string   `json:"icon,omitempty"`
+	Type        string    `json:"type"`
+	Quantity    int       `json:"quantity"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// ItemGrant — выданные пользователю предметы
+type ItemGrant struct {
+	ItemID   int    `json:"itemId"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Amount   int    `json:"amount"`
+	Quantity int    `json:"quantity"` // Количество в инвентаре после выдачи
+}
+
+// PlantRevival — запись журнала оживления засохшего растения
+type PlantRevival struct {
+	ID             int       `json:"id"`
+	UserID         int64     `json:"userId"`
+	UserPlantID    int       `json:"userPlantId"`
+	SeedID         int       `json:"seedId"`
+	Method         string    `json:"method"` // 'watering_can', 'bulk'
+	DroughtEventID *int      `json:"droughtEventId,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// PlantEffect — активный эффект предмета на растении
+type PlantEffect struct {
+	ID          int        `json:"id"`
+	UserID      int64      `json:"userId"`
+	UserPlantID int        `json:"userPlantId"`
+	ItemID      int        `json:"itemId"`
+	ItemCode    string     `json:"itemCode"`
+	ItemName    string     `json:"itemName"`
+	EffectType  string     `json:"effectType"`
+	Value       float64    `json:"value"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// ItemApplyResult — результат применения предмета к растению
+type ItemApplyResult struct {
+	ItemID     int           `json:"itemId"`
+	ItemCode   string        `json:"itemCode"`
+	EffectType string        `json:"effectType"`
+	Effect     *PlantEffect  `json:"effect,omitempty"`    // Для длительных эффектов
+	NewGrowth  *int          `json:"newGrowth,omitempty"` // Для мгновенного роста
+	Revival    *PlantRevival `json:"revival,omitempty"`   // Для оживления
+	Remaining  int           `json:"remaining"`           // Сколько предметов осталось
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// LeaderboardEntry — место пользователя в рейтинге
+type LeaderboardEntry struct {
+	Rank     int    `json:"rank"`
+	UserID   int64  `json:"userId"`
+	Username string `json:"username"`
+	Value    int64  `json:"value"`
+}
+
+// Leaderboard — рейтинг по метрике за недельный сезон, построенный по последнему снимку
+type Leaderboard struct {
+	Metric      string             `json:"metric"` // weekly_xp, longest_streak, total_harvests
+	Scope       string             `json:"scope"`  // global, friends
+	SeasonStart time.Time          `json:"seasonStart"`
+	SeasonEnd   time.Time          `json:"seasonEnd"`
+	ComputedAt  *time.Time         `json:"computedAt,omitempty"` // Время снимка; nil, если сезон еще не посчитан
+	Entries     []LeaderboardEntry `json:"entries"`
+	Me          *LeaderboardEntry  `json:"me,omitempty"` // Место текущего пользователя, если он есть в рейтинге
+}
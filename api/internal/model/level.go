@@ -0,0 +1,28 @@
+package model
+
+// LevelUp описывает повышение уровня после начисления опыта
+type LevelUp struct {
+	OldLevel      int           `json:"oldLevel"`
+	NewLevel      int           `json:"newLevel"`
+	UnlockedSeeds []Seed        `json:"unlockedSeeds"`
+	Rewards       []LevelReward `json:"rewards"`
+}
+
+// ExperienceChange — изменение опыта вместе с наградами за достигнутые уровни, выданными в той же транзакции
+type ExperienceChange struct {
+	Before  int64
+	After   int64
+	Rewards []LevelReward
+}
+
+// LevelReward описывает награду, выдаваемую при достижении уровня
+type LevelReward struct {
+	ID         int     `json:"id"`
+	Level      int     `json:"level"`
+	RewardType string  `json:"rewardType"` // gold, seed, bed, item
+	SeedID     *int    `json:"seedId,omitempty"`
+	SeedName   *string `json:"seedName,omitempty"`
+	ItemID     *int    `json:"itemId,omitempty"`
+	ItemName   *string `json:"itemName,omitempty"`
+	Amount     int     `json:"amount"`
+}
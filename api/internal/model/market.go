@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Produce — урожай в амбаре пользователя
+type Produce struct {
+	SeedID    int       `json:"seedId"`
+	SeedName  string    `json:"seedName"`
+	SeedIcon  string    `json:"seedIcon,omitempty"`
+	Quantity  int       `json:"quantity"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// MarketPrice — цена единицы урожая на рынке в указанный день
+type MarketPrice struct {
+	SeedID         int     `json:"seedId"`
+	SeedName       string  `json:"seedName"`
+	SeedIcon       string  `json:"seedIcon,omitempty"`
+	BasePrice      float64 `json:"basePrice"`
+	Price          float64 `json:"price"`
+	YesterdayPrice float64 `json:"yesterdayPrice"`
+	ChangePercent  float64 `json:"changePercent"` // Изменение цены относительно вчера, %
+	Owned          int     `json:"owned"`         // Сколько единиц урожая есть в амбаре
+}
+
+// MarketSale — результат продажи урожая
+type MarketSale struct {
+	SeedID     int     `json:"seedId"`
+	Quantity   int     `json:"quantity"`
+	UnitPrice  float64 `json:"unitPrice"`
+	GoldEarned int     `json:"goldEarned"`
+	Remaining  int     `json:"remaining"` // Сколько единиц урожая осталось в амбаре
+	Balance    int64   `json:"balance"`   // Баланс золота после продажи
+}
+
+// GoldLedgerEntry — запись журнала изменений золота
+type GoldLedgerEntry struct {
+	ID           int       `json:"id"`
+	UserID       int64     `json:"userId"`
+	Amount       int       `json:"amount"`
+	BalanceAfter int64     `json:"balanceAfter"`
+	Source       string    `json:"source"`
+	Description  *string   `json:"description,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
@@ -3,18 +3,20 @@ package model
 import "time"
 
 type ProgressLog struct {
-	ID         int       `json:"id"`
-	UserID     int64     `json:"userId"`
-	TaskID     *int      `json:"taskId,omitempty"`
-	HabitID    *int      `json:"habitId,omitempty"`
-	XPEarned   int       `json:"xpEarned"`
-	GoldEarned int       `json:"goldEarned"`
-	CreatedAt  time.Time `json:"createdAt"`
+	ID          int       `json:"id"`
+	UserID      int64     `json:"userId"`
+	TaskID      *int      `json:"taskId,omitempty"`
+	HabitID     *int      `json:"habitId,omitempty"`
+	XPEarned    int       `json:"xpEarned"`
+	GoldEarned  int       `json:"goldEarned"`
+	Source      string    `json:"source"` // task, habit, level_reward
+	Description *string   `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
 }
 
 type ProgressLogWithDetails struct {
 	ProgressLog
 	TaskTitle  *string `json:"taskTitle,omitempty"`
 	HabitTitle *string `json:"habitTitle,omitempty"`
-	Type       string  `json:"type"` // "task" или "habit"
+	Type       string  `json:"type"` // "task", "habit" или источник записи без задачи/привычки
 }
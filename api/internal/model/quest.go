@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// QuestTemplate — шаблон ежедневного задания
+type QuestTemplate struct {
+	ID               int     `json:"id"`
+	Code             string  `json:"code"`
+	Title            string  `json:"title"`
+	Kind             string  `json:"kind"` // complete_tasks, complete_habits, complete_tagged_habits, harvest_plants
+	Target           int     `json:"target"`
+	Difficulty       *string `json:"difficulty,omitempty"`
+	SeedID           *int    `json:"seedId,omitempty"`
+	TagName          *string `json:"tagName,omitempty"`
+	MinLevel         int     `json:"minLevel"`
+	Weight           int     `json:"weight"`
+	RewardGold       int     `json:"rewardGold"`
+	RewardXP         int     `json:"rewardXp"`
+	RewardItemID     *int    `json:"rewardItemId,omitempty"`
+	RewardItemAmount int     `json:"rewardItemAmount"`
+}
+
+// UserQuest — ежедневное задание пользователя с прогрессом
+type UserQuest struct {
+	ID               int        `json:"id"`
+	TemplateID       int        `json:"templateId"`
+	QuestDate        time.Time  `json:"questDate"`
+	Title            string     `json:"title"`
+	Kind             string     `json:"kind"`
+	Difficulty       *string    `json:"difficulty,omitempty"`
+	SeedID           *int       `json:"seedId,omitempty"`
+	TagName          *string    `json:"tagName,omitempty"`
+	Target           int        `json:"target"`
+	Progress         int        `json:"progress"`
+	Completed        bool       `json:"completed"`
+	Claimed          bool       `json:"claimed"`
+	CompletedAt      *time.Time `json:"completedAt,omitempty"`
+	ClaimedAt        *time.Time `json:"claimedAt,omitempty"`
+	RewardGold       int        `json:"rewardGold"`
+	RewardXP         int        `json:"rewardXp"`
+	RewardItemID     *int       `json:"rewardItemId,omitempty"`
+	RewardItemAmount int        `json:"rewardItemAmount"`
+}
+
+// QuestEvent — событие, продвигающее ежедневные задания: выполнение задачи или привычки либо сбор растения.
+// Заполняется ровно одно из полей.
+type QuestEvent struct {
+	TaskID  *int
+	HabitID *int
+	SeedID  *int
+}
+
+// QuestClaim — результат получения награды за задание
+type QuestClaim struct {
+	Quest   UserQuest  `json:"quest"`
+	Gold    int        `json:"gold"`
+	XP      int        `json:"xp"`
+	Item    *ItemGrant `json:"item,omitempty"`
+	LevelUp *LevelUp   `json:"levelUp,omitempty"`
+
+	Experience *ExperienceChange `json:"-"` // Изменение опыта вместе с уже выданными наградами за уровни
+}
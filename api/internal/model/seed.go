@@ -13,7 +13,12 @@ type Seed struct {
 	Modification  float64   `json:"modification"`
 	GoldReward    int       `json:"goldReward"`
 	XPReward      int       `json:"xpReward"`
+	IsHybrid      bool      `json:"isHybrid"` // Гибрид: можно получить только скрещиванием
 	CreatedAt     time.Time `json:"createdAt"`
+
+	// StageThresholds — проценты роста, с которых начинаются стадии роста (первая — 0).
+	// Картинка стадии N — ImgPlant/stateN.png
+	StageThresholds []int `json:"stageThresholds"`
 }
 
 type SeedWithUserData struct {
@@ -29,3 +34,13 @@ type AvailableSeed struct {
 	Rarity     string `json:"rarity"`
 	UserAmount int64  `json:"userAmount"`
 }
+
+// SeedDrop — семена, выпавшие при сборе урожая
+type SeedDrop struct {
+	SeedID    int    `json:"seedId"`
+	SeedName  string `json:"seedName"`
+	SeedIcon  string `json:"seedIcon,omitempty"`
+	Rarity    string `json:"rarity"`
+	Quantity  int    `json:"quantity"`
+	IsUpgrade bool   `json:"isUpgrade"` // Семя более высокой редкости, чем собранное растение
+}
@@ -12,5 +12,7 @@ type Tag struct {
 
 type TagWithCount struct {
 	Tag
-	TaskCount int `json:"task_count"`
+	TaskCount  int `json:"task_count"`
+	HabitCount int `json:"habit_count"`
+	TotalCount int `json:"total_count"`
 }
@@ -8,12 +8,12 @@ type Task struct {
 	Title       string     `json:"title"`
 	Description *string    `json:"description,omitempty"`
 	Difficulty  string     `json:"difficulty"`
-	TagID       *int       `json:"tagId,omitempty"`
+	TagIDs      []int      `json:"tagIds"`
 	Done        bool       `json:"done"`
 	Date        *time.Time `json:"date,omitempty"`
 	XPReward    int        `json:"xpReward"`
 	CreatedAt   time.Time  `json:"createdAt"`
-	Tag         *Tag       `json:"tag,omitempty"`
+	Tags        []Tag      `json:"tags"`
 }
 
 // Методы для Task
@@ -11,4 +11,7 @@ type User struct {
 	CreatedAt time.Time  `json:"createdAt"`
 	LastLogin *time.Time `json:"lastLogin,omitempty"`
 	IsActive  bool       `json:"isActive"`
+	Role      string     `json:"role"`
+	// FarmPrivacy — что друзья видят на ферме: full, level_only, hidden
+	FarmPrivacy string `json:"farmPrivacy"`
 }
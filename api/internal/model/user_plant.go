@@ -3,13 +3,14 @@ package model
 import "time"
 
 type UserPlant struct {
-	ID            int       `json:"id"`
-	UserID        int64     `json:"userId"`
-	SeedID        int       `json:"seedId"`
-	BedID         int       `json:"bedId"`
-	CurrentGrowth int       `json:"currentGrowth"`
-	IsWithered    bool      `json:"isWithered"`
-	CreatedAt     time.Time `json:"createdAt"`
+	ID            int        `json:"id"`
+	UserID        int64      `json:"userId"`
+	SeedID        int        `json:"seedId"`
+	BedID         int        `json:"bedId"`
+	CurrentGrowth int        `json:"currentGrowth"`
+	IsWithered    bool       `json:"isWithered"`
+	WitheredAt    *time.Time `json:"witheredAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
 }
 
 type UserPlantWithSeed struct {
@@ -22,15 +23,62 @@ type UserPlantWithSeed struct {
 	GoldReward    int    `json:"goldReward"`
 	GrowthPercent int    `json:"growthPercent"`
 	IsWithered    bool   `json:"isWithered"`
-}
-
-type UserPlantGrowthRequest struct {
-	GrowthAmount int `json:"growthAmount"`
+	// Modification — модификатор семени, GrowthBonus — бонус действующих эффектов предметов и соседних декораций,
+	// GrowthMultiplier = 1 + Modification + GrowthBonus.
+	// Очки роста за каждое выполнение задачи/привычки умножаются на GrowthMultiplier.
+	Modification     float64 `json:"modification"`
+	GrowthBonus      float64 `json:"growthBonus"`
+	GrowthMultiplier float64 `json:"growthMultiplier"`
+	// Stage — текущая стадия роста (с 1) из StageCount стадий семени, StageImgPath — картинка стадии
+	Stage        int    `json:"stage"`
+	StageCount   int    `json:"stageCount"`
+	StageImgPath string `json:"stageImgPath,omitempty"`
+	// HarvestYieldBonus — бонус к урожаю от инструментов и соседних декораций, награды за сбор умножаются на 1 + HarvestYieldBonus
+	HarvestYieldBonus float64 `json:"harvestYieldBonus"`
 }
 
 type UserPlantHarvestResult struct {
 	UserPlantWithSeed
-	GoldEarned int  `json:"goldEarned"`
-	XPEarned   int  `json:"xpEarned"`
-	IsReady    bool `json:"isReady"`
+	ProduceEarned   int                 `json:"produceEarned"` // Сколько единиц урожая добавлено в амбар
+	ProduceTotal    int                 `json:"produceTotal"`  // Сколько единиц урожая этого семени теперь в амбаре
+	XPEarned        int                 `json:"xpEarned"`
+	IsReady         bool                `json:"isReady"`
+	LevelUp         *LevelUp            `json:"levelUp,omitempty"`
+	SeedDrops       []SeedDrop          `json:"seedDrops"`                 // Семена, выпавшие при сборе
+	Hybrids         []HybridBreed       `json:"hybrids"`                   // Гибриды, выведенные скрещиванием с соседними растениями
+	Achievements    []AchievementUnlock `json:"achievements,omitempty"`    // Достижения, открытые этим сбором
+	CompletedQuests []UserQuest         `json:"completedQuests,omitempty"` // Ежедневные задания, выполненные этим сбором
+}
+
+// Harvest — награды за сбор растения, которые записываются в одной транзакции с удалением растения с грядки
+type Harvest struct {
+	UserID    int64
+	PlantID   int
+	SeedID    int
+	Produce   int           // Сколько единиц урожая добавить в амбар
+	XP        int           // Сколько опыта начислить
+	SeedDrops []SeedDrop    // Выпавшие семена
+	Hybrids   []HybridBreed // Выведенные гибриды; IsNewDiscovery заполняется при записи
+}
+
+// HarvestRecord — результат записи сбора
+type HarvestRecord struct {
+	ProduceTotal    int               // Сколько единиц урожая этого семени теперь в амбаре
+	Experience      *ExperienceChange // Начисленный опыт и выданные награды за уровни
+	CompletedQuests []UserQuest       // Ежедневные задания, выполненные этим сбором
+}
+
+// PlantHistory — запись истории растения, убранного с грядки (собрано, погибло или удалено)
+type PlantHistory struct {
+	ID          int        `json:"id"`
+	UserID      int64      `json:"userId"`
+	SeedID      int        `json:"seedId"`
+	SeedName    string     `json:"seedName"`
+	BedID       *int       `json:"bedId,omitempty"`
+	Event       string     `json:"event"`
+	Reason      *string    `json:"reason,omitempty"`
+	FinalGrowth int        `json:"finalGrowth"`
+	PlantedAt   time.Time  `json:"plantedAt"`
+	WitheredAt  *time.Time `json:"witheredAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
 }
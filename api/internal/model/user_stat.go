@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+)
 
 type UserStat struct {
 	ID                  int64     `json:"id"`
@@ -12,30 +16,17 @@ type UserStat struct {
 	TotalTasksCompleted int       `json:"totalTasksCompleted"`
 	TotalPlantHarvested int       `json:"totalPlantHarvested"`
 	IsDrought           bool      `json:"isDrought"`
+	DroughtDays         int       `json:"droughtDays"`
+	DroughtSeverity     int       `json:"droughtSeverity"`
 	UpdatedAt           time.Time `json:"updatedAt"`
 }
 
-// Level вычисляет уровень на основе опыта
+// Level вычисляет уровень на основе опыта по единой кривой уровней
 func (s *UserStat) Level() int {
-	if s.Experience < 0 {
-		return 1
-	}
-	level := int(s.Experience/100) + 1
-	if level < 1 {
-		return 1
-	}
-	if level > 100 {
-		return 100
-	}
-	return level
+	return utils.CalculateLevel(s.Experience)
 }
 
 // ExperienceForNextLevel возвращает опыт до следующего уровня
 func (s *UserStat) ExperienceForNextLevel() int64 {
-	currentLevel := s.Level()
-	nextLevelExp := int64(currentLevel * 100)
-	if s.Experience >= nextLevelExp {
-		return 0
-	}
-	return nextLevelExp - s.Experience
+	return utils.ExperienceForNextLevel(s.Level(), s.Experience)
 }
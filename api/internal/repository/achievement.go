@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AchievementRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAchievementRepo(db *pgxpool.Pool) *AchievementRepo {
+	return &AchievementRepo{db: db}
+}
+
+// achievementCounterValue — значение счетчика пользователя (us — user_stat), по которому открывается достижение
+const achievementCounterValue = `
+	CASE a.counter
+		WHEN 'tasks_completed' THEN COALESCE(us.total_tasks_completed, 0)
+		WHEN 'plants_harvested' THEN COALESCE(us.total_plant_harvested, 0)
+		WHEN 'longest_streak' THEN COALESCE(us.longest_streak, 0)
+		ELSE 0
+	END
+`
+
+// GetForUser возвращает все достижения с прогрессом и отметкой об открытии для пользователя
+func (r *AchievementRepo) GetForUser(ctx context.Context, userID int64) ([]model.UserAchievement, error) {
+	query := `
+		SELECT a.id, a.code, a.name, a.description, a.icon, a.kind, a.counter, a.event, a.threshold,
+		       a.reward_type, a.reward_seed_id, a.reward_item_id, a.reward_amount,
+		       CASE
+		           WHEN ua.user_id IS NOT NULL THEN a.threshold
+		           WHEN a.kind = 'counter' THEN LEAST(` + achievementCounterValue + `, a.threshold)
+		           ELSE 0
+		       END AS progress,
+		       ua.unlocked_at
+		FROM achievement a
+		LEFT JOIN user_stat us ON us.user_id = $1
+		LEFT JOIN user_achievement ua ON ua.achievement_id = a.id AND ua.user_id = $1
+		ORDER BY a.sort_order, a.id
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	achievements := []model.UserAchievement{}
+	for rows.Next() {
+		var achievement model.UserAchievement
+		if err := rows.Scan(
+			&achievement.ID, &achievement.Code, &achievement.Name, &achievement.Description, &achievement.Icon,
+			&achievement.Kind, &achievement.Counter, &achievement.Event, &achievement.Threshold,
+			&achievement.RewardType, &achievement.RewardSeedID, &achievement.RewardItemID, &achievement.RewardAmount,
+			&achievement.Progress, &achievement.UnlockedAt,
+		); err != nil {
+			return nil, err
+		}
+		achievement.Unlocked = achievement.UnlockedAt != nil
+		achievements = append(achievements, achievement)
+	}
+	return achievements, rows.Err()
+}
+
+// UnlockEligible открывает все еще не открытые достижения, условия которых выполнены:
+// счетчик пользователя достиг порога или произошло одно из событий events.
+// Награда выдается в той же транзакции, что и отметка об открытии, поэтому выплачивается ровно один раз.
+func (r *AchievementRepo) UnlockEligible(ctx context.Context, userID int64, events []string) ([]model.AchievementUnlock, error) {
+	if events == nil {
+		events = []string{}
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT a.id, a.code, a.name, a.kind, a.counter, a.event, a.threshold,
+		       a.reward_type, a.reward_seed_id, a.reward_item_id, a.reward_amount
+		FROM achievement a
+		LEFT JOIN user_stat us ON us.user_id = $1
+		WHERE NOT EXISTS (
+		          SELECT 1 FROM user_achievement ua
+		          WHERE ua.user_id = $1 AND ua.achievement_id = a.id
+		      )
+		  AND (
+		          (a.kind = 'counter' AND ` + achievementCounterValue + ` >= a.threshold)
+		          OR (a.kind = 'event' AND a.event = ANY($2))
+		      )
+		ORDER BY a.sort_order, a.id
+	`
+	rows, err := tx.Query(ctx, query, userID, events)
+	if err != nil {
+		return nil, err
+	}
+
+	eligible := []model.Achievement{}
+	for rows.Next() {
+		var achievement model.Achievement
+		if err := rows.Scan(
+			&achievement.ID, &achievement.Code, &achievement.Name, &achievement.Kind,
+			&achievement.Counter, &achievement.Event, &achievement.Threshold,
+			&achievement.RewardType, &achievement.RewardSeedID, &achievement.RewardItemID, &achievement.RewardAmount,
+		); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		eligible = append(eligible, achievement)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	unlocked := []model.AchievementUnlock{}
+	for _, achievement := range eligible {
+		// Параллельный запрос мог уже открыть достижение — тогда награду не выдаем
+		claim, err := tx.Exec(ctx, `
+			INSERT INTO user_achievement (user_id, achievement_id, unlocked_at)
+			VALUES ($1, $2, NOW())
+			ON CONFLICT (user_id, achievement_id) DO NOTHING
+		`, userID, achievement.ID)
+		if err != nil {
+			return nil, err
+		}
+		if claim.RowsAffected() == 0 {
+			continue
+		}
+
+		gold, err := applyAchievementReward(ctx, tx, userID, achievement)
+		if err != nil {
+			return nil, fmt.Errorf("failed to grant achievement %s reward: %w", achievement.Code, err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO progress_log (user_id, xp_earned, gold_earned, source, description, created_at)
+			VALUES ($1, 0, $2, $3, $4, $5)
+		`, userID, gold, constants.ProgressSourceAchievement,
+			fmt.Sprintf("Достижение «%s»", achievement.Name), time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		unlocked = append(unlocked, model.AchievementUnlock{
+			AchievementID: achievement.ID,
+			Code:          achievement.Code,
+			Name:          achievement.Name,
+			RewardType:    achievement.RewardType,
+			RewardAmount:  achievement.RewardAmount,
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return unlocked, nil
+}
+
+// applyAchievementReward выдает награду за достижение. Возвращает начисленное золото для лога прогресса.
+func applyAchievementReward(ctx context.Context, tx pgx.Tx, userID int64, achievement model.Achievement) (int, error) {
+	if achievement.RewardType == nil || achievement.RewardAmount == 0 {
+		return 0, nil
+	}
+
+	switch *achievement.RewardType {
+	case constants.AchievementRewardGold:
+		_, err := changeGold(ctx, tx, userID, int64(achievement.RewardAmount), goldChangeAllowNegative,
+			constants.GoldSourceAchievement, fmt.Sprintf("Достижение «%s»", achievement.Name))
+		return achievement.RewardAmount, err
+
+	case constants.AchievementRewardSeed:
+		if achievement.RewardSeedID == nil {
+			return 0, nil
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO user_seed (user_id, seed_id, quantity, created_at)
+			VALUES ($1, $2, $3, NOW())
+			ON CONFLICT (user_id, seed_id)
+			DO UPDATE SET quantity = user_seed.quantity + EXCLUDED.quantity
+		`, userID, *achievement.RewardSeedID, achievement.RewardAmount)
+		return 0, err
+
+	case constants.AchievementRewardItem:
+		if achievement.RewardItemID == nil {
+			return 0, nil
+		}
+		_, err := addUserItem(ctx, tx, userID, *achievement.RewardItemID, achievement.RewardAmount)
+		return 0, err
+
+	default:
+		return 0, fmt.Errorf("unknown achievement reward type: %s", *achievement.RewardType)
+	}
+}
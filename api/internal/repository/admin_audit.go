@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdminAuditRepo выполняет ручные изменения прогресса пользователей
+// и записывает каждое из них в admin_audit_log в той же транзакции
+type AdminAuditRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAdminAuditRepo(db *pgxpool.Pool) *AdminAuditRepo {
+	return &AdminAuditRepo{db: db}
+}
+
+// GetEntries возвращает журнал действий администраторов, начиная с последних.
+// Если targetUserID = 0, возвращаются записи по всем пользователям.
+func (r *AdminAuditRepo) GetEntries(ctx context.Context, targetUserID int64, limit int) ([]model.AdminAuditEntry, error) {
+	query := `
+		SELECT id, admin_id, target_user_id, action, value_before, value_after, reason, created_at
+		FROM admin_audit_log
+		WHERE $1 = 0 OR target_user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+	rows, err := r.db.Query(ctx, query, targetUserID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []model.AdminAuditEntry{}
+	for rows.Next() {
+		var entry model.AdminAuditEntry
+		if err := rows.Scan(
+			&entry.ID, &entry.AdminID, &entry.TargetUserID, &entry.Action,
+			&entry.ValueBefore, &entry.ValueAfter, &entry.Reason, &entry.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// AdjustExperience изменяет опыт пользователя на amount (опыт не опускается ниже нуля).
+// Награды за достигнутые уровни выдаются в той же транзакции и возвращаются вместе с записью журнала.
+func (r *AdminAuditRepo) AdjustExperience(ctx context.Context, adminID, userID, amount int64, reason string) (*model.AdminAuditEntry, []model.LevelReward, error) {
+	var rewards []model.LevelReward
+	entry, err := r.override(ctx, adminID, userID, constants.AdminActionExperience, reason,
+		func(tx pgx.Tx) (int64, int64, error) {
+			before, err := lockUserStatValue(ctx, tx, userID, "experience")
+			if err != nil {
+				return 0, 0, err
+			}
+			after := max(before+amount, 0)
+			_, err = tx.Exec(ctx, `UPDATE user_stat SET experience = $1, updated_at = NOW() WHERE user_id = $2`, after, userID)
+			if err != nil {
+				return 0, 0, err
+			}
+			rewards, err = grantLevelUp(ctx, tx, userID, before, after)
+			return before, after, err
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+	return entry, rewards, nil
+}
+
+// AdjustGold изменяет золото пользователя на amount (баланс не опускается ниже нуля).
+// Изменение также попадает в gold_ledger с источником admin_override.
+func (r *AdminAuditRepo) AdjustGold(ctx context.Context, adminID, userID, amount int64, reason string) (*model.AdminAuditEntry, error) {
+	return r.override(ctx, adminID, userID, constants.AdminActionGold, reason,
+		func(tx pgx.Tx) (int64, int64, error) {
+			before, err := lockUserStatValue(ctx, tx, userID, "gold")
+			if err != nil {
+				return 0, 0, err
+			}
+			after, err := changeGold(ctx, tx, userID, amount, goldChangeClampToZero, constants.GoldSourceAdmin, reason)
+			return before, after, err
+		})
+}
+
+// SetStreak устанавливает текущий стрик пользователя; рекорд стрика только растет
+func (r *AdminAuditRepo) SetStreak(ctx context.Context, adminID, userID int64, streak int, reason string) (*model.AdminAuditEntry, error) {
+	return r.override(ctx, adminID, userID, constants.AdminActionStreak, reason,
+		func(tx pgx.Tx) (int64, int64, error) {
+			before, err := lockUserStatValue(ctx, tx, userID, "current_streak")
+			if err != nil {
+				return 0, 0, err
+			}
+			_, err = tx.Exec(ctx, `
+				UPDATE user_stat
+				SET current_streak = $1, longest_streak = GREATEST(longest_streak, $1), updated_at = NOW()
+				WHERE user_id = $2
+			`, streak, userID)
+			return before, int64(streak), err
+		})
+}
+
+// override выполняет изменение apply и запись в журнал в одной транзакции
+func (r *AdminAuditRepo) override(
+	ctx context.Context,
+	adminID, userID int64,
+	action, reason string,
+	apply func(tx pgx.Tx) (before, after int64, err error),
+) (*model.AdminAuditEntry, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	before, after, err := apply(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := model.AdminAuditEntry{
+		AdminID:      &adminID,
+		TargetUserID: userID,
+		Action:       action,
+		ValueBefore:  before,
+		ValueAfter:   after,
+		Reason:       reason,
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO admin_audit_log (admin_id, target_user_id, action, value_before, value_after, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, adminID, userID, action, before, after, reason).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// lockUserStatValue блокирует строку user_stat и возвращает значение колонки column
+func lockUserStatValue(ctx context.Context, q DBTX, userID int64, column string) (int64, error) {
+	var value int64
+	query := fmt.Sprintf(`SELECT %s FROM user_stat WHERE user_id = $1 FOR UPDATE`, column)
+	err := q.QueryRow(ctx, query, userID).Scan(&value)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("user stat not found for user_id=%d", userID)
+		}
+		return 0, err
+	}
+	return value, nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/RinatHar/FarmFocus/api/internal/utils"
 	"github.com/jackc/pgx/v5"
@@ -21,24 +23,24 @@ func NewBedRepo(db *pgxpool.Pool) *BedRepo {
 
 func (r *BedRepo) Create(ctx context.Context, bed *model.Bed) error {
 	query := `
-		INSERT INTO bed (user_id, cell_number, is_locked, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO bed (user_id, cell_number, x, y, is_locked, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id
 	`
 	return r.db.QueryRow(ctx, query,
-		bed.UserID, bed.CellNumber, bed.IsLocked, bed.CreatedAt,
+		bed.UserID, bed.CellNumber, bed.X, bed.Y, bed.IsLocked, bed.CreatedAt,
 	).Scan(&bed.ID)
 }
 
 func (r *BedRepo) GetByID(ctx context.Context, id int) (*model.Bed, error) {
 	var bed model.Bed
 	query := `
-		SELECT id, user_id, cell_number, is_locked, created_at
+		SELECT id, user_id, cell_number, x, y, is_locked, created_at
 		FROM bed
 		WHERE id = $1
 	`
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&bed.ID, &bed.UserID, &bed.CellNumber, &bed.IsLocked, &bed.CreatedAt,
+		&bed.ID, &bed.UserID, &bed.CellNumber, &bed.X, &bed.Y, &bed.IsLocked, &bed.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -51,10 +53,10 @@ func (r *BedRepo) GetByID(ctx context.Context, id int) (*model.Bed, error) {
 
 func (r *BedRepo) GetByUser(ctx context.Context, userID int64) ([]model.Bed, error) {
 	query := `
-		SELECT id, user_id, cell_number, is_locked, created_at
+		SELECT id, user_id, cell_number, x, y, is_locked, created_at
 		FROM bed
 		WHERE user_id = $1
-		ORDER BY cell_number
+		ORDER BY y, x
 	`
 	rows, err := r.db.Query(ctx, query, userID)
 	if err != nil {
@@ -66,7 +68,7 @@ func (r *BedRepo) GetByUser(ctx context.Context, userID int64) ([]model.Bed, err
 	for rows.Next() {
 		var bed model.Bed
 		if err := rows.Scan(
-			&bed.ID, &bed.UserID, &bed.CellNumber, &bed.IsLocked, &bed.CreatedAt,
+			&bed.ID, &bed.UserID, &bed.CellNumber, &bed.X, &bed.Y, &bed.IsLocked, &bed.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -78,12 +80,12 @@ func (r *BedRepo) GetByUser(ctx context.Context, userID int64) ([]model.Bed, err
 func (r *BedRepo) GetByCellNumber(ctx context.Context, userID int64, cellNumber int) (*model.Bed, error) {
 	var bed model.Bed
 	query := `
-		SELECT id, user_id, cell_number, is_locked, created_at
+		SELECT id, user_id, cell_number, x, y, is_locked, created_at
 		FROM bed
 		WHERE user_id = $1 AND cell_number = $2
 	`
 	err := r.db.QueryRow(ctx, query, userID, cellNumber).Scan(
-		&bed.ID, &bed.UserID, &bed.CellNumber, &bed.IsLocked, &bed.CreatedAt,
+		&bed.ID, &bed.UserID, &bed.CellNumber, &bed.X, &bed.Y, &bed.IsLocked, &bed.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -136,10 +138,10 @@ func (r *BedRepo) Delete(ctx context.Context, id int) error {
 
 func (r *BedRepo) GetAvailableBeds(ctx context.Context, userID int64) ([]model.Bed, error) {
 	query := `
-		SELECT id, user_id, cell_number, is_locked, created_at
+		SELECT id, user_id, cell_number, x, y, is_locked, created_at
 		FROM bed
 		WHERE user_id = $1 AND is_locked = false
-		ORDER BY cell_number
+		ORDER BY y, x
 	`
 	rows, err := r.db.Query(ctx, query, userID)
 	if err != nil {
@@ -151,7 +153,7 @@ func (r *BedRepo) GetAvailableBeds(ctx context.Context, userID int64) ([]model.B
 	for rows.Next() {
 		var bed model.Bed
 		if err := rows.Scan(
-			&bed.ID, &bed.UserID, &bed.CellNumber, &bed.IsLocked, &bed.CreatedAt,
+			&bed.ID, &bed.UserID, &bed.CellNumber, &bed.X, &bed.Y, &bed.IsLocked, &bed.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -162,14 +164,15 @@ func (r *BedRepo) GetAvailableBeds(ctx context.Context, userID int64) ([]model.B
 
 func (r *BedRepo) GetWithPlants(ctx context.Context, userID int64) ([]model.BedWithUserPlant, error) {
 	query := `
-		SELECT b.id, b.user_id, b.cell_number, b.is_locked, b.created_at,
-		       up.id as plant_id, up.seed_id, up.current_growth, up.created_at as plant_created_at,
-		       s.name as seed_name, s.icon as seed_icon, s.target_growth, s.gold_reward, s.xp_reward
+		SELECT b.id, b.user_id, b.cell_number, b.x, b.y, b.is_locked, b.created_at,
+		       up.id as plant_id, up.seed_id, up.current_growth, up.is_withered, up.withered_at, up.created_at as plant_created_at,
+		       s.name as seed_name, s.icon as seed_icon, s.img_plant as seed_img_plant, s.target_growth, s.gold_reward, s.xp_reward, s.modification,
+		       s.stage_thresholds
 		FROM bed b
 		LEFT JOIN user_plant up ON b.id = up.bed_id
 		LEFT JOIN seed s ON up.seed_id = s.id
 		WHERE b.user_id = $1
-		ORDER BY b.cell_number
+		ORDER BY b.y, b.x
 	`
 	rows, err := r.db.Query(ctx, query, userID)
 	if err != nil {
@@ -181,14 +184,18 @@ func (r *BedRepo) GetWithPlants(ctx context.Context, userID int64) ([]model.BedW
 	for rows.Next() {
 		var bed model.BedWithUserPlant
 		var plantID, seedID, currentGrowth *int
-		var plantCreatedAt *string
-		var seedName, seedIcon *string
+		var isWithered *bool
+		var witheredAt, plantCreatedAt *time.Time
+		var seedName, seedIcon, seedImgPlant *string
+		var stageThresholds []int
 		var targetGrowth, goldReward, xpReward *int
+		var modification *float64
 
 		err := rows.Scan(
-			&bed.ID, &bed.UserID, &bed.CellNumber, &bed.IsLocked, &bed.CreatedAt,
-			&plantID, &seedID, &currentGrowth, &plantCreatedAt,
-			&seedName, &seedIcon, &targetGrowth, &goldReward, &xpReward,
+			&bed.ID, &bed.UserID, &bed.CellNumber, &bed.X, &bed.Y, &bed.IsLocked, &bed.CreatedAt,
+			&plantID, &seedID, &currentGrowth, &isWithered, &witheredAt, &plantCreatedAt,
+			&seedName, &seedIcon, &seedImgPlant, &targetGrowth, &goldReward, &xpReward, &modification,
+			&stageThresholds,
 		)
 		if err != nil {
 			return nil, err
@@ -203,14 +210,23 @@ func (r *BedRepo) GetWithPlants(ctx context.Context, userID int64) ([]model.BedW
 					SeedID:        *seedID,
 					BedID:         bed.ID,
 					CurrentGrowth: *currentGrowth,
+					WitheredAt:    witheredAt,
 				},
-				SeedName:      *seedName,
-				SeedIcon:      getStringPtr(seedIcon),
-				TargetGrowth:  *targetGrowth,
-				GoldReward:    *goldReward,
-				XPReward:      *xpReward,
-				GrowthPercent: utils.CalculateGrowthPercent(*currentGrowth, *targetGrowth),
+				SeedName:         *seedName,
+				SeedIcon:         getStringPtr(seedIcon),
+				SeedImgPlant:     getStringPtr(seedImgPlant),
+				TargetGrowth:     *targetGrowth,
+				GoldReward:       *goldReward,
+				XPReward:         *xpReward,
+				GrowthPercent:    utils.CalculateGrowthPercent(*currentGrowth, *targetGrowth),
+				IsWithered:       isWithered != nil && *isWithered,
+				Modification:     *modification,
+				GrowthMultiplier: utils.GrowthMultiplier(*modification),
 			}
+			if plantCreatedAt != nil {
+				bed.UserPlant.CreatedAt = *plantCreatedAt
+			}
+			setPlantStage(bed.UserPlant, stageThresholds)
 		}
 
 		beds = append(beds, bed)
@@ -220,11 +236,11 @@ func (r *BedRepo) GetWithPlants(ctx context.Context, userID int64) ([]model.BedW
 
 func (r *BedRepo) GetEmptyBeds(ctx context.Context, userID int64) ([]model.Bed, error) {
 	query := `
-		SELECT b.id, b.user_id, b.cell_number, b.is_locked, b.created_at
+		SELECT b.id, b.user_id, b.cell_number, b.x, b.y, b.is_locked, b.created_at
 		FROM bed b
 		LEFT JOIN user_plant up ON b.id = up.bed_id
 		WHERE b.user_id = $1 AND up.id IS NULL AND b.is_locked = false
-		ORDER BY b.cell_number
+		ORDER BY b.y, b.x
 	`
 	rows, err := r.db.Query(ctx, query, userID)
 	if err != nil {
@@ -236,7 +252,7 @@ func (r *BedRepo) GetEmptyBeds(ctx context.Context, userID int64) ([]model.Bed,
 	for rows.Next() {
 		var bed model.Bed
 		if err := rows.Scan(
-			&bed.ID, &bed.UserID, &bed.CellNumber, &bed.IsLocked, &bed.CreatedAt,
+			&bed.ID, &bed.UserID, &bed.CellNumber, &bed.X, &bed.Y, &bed.IsLocked, &bed.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -245,23 +261,150 @@ func (r *BedRepo) GetEmptyBeds(ctx context.Context, userID int64) ([]model.Bed,
 	return beds, nil
 }
 
-func (r *BedRepo) CreateInitialBeds(ctx context.Context, userID int64, count int) error {
-	for i := 1; i <= count; i++ {
-		// Первые 1 грядка разблокирована, остальные заблокированы
-		isLocked := i > 1
-		query := `
-			INSERT INTO bed (user_id, cell_number, is_locked, created_at)
-			VALUES ($1, $2, $3, NOW())
-			ON CONFLICT (user_id, cell_number) DO NOTHING
-		`
-		_, err := r.db.Exec(ctx, query, userID, i, isLocked)
-		if err != nil {
-			return err
+// CreateInitialBeds создает поле width x height. Первая грядка (0, 0) открыта, остальные закрыты.
+// Номера грядок (cellNumber) присваиваются построчно с 1.
+func (r *BedRepo) CreateInitialBeds(ctx context.Context, userID int64, width, height int) error {
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cellNumber := y*width + x + 1
+			isLocked := cellNumber > 1
+			query := `
+				INSERT INTO bed (user_id, cell_number, x, y, is_locked, created_at)
+				VALUES ($1, $2, $3, $4, $5, NOW())
+				ON CONFLICT DO NOTHING
+			`
+			_, err := r.db.Exec(ctx, query, userID, cellNumber, x, y, isLocked)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+// GetByCoordinates возвращает грядку пользователя по координатам
+func (r *BedRepo) GetByCoordinates(ctx context.Context, userID int64, x, y int) (*model.Bed, error) {
+	var bed model.Bed
+	query := `
+		SELECT id, user_id, cell_number, x, y, is_locked, created_at
+		FROM bed
+		WHERE user_id = $1 AND x = $2 AND y = $3
+	`
+	err := r.db.QueryRow(ctx, query, userID, x, y).Scan(
+		&bed.ID, &bed.UserID, &bed.CellNumber, &bed.X, &bed.Y, &bed.IsLocked, &bed.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("bed at (%d, %d) not found", x, y)
+		}
+		return nil, err
+	}
+	return &bed, nil
+}
+
+// GetExpansionCount возвращает количество купленных расширений поля
+func (r *BedRepo) GetExpansionCount(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM farm_expansion WHERE user_id = $1`, userID).Scan(&count)
+	return count, err
+}
+
+// Expand покупает расширение поля: добавляет закрытые грядки в новый ряд (снизу) или столбец (справа),
+// списывает золото и записывает покупку. Стоимость зависит от количества уже купленных расширений.
+func (r *BedRepo) Expand(ctx context.Context, userID int64, direction string) (*model.FarmExpansion, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Блокируем грядки пользователя, чтобы параллельные покупки не получили одинаковые координаты
+	var width, height, maxCellNumber int
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(MAX(x) + 1, 0), COALESCE(MAX(y) + 1, 0), COALESCE(MAX(cell_number), 0)
+		FROM (SELECT x, y, cell_number FROM bed WHERE user_id = $1 FOR UPDATE) b
+	`, userID).Scan(&width, &height, &maxCellNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var expansions int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM farm_expansion WHERE user_id = $1`, userID).Scan(&expansions); err != nil {
+		return nil, err
+	}
+
+	expansion := &model.FarmExpansion{
+		UserID:    userID,
+		Direction: direction,
+		Cost:      utils.FarmExpansionCost(expansions),
+		Width:     width,
+		Height:    height,
+		Beds:      []model.Bed{},
+	}
+
+	// Координаты новых грядок
+	var cells [][2]int
+	switch direction {
+	case constants.FarmExpandRow:
+		if height >= constants.MaxFarmHeight {
+			return nil, fmt.Errorf("farm already has maximum height %d", constants.MaxFarmHeight)
+		}
+		for x := 0; x < width; x++ {
+			cells = append(cells, [2]int{x, height})
+		}
+		expansion.Height++
+	case constants.FarmExpandColumn:
+		if width >= constants.MaxFarmWidth {
+			return nil, fmt.Errorf("farm already has maximum width %d", constants.MaxFarmWidth)
+		}
+		for y := 0; y < height; y++ {
+			cells = append(cells, [2]int{width, y})
+		}
+		expansion.Width++
+	default:
+		return nil, fmt.Errorf("invalid expansion direction: %s", direction)
+	}
+
+	expansion.Balance, err = changeGold(ctx, tx, userID, -int64(expansion.Cost), goldChangeRequireBalance,
+		constants.GoldSourceFarmExpansion, fmt.Sprintf("Расширение поля до %dx%d", expansion.Width, expansion.Height))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, cell := range cells {
+		bed := model.Bed{
+			UserID:     userID,
+			CellNumber: maxCellNumber + i + 1,
+			X:          cell[0],
+			Y:          cell[1],
+			IsLocked:   true,
+		}
+		err := tx.QueryRow(ctx, `
+			INSERT INTO bed (user_id, cell_number, x, y, is_locked, created_at)
+			VALUES ($1, $2, $3, $4, true, NOW())
+			RETURNING id, created_at
+		`, userID, bed.CellNumber, bed.X, bed.Y).Scan(&bed.ID, &bed.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		expansion.Beds = append(expansion.Beds, bed)
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO farm_expansion (user_id, direction, cost, width, height, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING id, created_at
+	`, userID, direction, expansion.Cost, expansion.Width, expansion.Height).Scan(&expansion.ID, &expansion.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return expansion, nil
+}
+
 // Вспомогательные функции
 func getStringPtr(s *string) string {
 	if s != nil {
@@ -272,9 +415,9 @@ func getStringPtr(s *string) string {
 
 func (r *BedRepo) GetAll(ctx context.Context) ([]model.Bed, error) {
 	query := `
-		SELECT id, user_id, cell_number, is_locked, created_at
+		SELECT id, user_id, cell_number, x, y, is_locked, created_at
 		FROM bed
-		ORDER BY cell_number
+		ORDER BY y, x
 	`
 
 	rows, err := r.db.Query(ctx, query)
@@ -290,6 +433,8 @@ func (r *BedRepo) GetAll(ctx context.Context) ([]model.Bed, error) {
 			&bed.ID,
 			&bed.UserID,
 			&bed.CellNumber,
+			&bed.X,
+			&bed.Y,
 			&bed.IsLocked,
 			&bed.CreatedAt,
 		)
@@ -301,20 +446,28 @@ func (r *BedRepo) GetAll(ctx context.Context) ([]model.Bed, error) {
 	return beds, nil
 }
 
-// UnlockNextBed разблокирует следующую закрытую грядку пользователя (по cellNumber)
+// UnlockNextBed разблокирует следующую закрытую грядку пользователя (по рядам, затем по столбцам)
 func (r *BedRepo) UnlockNextBed(ctx context.Context, userID int64) (*model.Bed, error) {
-	// Находим первую заблокированную грядку с минимальным cell_number
+	return unlockNextBed(ctx, r.db, userID)
+}
+
+func unlockNextBed(ctx context.Context, q DBTX, userID int64) (*model.Bed, error) {
+	// Первая заблокированная грядка при просмотре поля по рядам
 	query := `
-		SELECT id, user_id, cell_number, is_locked, created_at
-		FROM bed
-		WHERE user_id = $1 AND is_locked = true
-		ORDER BY cell_number
-		LIMIT 1
+		UPDATE bed SET is_locked = false
+		WHERE id = (
+			SELECT id FROM bed
+			WHERE user_id = $1 AND is_locked = true
+			ORDER BY y, x
+			LIMIT 1
+			FOR UPDATE
+		)
+		RETURNING id, user_id, cell_number, x, y, is_locked, created_at
 	`
 
 	var bed model.Bed
-	err := r.db.QueryRow(ctx, query, userID).Scan(
-		&bed.ID, &bed.UserID, &bed.CellNumber, &bed.IsLocked, &bed.CreatedAt,
+	err := q.QueryRow(ctx, query, userID).Scan(
+		&bed.ID, &bed.UserID, &bed.CellNumber, &bed.X, &bed.Y, &bed.IsLocked, &bed.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -322,17 +475,5 @@ func (r *BedRepo) UnlockNextBed(ctx context.Context, userID int64) (*model.Bed,
 		}
 		return nil, err
 	}
-
-	// Разблокируем грядку
-	if err := r.Unlock(ctx, bed.ID); err != nil {
-		return nil, fmt.Errorf("failed to unlock bed %d: %w", bed.ID, err)
-	}
-
-	// Получаем обновленную грядку
-	unlockedBed, err := r.GetByID(ctx, bed.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	return unlockedBed, nil
+	return &bed, nil
 }
@@ -0,0 +1,632 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+// Complete записывает выполнение задачи или привычки в одной транзакции. Сначала строка задачи или привычки
+// захватывается условным обновлением (done = false): повторное или параллельное выполнение получает ошибку
+// "already marked as done" и ничего не начисляет. Затем под блокировкой статистики пользователя применяются
+// все эффекты — ослабление засухи и стрик с наградой (для первого выполнения за день), опыт с наградами
+// за уровни, золото и рост растений — и записываются progress_log, эффекты для отмены, счетчик выполнений
+// и прогресс ежедневных заданий.
+func (r *ProgressLogRepo) Complete(ctx context.Context, completion *model.Completion) (*model.CompletionResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := claimCompletion(ctx, tx, completion); err != nil {
+		return nil, err
+	}
+
+	userID := completion.UserID
+	effects := &model.CompletionEffects{}
+
+	// Блокировка статистики упорядочивает выполнения одного пользователя: дневные итоги,
+	// по которым ограничивается награда, не меняются до конца транзакции
+	err = tx.QueryRow(ctx, `
+		SELECT longest_streak, drought_days, drought_severity FROM user_stat WHERE user_id = $1 FOR UPDATE
+	`, userID).Scan(&effects.LongestStreakBefore, &effects.DroughtDaysBefore, &effects.DroughtSeverityBefore)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("user stat not found for user_id=%d", userID)
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	completionsToday, xpToday, growthToday, err := dailyCompletionTotals(ctx, tx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.CompletionResult{}
+	result.Reward, result.RewardCap = limitCompletionReward(completion.Reward, completion.Limits,
+		completionsToday, xpToday, growthToday)
+	reward := result.Reward
+	effects.GrowthPoints = reward.Growth
+
+	// Первое выполнение за день — если сегодня еще нет неотмененных выполнений
+	firstToday := completionsToday == 0
+	days, severity := effects.DroughtDaysBefore, effects.DroughtSeverityBefore
+
+	// Первое выполнение за день ослабляет засуху на одну ступень и увеличивает стрик
+	if firstToday {
+		days, severity, err = registerDroughtActivity(ctx, tx, userID, days, severity)
+		if err != nil {
+			return nil, err
+		}
+
+		result.StreakReward, err = incrementStreak(ctx, tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		effects.StreakIncremented = true
+		effects.StreakReward = result.StreakReward
+	}
+	effects.DroughtDaysAfter, effects.DroughtSeverityAfter = days, severity
+	result.Drought = &model.DroughtState{
+		IsDrought:        severity > constants.DroughtSeverityNone,
+		Days:             days,
+		Severity:         severity,
+		GrowthMultiplier: utils.DroughtGrowthMultiplier(severity),
+	}
+
+	result.Experience, err = addExperience(ctx, tx, userID, int64(reward.XP))
+	if err != nil {
+		return nil, err
+	}
+
+	log := &model.ProgressLog{
+		UserID:     userID,
+		TaskID:     completion.TaskID,
+		HabitID:    completion.HabitID,
+		XPEarned:   reward.XP,
+		GoldEarned: reward.Gold,
+		CreatedAt:  now,
+	}
+	log.Source = progressLogSource(log)
+
+	if reward.Gold > 0 {
+		goldSource := constants.GoldSourceTask
+		if log.HabitID != nil {
+			goldSource = constants.GoldSourceHabit
+		}
+		if _, err := changeGold(ctx, tx, userID, int64(reward.Gold), goldChangeAllowNegative,
+			goldSource, completion.GoldDescription); err != nil {
+			return nil, err
+		}
+	}
+
+	result.PlantGrowth, err = growPlants(ctx, tx, userID, reward.Growth, severity)
+	if err != nil {
+		return nil, err
+	}
+	effects.PlantGrowth = result.PlantGrowth
+
+	result.CompletedQuests, err = recordCompletion(ctx, tx, log, effects)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// claimCompletion отмечает задачу или привычку выполненной, только если она еще не выполнена
+func claimCompletion(ctx context.Context, tx pgx.Tx, completion *model.Completion) error {
+	var kind, query string
+	var id int
+	switch {
+	case completion.TaskID != nil:
+		kind, id = "task", *completion.TaskID
+		query = `UPDATE task SET done = true WHERE id = $1 AND user_id = $2 AND done = false`
+	case completion.HabitID != nil:
+		kind, id = "habit", *completion.HabitID
+		query = `UPDATE habit SET done = true, count = count + 1 WHERE id = $1 AND user_id = $2 AND done = false`
+	default:
+		return fmt.Errorf("completion has neither task nor habit")
+	}
+
+	result, err := tx.Exec(ctx, query, id, completion.UserID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+
+	var exists bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM `+kind+` WHERE id = $1 AND user_id = $2)`,
+		id, completion.UserID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%s with id=%d is already marked as done", kind, id)
+	}
+	return fmt.Errorf("%s with id=%d not found or does not belong to user", kind, id)
+}
+
+// growPlants увеличивает рост всех незасохших растений пользователя на очки роста points с учетом множителя
+// роста семени и эффектов (1 + modification + growthBonus) и засухи тяжести droughtSeverity и возвращает,
+// на сколько выросло каждое. Дробная часть накапливается у растения.
+func growPlants(ctx context.Context, tx pgx.Tx, userID int64, points int, droughtSeverity int) ([]model.PlantGrowth, error) {
+	grown := []model.PlantGrowth{}
+	if points <= 0 {
+		return grown, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT up.id, COALESCE(s.modification, 0)::float8, `+plantEffectBonusColumns+`
+		FROM user_plant up
+		INNER JOIN seed s ON s.id = up.seed_id
+		WHERE up.user_id = $1 AND up.is_withered = false
+		ORDER BY up.id
+		FOR UPDATE OF up
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	type plantModifier struct {
+		id           int
+		modification float64
+		growthBonus  float64
+	}
+	plants := []plantModifier{}
+	for rows.Next() {
+		var plant plantModifier
+		var harvestYieldBonus float64
+		if err := rows.Scan(&plant.id, &plant.modification, &plant.growthBonus, &harvestYieldBonus); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		plants = append(plants, plant)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, plant := range plants {
+		gain := utils.ApplyDroughtPenalty(utils.ApplyGrowthModifier(points, plant.modification+plant.growthBonus), droughtSeverity)
+		growth, err := addPlantGrowth(ctx, tx, plant.id, gain)
+		if err != nil {
+			return nil, err
+		}
+		grown = append(grown, model.PlantGrowth{UserPlantID: plant.id, Growth: growth, Gain: gain})
+	}
+	return grown, nil
+}
+
+// recordCompletion записывает выполнение log вместе с его побочными эффектами, увеличивает счетчик
+// выполнений и продвигает ежедневные задания. Возвращает задания, выполненные этим выполнением.
+func recordCompletion(ctx context.Context, tx pgx.Tx, log *model.ProgressLog, effects *model.CompletionEffects) ([]model.UserQuest, error) {
+	err := tx.QueryRow(ctx, `
+		INSERT INTO progress_log (user_id, task_id, habit_id, xp_earned, gold_earned, source, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`, log.UserID, log.TaskID, log.HabitID, log.XPEarned, log.GoldEarned, log.Source, log.Description, log.CreatedAt,
+	).Scan(&log.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rewardItemID *int
+	rewardAmount := 0
+	if effects.StreakReward != nil {
+		rewardItemID = &effects.StreakReward.ItemID
+		rewardAmount = effects.StreakReward.Amount
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO completion_effect (
+			progress_log_id, streak_incremented, longest_streak_before, streak_reward_item_id, streak_reward_amount,
+			drought_days_before, drought_severity_before, drought_days_after, drought_severity_after, growth_points
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, log.ID, effects.StreakIncremented, effects.LongestStreakBefore, rewardItemID, rewardAmount,
+		effects.DroughtDaysBefore, effects.DroughtSeverityBefore, effects.DroughtDaysAfter, effects.DroughtSeverityAfter,
+		effects.GrowthPoints)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, growth := range effects.PlantGrowth {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO completion_plant_growth (progress_log_id, user_plant_id, growth, growth_exact)
+			VALUES ($1, $2, $3, $4)
+		`, log.ID, growth.UserPlantID, growth.Growth, growth.Gain)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// total_tasks_completed считает выполнения и задач, и привычек
+	_, err = tx.Exec(ctx, `
+		UPDATE user_stat SET total_tasks_completed = total_tasks_completed + 1, updated_at = NOW() WHERE user_id = $1
+	`, log.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return advanceQuests(ctx, tx, log.UserID, log.CreatedAt, completionQuestEvent(log), 1)
+}
+
+// dailyCompletionTotals возвращает количество неотмененных выполнений задач и привычек пользователя
+// за день now, а также полученные за них опыт и базовые очки роста. Выполнения удаленных задач
+// и привычек тоже учитываются.
+func dailyCompletionTotals(ctx context.Context, q DBTX, userID int64, now time.Time) (count, xp, growth int, err error) {
+	err = q.QueryRow(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(pl.xp_earned), 0), COALESCE(SUM(ce.growth_points), 0)
+		FROM progress_log pl
+		LEFT JOIN completion_effect ce ON ce.progress_log_id = pl.id
+		WHERE pl.user_id = $1 AND DATE(pl.created_at) = $2
+		  AND pl.source IN ($3, $4)
+		  AND pl.xp_earned > 0 AND pl.undone_at IS NULL
+	`, userID, now.Format("2006-01-02"), constants.ProgressSourceTask, constants.ProgressSourceHabit).Scan(&count, &xp, &growth)
+	return count, xp, growth, err
+}
+
+// limitCompletionReward уменьшает награду reward с учетом completions выполнений и полученных
+// за сегодня xpToday опыта и growthToday очков роста. Опыт, золото и очки роста умножаются на множитель
+// убывающей отдачи, затем опыт и очки роста ограничиваются мягкими дневными лимитами. Возвращает итоговую
+// награду и сведения об ограничении или nil, если награда не изменилась. Опыт за выполнение не опускается ниже 1.
+func limitCompletionReward(reward model.CompletionReward, limits model.RewardLimits, completions, xpToday, growthToday int) (model.CompletionReward, *model.RewardCap) {
+	multiplier := utils.DiminishingMultiplier(completions, limits.DiminishingReturnsAfter,
+		constants.DiminishingReturnsFactor, constants.DiminishingReturnsMinMultiplier)
+
+	limited := model.CompletionReward{
+		XP:     utils.ApplyMultiplier(reward.XP, multiplier),
+		Gold:   utils.ApplyMultiplier(reward.Gold, multiplier),
+		Growth: utils.ApplyMultiplier(reward.Growth, multiplier),
+	}
+
+	xpBeforeCap, growthBeforeCap := limited.XP, limited.Growth
+	limited.XP = utils.ApplySoftCap(limited.XP, xpToday, limits.DailyXPSoftCap, constants.OverSoftCapMultiplier)
+	limited.Growth = utils.ApplySoftCap(limited.Growth, growthToday, limits.DailyGrowthSoftCap, constants.OverSoftCapMultiplier)
+
+	// Выполнение без опыта нельзя было бы отменить
+	if reward.XP > 0 {
+		limited.XP = max(limited.XP, 1)
+	}
+
+	if limited == reward {
+		return reward, nil
+	}
+
+	return limited, &model.RewardCap{
+		CompletionsToday:   completions,
+		Multiplier:         multiplier,
+		XPCapped:           limited.XP < xpBeforeCap,
+		GrowthCapped:       limited.Growth < growthBeforeCap,
+		XPBeforeCap:        reward.XP,
+		GoldBeforeCap:      reward.Gold,
+		GrowthBeforeCap:    reward.Growth,
+		DailyXPSoftCap:     limits.DailyXPSoftCap,
+		DailyGrowthSoftCap: limits.DailyGrowthSoftCap,
+	}
+}
+
+// UndoTaskCompletion отменяет последнее выполнение задачи: снимает отметку выполнения
+// и откатывает все записанные эффекты выполнения в одной транзакции
+func (r *ProgressLogRepo) UndoTaskCompletion(ctx context.Context, userID int64, taskID int, description string) (*model.CompletionUndo, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	log, err := lockLastCompletion(ctx, tx, "task_id", taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(ctx, `UPDATE task SET done = false WHERE id = $1 AND user_id = $2`, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, fmt.Errorf("task with id=%d not found or does not belong to user", taskID)
+	}
+
+	undo, err := undoCompletion(ctx, tx, log, constants.GoldSourceTaskUndo, description)
+	if err != nil {
+		return nil, err
+	}
+	return undo, tx.Commit(ctx)
+}
+
+// UndoHabitCompletion отменяет последнее выполнение привычки: снимает отметку выполнения,
+// уменьшает счетчик и откатывает все записанные эффекты выполнения в одной транзакции
+func (r *ProgressLogRepo) UndoHabitCompletion(ctx context.Context, userID int64, habitID int, description string) (*model.CompletionUndo, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	log, err := lockLastCompletion(ctx, tx, "habit_id", habitID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE habit SET done = false, count = GREATEST(0, count - 1) WHERE id = $1 AND user_id = $2
+	`, habitID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, fmt.Errorf("habit with id=%d not found or does not belong to user", habitID)
+	}
+
+	undo, err := undoCompletion(ctx, tx, log, constants.GoldSourceHabitUndo, description)
+	if err != nil {
+		return nil, err
+	}
+	return undo, tx.Commit(ctx)
+}
+
+// lockLastCompletion блокирует последнее неотмененное выполнение задачи или привычки
+func lockLastCompletion(ctx context.Context, q DBTX, column string, id int) (*model.ProgressLog, error) {
+	query := fmt.Sprintf(`
+		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, source, description, created_at
+		FROM progress_log
+		WHERE %s = $1 AND xp_earned > 0 AND undone_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT 1
+		FOR UPDATE
+	`, column)
+
+	var log model.ProgressLog
+	err := q.QueryRow(ctx, query, id).Scan(
+		&log.ID, &log.UserID, &log.TaskID, &log.HabitID, &log.XPEarned, &log.GoldEarned,
+		&log.Source, &log.Description, &log.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("completion for %s=%d not found", column, id)
+		}
+		return nil, err
+	}
+	return &log, nil
+}
+
+// undoCompletion откатывает опыт, счетчик выполнений, золото, рост растений, стрик с наградой, засуху и прогресс заданий,
+// записанные при выполнении log, помечает выполнение отмененным и пишет возвратную запись в progress_log
+func undoCompletion(ctx context.Context, tx pgx.Tx, log *model.ProgressLog, goldSource, description string) (*model.CompletionUndo, error) {
+	// Урожай и опыт со сбора растения, которое выросло благодаря выполнению, уже получены,
+	// поэтому такое выполнение не отменяется
+	var harvested bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM completion_plant_growth cpg
+			INNER JOIN plant_history ph ON ph.user_plant_id = cpg.user_plant_id
+			WHERE cpg.progress_log_id = $1 AND ph.event = $2
+		)
+	`, log.ID, constants.PlantEventHarvested).Scan(&harvested)
+	if err != nil {
+		return nil, err
+	}
+	if harvested {
+		return nil, fmt.Errorf("completion cannot be undone: a plant grown by it has already been harvested")
+	}
+
+	// Награда за задание, в которое засчитано выполнение, уже получена и не забирается обратно
+	questClaimed, err := hasClaimedQuestSince(ctx, tx, log.UserID, log.CreatedAt, completionQuestEvent(log), log.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if questClaimed {
+		return nil, fmt.Errorf("completion cannot be undone: a daily quest it counted towards has already been claimed")
+	}
+
+	undo := &model.CompletionUndo{
+		XPReturned:   log.XPEarned,
+		GoldReturned: log.GoldEarned,
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE user_stat
+		SET experience = GREATEST(0, experience - $1),
+		    total_tasks_completed = GREATEST(0, total_tasks_completed - 1),
+		    updated_at = NOW()
+		WHERE user_id = $2
+	`, log.XPEarned, log.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if log.GoldEarned > 0 {
+		if _, err := changeGold(ctx, tx, log.UserID, -int64(log.GoldEarned), goldChangeClampToZero, goldSource, description); err != nil {
+			return nil, err
+		}
+	}
+
+	// Рост откатывается только у растений, которые еще на грядке
+	reverted, err := revertPlantGrowth(ctx, tx, log.ID)
+	if err != nil {
+		return nil, err
+	}
+	undo.PlantsReverted = reverted
+
+	var effect struct {
+		streakIncremented     bool
+		longestStreakBefore   int
+		rewardItemID          *int
+		rewardAmount          int
+		droughtDaysBefore     int
+		droughtSeverityBefore int
+		droughtDaysAfter      int
+		droughtSeverityAfter  int
+	}
+	err = tx.QueryRow(ctx, `
+		SELECT streak_incremented, longest_streak_before, streak_reward_item_id, streak_reward_amount,
+		       drought_days_before, drought_severity_before, drought_days_after, drought_severity_after
+		FROM completion_effect
+		WHERE progress_log_id = $1
+	`, log.ID).Scan(
+		&effect.streakIncremented, &effect.longestStreakBefore, &effect.rewardItemID, &effect.rewardAmount,
+		&effect.droughtDaysBefore, &effect.droughtSeverityBefore, &effect.droughtDaysAfter, &effect.droughtSeverityAfter,
+	)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	// Выполнения, записанные до появления completion_effect, откатывают только опыт и золото
+
+	if effect.streakIncremented {
+		_, err = tx.Exec(ctx, `
+			UPDATE user_stat
+			SET current_streak = GREATEST(0, current_streak - 1),
+			    longest_streak = GREATEST($1, current_streak - 1),
+			    updated_at = NOW()
+			WHERE user_id = $2
+		`, effect.longestStreakBefore, log.UserID)
+		if err != nil {
+			return nil, err
+		}
+		undo.StreakReverted = true
+	}
+
+	// Награду за стрик списываем, сколько ее осталось в инвентаре
+	if effect.rewardItemID != nil && effect.rewardAmount > 0 {
+		var before int
+		err = tx.QueryRow(ctx, `
+			SELECT quantity FROM user_item WHERE user_id = $1 AND item_id = $2 FOR UPDATE
+		`, log.UserID, *effect.rewardItemID).Scan(&before)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		if returned := min(before, effect.rewardAmount); returned > 0 {
+			_, err = tx.Exec(ctx, `
+				UPDATE user_item SET quantity = quantity - $1, updated_at = NOW() WHERE user_id = $2 AND item_id = $3
+			`, returned, log.UserID, *effect.rewardItemID)
+			if err != nil {
+				return nil, err
+			}
+			undo.StreakRewardReturned = returned
+		}
+	}
+
+	// Засуха возвращается к прежнему состоянию, только если с момента выполнения она не менялась
+	if effect.droughtSeverityBefore != effect.droughtSeverityAfter || effect.droughtDaysBefore != effect.droughtDaysAfter {
+		result, err := tx.Exec(ctx, `
+			UPDATE user_stat
+			SET drought_days = $1, drought_severity = $2, is_drought = $2 > 0, updated_at = NOW()
+			WHERE user_id = $3 AND drought_days = $4 AND drought_severity = $5
+		`, effect.droughtDaysBefore, effect.droughtSeverityBefore, log.UserID, effect.droughtDaysAfter, effect.droughtSeverityAfter)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.RowsAffected() > 0 {
+			// Засуха, ослабленную или завершенную этим выполнением, снова делаем активной
+			_, err = tx.Exec(ctx, `
+				UPDATE drought_event de
+				SET severity = $1, ended_at = NULL, end_reason = NULL
+				WHERE de.id = (
+					SELECT id FROM drought_event
+					WHERE user_id = $2 AND started_at <= $3
+					ORDER BY started_at DESC
+					LIMIT 1
+				)
+				AND (de.ended_at IS NULL OR de.end_reason = $4)
+				AND NOT EXISTS (
+					SELECT 1 FROM drought_event other
+					WHERE other.user_id = $2 AND other.ended_at IS NULL AND other.id <> de.id
+				)
+			`, effect.droughtSeverityBefore, log.UserID, log.CreatedAt, constants.DroughtEndRecovered)
+			if err != nil {
+				return nil, err
+			}
+			undo.DroughtRestored = true
+		}
+	}
+
+	// Прогресс еще не полученных заданий дня выполнения откатывается вместе с выполнением
+	if _, err := advanceQuests(ctx, tx, log.UserID, log.CreatedAt, completionQuestEvent(log), -1); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if _, err := tx.Exec(ctx, `UPDATE progress_log SET undone_at = $1 WHERE id = $2`, now, log.ID); err != nil {
+		return nil, err
+	}
+
+	// Возвратная запись с отрицательными значениями
+	_, err = tx.Exec(ctx, `
+		INSERT INTO progress_log (user_id, task_id, habit_id, xp_earned, gold_earned, source, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, log.UserID, log.TaskID, log.HabitID, -log.XPEarned, -log.GoldEarned, log.Source, description, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return undo, nil
+}
+
+// revertPlantGrowth вычитает из растений точные очки роста, полученные при выполнении progressLogID,
+// и возвращает, у скольких растений откачен рост. Для выполнений, записанных до появления дробного роста,
+// вычитаются целые очки роста.
+func revertPlantGrowth(ctx context.Context, tx pgx.Tx, progressLogID int) (int, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT up.id, up.current_growth, up.growth_fraction, COALESCE(cpg.growth_exact, cpg.growth)
+		FROM completion_plant_growth cpg
+		INNER JOIN user_plant up ON up.id = cpg.user_plant_id
+		WHERE cpg.progress_log_id = $1
+		FOR UPDATE OF up
+	`, progressLogID)
+	if err != nil {
+		return 0, err
+	}
+
+	type plantGrowth struct {
+		id       int
+		growth   int
+		fraction float64
+		gain     float64
+	}
+	plants := []plantGrowth{}
+	for rows.Next() {
+		var plant plantGrowth
+		if err := rows.Scan(&plant.id, &plant.growth, &plant.fraction, &plant.gain); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		plants = append(plants, plant)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, plant := range plants {
+		growth, fraction := utils.AddPlantGrowth(plant.growth, plant.fraction, -plant.gain)
+		_, err := tx.Exec(ctx, `
+			UPDATE user_plant SET current_growth = $1, growth_fraction = $2 WHERE id = $3
+		`, growth, fraction, plant.id)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(plants), nil
+}
+
+// completionQuestEvent описывает выполнение задачи или привычки как событие для ежедневных заданий
+func completionQuestEvent(log *model.ProgressLog) model.QuestEvent {
+	return model.QuestEvent{TaskID: log.TaskID, HabitID: log.HabitID}
+}
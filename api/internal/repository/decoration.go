@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DecorationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewDecorationRepo(db *pgxpool.Pool) *DecorationRepo {
+	return &DecorationRepo{db: db}
+}
+
+const decorationColumns = `d.id, d.code, d.name, d.description, d.icon, d.cost, d.bonus_type, d.bonus_value::float8, d.created_at`
+
+func decorationDest(d *model.Decoration) []any {
+	return []any{
+		&d.ID, &d.Code, &d.Name, &d.Description, &d.Icon, &d.Cost, &d.BonusType, &d.BonusValue, &d.CreatedAt,
+	}
+}
+
+const placedDecorationQuery = `
+	SELECT ud.id, ud.user_id, ud.decoration_id, ud.x, ud.y, ud.created_at, ` + decorationColumns + `
+	FROM user_decoration ud
+	INNER JOIN decoration d ON d.id = ud.decoration_id
+`
+
+func scanPlacedDecoration(row pgx.Row) (*model.PlacedDecoration, error) {
+	var placed model.PlacedDecoration
+	dest := append([]any{
+		&placed.ID, &placed.UserID, &placed.DecorationID, &placed.X, &placed.Y, &placed.CreatedAt,
+	}, decorationDest(&placed.Decoration)...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &placed, nil
+}
+
+// GetCatalog возвращает каталог декораций
+func (r *DecorationRepo) GetCatalog(ctx context.Context) ([]model.Decoration, error) {
+	query := `SELECT ` + decorationColumns + ` FROM decoration d ORDER BY d.cost, d.id`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	decorations := []model.Decoration{}
+	for rows.Next() {
+		var d model.Decoration
+		if err := rows.Scan(decorationDest(&d)...); err != nil {
+			return nil, err
+		}
+		decorations = append(decorations, d)
+	}
+	return decorations, rows.Err()
+}
+
+// GetPlaced возвращает декорации, поставленные пользователем на поле
+func (r *DecorationRepo) GetPlaced(ctx context.Context, userID int64) ([]model.PlacedDecoration, error) {
+	rows, err := r.db.Query(ctx, placedDecorationQuery+` WHERE ud.user_id = $1 ORDER BY ud.y, ud.x`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	placed := []model.PlacedDecoration{}
+	for rows.Next() {
+		p, err := scanPlacedDecoration(rows)
+		if err != nil {
+			return nil, err
+		}
+		placed = append(placed, *p)
+	}
+	return placed, rows.Err()
+}
+
+// Place покупает декорацию и ставит ее на свободную клетку поля (x, y)
+func (r *DecorationRepo) Place(ctx context.Context, userID int64, decorationID int, x, y int) (*model.DecorationChange, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var decoration model.Decoration
+	err = tx.QueryRow(ctx, `SELECT `+decorationColumns+` FROM decoration d WHERE d.id = $1`, decorationID).
+		Scan(decorationDest(&decoration)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("decoration with id=%d not found", decorationID)
+		}
+		return nil, err
+	}
+
+	if err := checkCellFree(ctx, tx, userID, x, y, 0); err != nil {
+		return nil, err
+	}
+
+	change := &model.DecorationChange{GoldChange: -decoration.Cost}
+	change.Balance, err = changeGold(ctx, tx, userID, -int64(decoration.Cost), goldChangeRequireBalance,
+		constants.GoldSourceDecoration, fmt.Sprintf("Покупка декорации «%s»", decoration.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	placed := model.PlacedDecoration{
+		UserID:       userID,
+		DecorationID: decorationID,
+		X:            x,
+		Y:            y,
+		Decoration:   decoration,
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO user_decoration (user_id, decoration_id, x, y, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, created_at
+	`, userID, decorationID, x, y).Scan(&placed.ID, &placed.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	change.Decoration = placed
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// Move переносит поставленную декорацию на свободную клетку поля (x, y)
+func (r *DecorationRepo) Move(ctx context.Context, userID int64, placedID int, x, y int) (*model.DecorationChange, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := checkCellFree(ctx, tx, userID, x, y, placedID); err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(ctx, `UPDATE user_decoration SET x = $1, y = $2 WHERE id = $3 AND user_id = $4`,
+		x, y, placedID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, fmt.Errorf("placed decoration with id=%d not found", placedID)
+	}
+
+	placed, err := scanPlacedDecoration(tx.QueryRow(ctx, placedDecorationQuery+` WHERE ud.id = $1`, placedID))
+	if err != nil {
+		return nil, err
+	}
+
+	change := &model.DecorationChange{Decoration: *placed}
+	if err := tx.QueryRow(ctx, `SELECT gold FROM user_stat WHERE user_id = $1`, userID).Scan(&change.Balance); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// Remove убирает декорацию с поля и возвращает DecorationRefundPercent процентов ее стоимости
+func (r *DecorationRepo) Remove(ctx context.Context, userID int64, placedID int) (*model.DecorationChange, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	placed, err := scanPlacedDecoration(tx.QueryRow(ctx,
+		placedDecorationQuery+` WHERE ud.id = $1 AND ud.user_id = $2 FOR UPDATE OF ud`, placedID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("placed decoration with id=%d not found", placedID)
+		}
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM user_decoration WHERE id = $1`, placedID); err != nil {
+		return nil, err
+	}
+
+	refund := placed.Decoration.Cost * constants.DecorationRefundPercent / 100
+	change := &model.DecorationChange{Decoration: *placed, GoldChange: refund}
+	change.Balance, err = changeGold(ctx, tx, userID, int64(refund), goldChangeAllowNegative,
+		constants.GoldSourceDecorationRefund, fmt.Sprintf("Возврат за декорацию «%s»", placed.Decoration.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// checkCellFree проверяет, что клетка (x, y) есть на поле пользователя, на ее грядке нет растения
+// и на ней не стоит другая декорация (кроме ignoreID)
+func checkCellFree(ctx context.Context, q DBTX, userID int64, x, y int, ignoreID int) error {
+	var bedID int
+	var hasPlant bool
+	err := q.QueryRow(ctx, `
+		SELECT b.id, EXISTS(SELECT 1 FROM user_plant up WHERE up.bed_id = b.id)
+		FROM bed b
+		WHERE b.user_id = $1 AND b.x = $2 AND b.y = $3
+		FOR UPDATE OF b
+	`, userID, x, y).Scan(&bedID, &hasPlant)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("cell (%d, %d) is outside the farm", x, y)
+		}
+		return err
+	}
+	if hasPlant {
+		return fmt.Errorf("cell (%d, %d) is occupied by a plant", x, y)
+	}
+
+	var decorated bool
+	err = q.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM user_decoration WHERE user_id = $1 AND x = $2 AND y = $3 AND id <> $4)`,
+		userID, x, y, ignoreID,
+	).Scan(&decorated)
+	if err != nil {
+		return err
+	}
+	if decorated {
+		return fmt.Errorf("cell (%d, %d) is occupied by a decoration", x, y)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DroughtEventRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewDroughtEventRepo(db *pgxpool.Pool) *DroughtEventRepo {
+	return &DroughtEventRepo{db: db}
+}
+
+const droughtEventColumns = `
+	id, user_id, started_at, ended_at, days, severity, max_severity,
+	plants_withered, plants_died, end_reason
+`
+
+func scanDroughtEvent(row pgx.Row) (*model.DroughtEvent, error) {
+	var event model.DroughtEvent
+	err := row.Scan(
+		&event.ID, &event.UserID, &event.StartedAt, &event.EndedAt, &event.Days, &event.Severity,
+		&event.MaxSeverity, &event.PlantsWithered, &event.PlantsDied, &event.EndReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+	event.IsActive = event.EndedAt == nil
+	return &event, nil
+}
+
+// RegisterMissedDay в одной транзакции усиливает засуху после пропущенного дня: обновляет состояние засухи
+// пользователя и запись drought_event и применяет последствия к растениям.
+// Сначала погибают растения, засохшие в предыдущие дни, затем засыхают оставшиеся.
+func (r *DroughtEventRepo) RegisterMissedDay(ctx context.Context, userID int64) (*model.DroughtEvent, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	days, severity, err := lockDroughtState(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	days, severity = utils.DroughtAfterMissedDay(days, severity)
+
+	event, err := getActiveDroughtEvent(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		query := `
+			INSERT INTO drought_event (user_id, started_at, days, severity, max_severity)
+			VALUES ($1, NOW(), 1, $2, $2)
+			RETURNING ` + droughtEventColumns
+		if event, err = scanDroughtEvent(tx.QueryRow(ctx, query, userID, severity)); err != nil {
+			return nil, err
+		}
+	}
+
+	var died, withered int64
+	if severity >= constants.DroughtSeverityDeadly {
+		result, err := tx.Exec(ctx, archivePlantsQuery(`up.user_id = $3 AND up.is_withered = true`),
+			constants.PlantEventDied, constants.PlantDeathDrought, userID)
+		if err != nil {
+			return nil, err
+		}
+		died = result.RowsAffected()
+	}
+	if severity >= constants.DroughtSeverityWithering {
+		result, err := tx.Exec(ctx, `
+			UPDATE user_plant SET is_withered = true, withered_at = NOW() WHERE user_id = $1 AND is_withered = false
+		`, userID)
+		if err != nil {
+			return nil, err
+		}
+		withered = result.RowsAffected()
+	}
+
+	event, err = scanDroughtEvent(tx.QueryRow(ctx, `
+		UPDATE drought_event
+		SET days = $1, severity = $2, max_severity = GREATEST(max_severity, $2),
+		    plants_withered = plants_withered + $3, plants_died = plants_died + $4
+		WHERE id = $5
+		RETURNING `+droughtEventColumns, days, severity, withered, died, event.ID))
+	if err != nil {
+		return nil, err
+	}
+	if err := setDroughtState(ctx, tx, userID, days, severity); err != nil {
+		return nil, err
+	}
+
+	return event, tx.Commit(ctx)
+}
+
+// registerDroughtActivity ослабляет засуху с текущими days и severity на одну ступень в транзакции tx:
+// обновляет состояние засухи пользователя и активную запись drought_event, а при тяжести 0 закрывает ее
+func registerDroughtActivity(ctx context.Context, tx pgx.Tx, userID int64, days, severity int) (int, int, error) {
+	if severity <= constants.DroughtSeverityNone {
+		return days, severity, nil
+	}
+	days, severity = utils.DroughtAfterActivity(days, severity)
+
+	var err error
+	if severity == constants.DroughtSeverityNone {
+		_, err = tx.Exec(ctx, `
+			UPDATE drought_event SET ended_at = NOW(), severity = 0, end_reason = $1 WHERE user_id = $2 AND ended_at IS NULL
+		`, constants.DroughtEndRecovered, userID)
+	} else {
+		_, err = tx.Exec(ctx, `
+			UPDATE drought_event SET severity = $1 WHERE user_id = $2 AND ended_at IS NULL
+		`, severity, userID)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := setDroughtState(ctx, tx, userID, days, severity); err != nil {
+		return 0, 0, err
+	}
+	return days, severity, nil
+}
+
+// lockDroughtState блокирует строку user_stat и возвращает текущее число дней и тяжесть засухи
+func lockDroughtState(ctx context.Context, q DBTX, userID int64) (days, severity int, err error) {
+	err = q.QueryRow(ctx, `
+		SELECT drought_days, drought_severity FROM user_stat WHERE user_id = $1 FOR UPDATE
+	`, userID).Scan(&days, &severity)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, 0, fmt.Errorf("user stat not found for user_id=%d", userID)
+	}
+	return days, severity, err
+}
+
+// getActiveDroughtEvent возвращает текущую засуху пользователя или nil, если засухи нет
+func getActiveDroughtEvent(ctx context.Context, q DBTX, userID int64) (*model.DroughtEvent, error) {
+	query := `SELECT ` + droughtEventColumns + ` FROM drought_event WHERE user_id = $1 AND ended_at IS NULL FOR UPDATE`
+	event, err := scanDroughtEvent(q.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return event, nil
+}
+
+// GetByUser возвращает историю засух пользователя, начиная с последней
+func (r *DroughtEventRepo) GetByUser(ctx context.Context, userID int64, limit int) ([]model.DroughtEvent, error) {
+	query := `
+		SELECT ` + droughtEventColumns + `
+		FROM drought_event
+		WHERE user_id = $1
+		ORDER BY started_at DESC
+		LIMIT $2
+	`
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []model.DroughtEvent{}
+	for rows.Next() {
+		event, err := scanDroughtEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type FriendRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewFriendRepo(db *pgxpool.Pool) *FriendRepo {
+	return &FriendRepo{db: db}
+}
+
+const friendRequestQuery = `
+	SELECT f.id, f.requester_id, ru.max_id, ru.username, f.addressee_id, au.max_id, au.username, f.created_at
+	FROM friendship f
+	INNER JOIN user_info ru ON ru.id = f.requester_id
+	INNER JOIN user_info au ON au.id = f.addressee_id
+`
+
+func scanFriendRequest(row pgx.Row) (*model.FriendRequest, error) {
+	var request model.FriendRequest
+	err := row.Scan(
+		&request.ID, &request.FromUserID, &request.FromMaxID, &request.FromUsername,
+		&request.ToUserID, &request.ToMaxID, &request.ToUsername, &request.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// CreateRequest отправляет заявку в друзья от fromUserID пользователю toUserID.
+// Если встречная заявка уже ждет ответа, она принимается и возвращается accepted = true.
+func (r *FriendRepo) CreateRequest(ctx context.Context, fromUserID, toUserID int64) (request *model.FriendRequest, accepted bool, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var id, requesterID int64
+	var status string
+	err = tx.QueryRow(ctx, `
+		SELECT id, requester_id, status FROM friendship
+		WHERE LEAST(requester_id, addressee_id) = LEAST($1::bigint, $2::bigint)
+		  AND GREATEST(requester_id, addressee_id) = GREATEST($1::bigint, $2::bigint)
+		FOR UPDATE
+	`, fromUserID, toUserID).Scan(&id, &requesterID, &status)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		err = tx.QueryRow(ctx, `
+			INSERT INTO friendship (requester_id, addressee_id, status, created_at)
+			VALUES ($1, $2, $3, NOW())
+			ON CONFLICT DO NOTHING
+			RETURNING id
+		`, fromUserID, toUserID, constants.FriendshipPending).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, fmt.Errorf("friend request already sent")
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	case err != nil:
+		return nil, false, err
+	case status == constants.FriendshipAccepted:
+		return nil, false, fmt.Errorf("users are already friends")
+	case requesterID == fromUserID:
+		return nil, false, fmt.Errorf("friend request already sent")
+	default:
+		// Встречная заявка: считаем ее принятой
+		_, err = tx.Exec(ctx, `
+			UPDATE friendship SET status = $1, accepted_at = NOW() WHERE id = $2
+		`, constants.FriendshipAccepted, id)
+		if err != nil {
+			return nil, false, err
+		}
+		accepted = true
+	}
+
+	request, err = scanFriendRequest(tx.QueryRow(ctx, friendRequestQuery+` WHERE f.id = $1`, id))
+	if err != nil {
+		return nil, false, err
+	}
+	return request, accepted, tx.Commit(ctx)
+}
+
+// GetRequests возвращает входящие и исходящие заявки пользователя, ожидающие ответа
+func (r *FriendRepo) GetRequests(ctx context.Context, userID int64) (*model.FriendRequests, error) {
+	query := friendRequestQuery + `
+		WHERE f.status = $1 AND (f.requester_id = $2 OR f.addressee_id = $2)
+		ORDER BY f.created_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, constants.FriendshipPending, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := &model.FriendRequests{
+		Incoming: []model.FriendRequest{},
+		Outgoing: []model.FriendRequest{},
+	}
+	for rows.Next() {
+		request, err := scanFriendRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		if request.ToUserID == userID {
+			requests.Incoming = append(requests.Incoming, *request)
+		} else {
+			requests.Outgoing = append(requests.Outgoing, *request)
+		}
+	}
+	return requests, rows.Err()
+}
+
+// Accept принимает входящую заявку requestID
+func (r *FriendRepo) Accept(ctx context.Context, userID int64, requestID int64) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE friendship SET status = $1, accepted_at = NOW()
+		WHERE id = $2 AND addressee_id = $3 AND status = $4
+	`, constants.FriendshipAccepted, requestID, userID, constants.FriendshipPending)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("friend request with id=%d not found", requestID)
+	}
+	return nil
+}
+
+// Decline отклоняет входящую или отменяет исходящую заявку requestID
+func (r *FriendRepo) Decline(ctx context.Context, userID int64, requestID int64) error {
+	result, err := r.db.Exec(ctx, `
+		DELETE FROM friendship
+		WHERE id = $1 AND (addressee_id = $2 OR requester_id = $2) AND status = $3
+	`, requestID, userID, constants.FriendshipPending)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("friend request with id=%d not found", requestID)
+	}
+	return nil
+}
+
+// GetFriends возвращает друзей пользователя с их уровнем
+func (r *FriendRepo) GetFriends(ctx context.Context, userID int64) ([]model.Friend, error) {
+	query := `
+		SELECT u.id, u.max_id, u.username, COALESCE(us.experience, 0), f.accepted_at
+		FROM friendship f
+		INNER JOIN user_info u ON u.id = CASE WHEN f.requester_id = $1 THEN f.addressee_id ELSE f.requester_id END
+		LEFT JOIN user_stat us ON us.user_id = u.id
+		WHERE f.status = $2 AND (f.requester_id = $1 OR f.addressee_id = $1)
+		ORDER BY u.username
+	`
+	rows, err := r.db.Query(ctx, query, userID, constants.FriendshipAccepted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	friends := []model.Friend{}
+	for rows.Next() {
+		var friend model.Friend
+		var experience int64
+		if err := rows.Scan(&friend.UserID, &friend.MaxID, &friend.Username, &experience, &friend.Since); err != nil {
+			return nil, err
+		}
+		friend.Level = utils.CalculateLevel(experience)
+		friends = append(friends, friend)
+	}
+	return friends, rows.Err()
+}
+
+// AreFriends проверяет, что пользователи — друзья
+func (r *FriendRepo) AreFriends(ctx context.Context, userID, otherUserID int64) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM friendship
+			WHERE status = $3
+			  AND ((requester_id = $1 AND addressee_id = $2) OR (requester_id = $2 AND addressee_id = $1))
+		)
+	`, userID, otherUserID, constants.FriendshipAccepted).Scan(&exists)
+	return exists, err
+}
+
+// Remove удаляет пользователя friendID из друзей
+func (r *FriendRepo) Remove(ctx context.Context, userID, friendID int64) error {
+	result, err := r.db.Exec(ctx, `
+		DELETE FROM friendship
+		WHERE status = $3
+		  AND ((requester_id = $1 AND addressee_id = $2) OR (requester_id = $2 AND addressee_id = $1))
+	`, userID, friendID, constants.FriendshipAccepted)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("friend with id=%d not found", friendID)
+	}
+	return nil
+}
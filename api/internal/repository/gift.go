@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type GiftRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewGiftRepo(db *pgxpool.Pool) *GiftRepo {
+	return &GiftRepo{db: db}
+}
+
+const giftQuery = `
+	SELECT g.id, g.sender_id, su.username, g.recipient_id, ru.username, g.kind, g.seed_id, s.name,
+	       g.amount, g.status, g.created_at, g.resolved_at
+	FROM gift g
+	INNER JOIN user_info su ON su.id = g.sender_id
+	INNER JOIN user_info ru ON ru.id = g.recipient_id
+	INNER JOIN seed s ON s.id = g.seed_id
+`
+
+func scanGift(row pgx.Row) (*model.Gift, error) {
+	var gift model.Gift
+	err := row.Scan(
+		&gift.ID, &gift.SenderID, &gift.SenderUsername, &gift.RecipientID, &gift.RecipientUsername,
+		&gift.Kind, &gift.SeedID, &gift.SeedName, &gift.Amount, &gift.Status, &gift.CreatedAt, &gift.ResolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &gift, nil
+}
+
+// Create отправляет подарок: проверяет дружбу и дневные лимиты, списывает подарок у отправителя
+// и создает ожидающий ответа подарок в одной транзакции. При отклонении подарок возвращается отправителю.
+func (r *GiftRepo) Create(ctx context.Context, gift *model.Gift, sendLimit, receiveLimit int) (*model.Gift, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := checkExchangeAllowed(ctx, tx, gift.SenderID, gift.RecipientID, sendLimit, receiveLimit); err != nil {
+		return nil, err
+	}
+
+	if err := takeInventory(ctx, tx, gift.SenderID, gift.Kind, gift.SeedID, gift.Amount); err != nil {
+		return nil, err
+	}
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO gift (sender_id, recipient_id, kind, seed_id, amount, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id
+	`, gift.SenderID, gift.RecipientID, gift.Kind, gift.SeedID, gift.Amount, constants.ExchangePending).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := scanGift(tx.QueryRow(ctx, giftQuery+` WHERE g.id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+	return created, tx.Commit(ctx)
+}
+
+// GetPending возвращает входящие и исходящие подарки пользователя, ожидающие ответа
+func (r *GiftRepo) GetPending(ctx context.Context, userID int64) (*model.Gifts, error) {
+	query := giftQuery + `
+		WHERE g.status = $1 AND (g.sender_id = $2 OR g.recipient_id = $2)
+		ORDER BY g.created_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, constants.ExchangePending, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	gifts := &model.Gifts{
+		Incoming: []model.Gift{},
+		Outgoing: []model.Gift{},
+	}
+	for rows.Next() {
+		gift, err := scanGift(rows)
+		if err != nil {
+			return nil, err
+		}
+		if gift.RecipientID == userID {
+			gifts.Incoming = append(gifts.Incoming, *gift)
+		} else {
+			gifts.Outgoing = append(gifts.Outgoing, *gift)
+		}
+	}
+	return gifts, rows.Err()
+}
+
+// Accept принимает входящий подарок: добавляет его получателю
+func (r *GiftRepo) Accept(ctx context.Context, userID int64, giftID int64) (*model.Gift, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	gift, err := lockPendingGift(ctx, tx, giftID, `recipient_id = $2`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := putInventory(ctx, tx, gift.RecipientID, gift.Kind, gift.SeedID, gift.Amount); err != nil {
+		return nil, err
+	}
+
+	return r.resolve(ctx, tx, gift.ID, constants.ExchangeAccepted)
+}
+
+// Decline отклоняет входящий подарок или отменяет исходящий и возвращает подарок отправителю
+func (r *GiftRepo) Decline(ctx context.Context, userID int64, giftID int64) (*model.Gift, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	gift, err := lockPendingGift(ctx, tx, giftID, `(recipient_id = $2 OR sender_id = $2)`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := putInventory(ctx, tx, gift.SenderID, gift.Kind, gift.SeedID, gift.Amount); err != nil {
+		return nil, err
+	}
+
+	status := constants.ExchangeDeclined
+	if gift.SenderID == userID {
+		status = constants.ExchangeCancelled
+	}
+	return r.resolve(ctx, tx, gift.ID, status)
+}
+
+// resolve завершает подарок со статусом status и фиксирует транзакцию
+func (r *GiftRepo) resolve(ctx context.Context, tx pgx.Tx, giftID int64, status string) (*model.Gift, error) {
+	_, err := tx.Exec(ctx, `UPDATE gift SET status = $1, resolved_at = $2 WHERE id = $3`, status, time.Now(), giftID)
+	if err != nil {
+		return nil, err
+	}
+
+	gift, err := scanGift(tx.QueryRow(ctx, giftQuery+` WHERE g.id = $1`, giftID))
+	if err != nil {
+		return nil, err
+	}
+	return gift, tx.Commit(ctx)
+}
+
+// lockPendingGift блокирует ожидающий ответа подарок, доступный пользователю по условию access
+func lockPendingGift(ctx context.Context, tx pgx.Tx, giftID int64, access string, userID int64) (*model.Gift, error) {
+	var gift model.Gift
+	err := tx.QueryRow(ctx, `
+		SELECT id, sender_id, recipient_id, kind, seed_id, amount
+		FROM gift
+		WHERE id = $1 AND `+access+` AND status = $3
+		FOR UPDATE
+	`, giftID, userID, constants.ExchangePending).Scan(
+		&gift.ID, &gift.SenderID, &gift.RecipientID, &gift.Kind, &gift.SeedID, &gift.Amount,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("gift with id=%d not found", giftID)
+		}
+		return nil, err
+	}
+	return &gift, nil
+}
+
+// checkExchangeAllowed проверяет, что отправитель может отправить подарок или предложение обмена получателю:
+// пользователи — друзья, и ни у одного не исчерпан дневной лимит. Блокирует обоих пользователей до конца транзакции,
+// чтобы параллельные запросы не обошли лимиты.
+func checkExchangeAllowed(ctx context.Context, tx pgx.Tx, senderID, recipientID int64, sendLimit, receiveLimit int) error {
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM user_info WHERE id = ANY($1) ORDER BY id FOR UPDATE
+	`, []int64{senderID, recipientID})
+	if err != nil {
+		return err
+	}
+	locked := 0
+	for rows.Next() {
+		locked++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if locked < 2 {
+		return fmt.Errorf("user with id=%d not found", recipientID)
+	}
+
+	var isFriend bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM friendship
+			WHERE status = $3
+			  AND ((requester_id = $1 AND addressee_id = $2) OR (requester_id = $2 AND addressee_id = $1))
+		)
+	`, senderID, recipientID, constants.FriendshipAccepted).Scan(&isFriend)
+	if err != nil {
+		return err
+	}
+	if !isFriend {
+		return fmt.Errorf("recipient is not a friend")
+	}
+
+	today := time.Now().Format("2006-01-02")
+
+	var sent int
+	err = tx.QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM gift WHERE sender_id = $1 AND DATE(created_at) = $2)
+		     + (SELECT COUNT(*) FROM trade_offer WHERE proposer_id = $1 AND DATE(created_at) = $2)
+	`, senderID, today).Scan(&sent)
+	if err != nil {
+		return err
+	}
+	if sent >= sendLimit {
+		return fmt.Errorf("daily gift limit reached (%d per day)", sendLimit)
+	}
+
+	// Отмененные отправителем подарки и обмены не занимают лимит получателя
+	var received int
+	err = tx.QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM gift WHERE recipient_id = $1 AND DATE(created_at) = $2 AND status <> $3)
+		     + (SELECT COUNT(*) FROM trade_offer WHERE recipient_id = $1 AND DATE(created_at) = $2 AND status <> $3)
+	`, recipientID, today, constants.ExchangeCancelled).Scan(&received)
+	if err != nil {
+		return err
+	}
+	if received >= receiveLimit {
+		return fmt.Errorf("recipient daily gift limit reached (%d per day)", receiveLimit)
+	}
+	return nil
+}
+
+// takeInventory списывает семена или урожай у пользователя.
+// Если их недостаточно, возвращает ошибку "not enough ...".
+func takeInventory(ctx context.Context, q DBTX, userID int64, kind string, seedID int, amount int) error {
+	var query string
+	switch kind {
+	case constants.ExchangeKindSeed:
+		query = `UPDATE user_seed SET quantity = quantity - $3 WHERE user_id = $1 AND seed_id = $2 AND quantity >= $3`
+	case constants.ExchangeKindProduce:
+		query = `
+			UPDATE user_produce SET quantity = quantity - $3, updated_at = NOW()
+			WHERE user_id = $1 AND seed_id = $2 AND quantity >= $3
+		`
+	default:
+		return fmt.Errorf("unknown exchange kind: %s", kind)
+	}
+
+	result, err := q.Exec(ctx, query, userID, seedID, amount)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("not enough %s of seed_id=%d for user %d", kind, seedID, userID)
+	}
+	return nil
+}
+
+// putInventory добавляет семена или урожай пользователю
+func putInventory(ctx context.Context, q DBTX, userID int64, kind string, seedID int, amount int) error {
+	var query string
+	switch kind {
+	case constants.ExchangeKindSeed:
+		query = `
+			INSERT INTO user_seed (user_id, seed_id, quantity, created_at)
+			VALUES ($1, $2, $3, NOW())
+			ON CONFLICT (user_id, seed_id)
+			DO UPDATE SET quantity = user_seed.quantity + EXCLUDED.quantity
+		`
+	case constants.ExchangeKindProduce:
+		query = `
+			INSERT INTO user_produce (user_id, seed_id, quantity, updated_at)
+			VALUES ($1, $2, $3, NOW())
+			ON CONFLICT (user_id, seed_id)
+			DO UPDATE SET quantity = user_produce.quantity + EXCLUDED.quantity, updated_at = NOW()
+		`
+	default:
+		return fmt.Errorf("unknown exchange kind: %s", kind)
+	}
+
+	_, err := q.Exec(ctx, query, userID, seedID, amount)
+	return err
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type GoldLedgerRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewGoldLedgerRepo(db *pgxpool.Pool) *GoldLedgerRepo {
+	return &GoldLedgerRepo{db: db}
+}
+
+// GetByUser возвращает журнал изменений золота пользователя, начиная с последних
+func (r *GoldLedgerRepo) GetByUser(ctx context.Context, userID int64, limit int) ([]model.GoldLedgerEntry, error) {
+	query := `
+		SELECT id, user_id, amount, balance_after, source, description, created_at
+		FROM gold_ledger
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []model.GoldLedgerEntry{}
+	for rows.Next() {
+		var entry model.GoldLedgerEntry
+		if err := rows.Scan(
+			&entry.ID, &entry.UserID, &entry.Amount, &entry.BalanceAfter, &entry.Source, &entry.Description, &entry.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// goldChangeMode определяет, как поступать, если списание больше баланса
+type goldChangeMode int
+
+const (
+	goldChangeAllowNegative  goldChangeMode = iota // Баланс может стать отрицательным
+	goldChangeClampToZero                          // Списывается не больше, чем есть
+	goldChangeRequireBalance                       // Ошибка "not enough gold", если золота недостаточно
+)
+
+// changeGold изменяет баланс золота и записывает фактическое изменение в gold_ledger.
+// Должна вызываться внутри транзакции. Возвращает новый баланс.
+func changeGold(ctx context.Context, q DBTX, userID int64, amount int64, mode goldChangeMode, source, description string) (int64, error) {
+	var balance int64
+	err := q.QueryRow(ctx, `SELECT gold FROM user_stat WHERE user_id = $1 FOR UPDATE`, userID).Scan(&balance)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("user stat not found for user_id=%d", userID)
+		}
+		return 0, err
+	}
+
+	if balance+amount < 0 {
+		switch mode {
+		case goldChangeClampToZero:
+			amount = -balance
+		case goldChangeRequireBalance:
+			return 0, fmt.Errorf("not enough gold")
+		}
+	}
+	if amount == 0 {
+		return balance, nil
+	}
+
+	err = q.QueryRow(ctx, `
+		UPDATE user_stat SET gold = gold + $1, updated_at = NOW()
+		WHERE user_id = $2
+		RETURNING gold
+	`, amount, userID).Scan(&balance)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = q.Exec(ctx, `
+		INSERT INTO gold_ledger (user_id, amount, balance_after, source, description, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NOW())
+	`, userID, amount, balance, source, description)
+	if err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
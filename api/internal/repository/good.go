@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -177,26 +179,6 @@ func (r *GoodRepo) UpdateCost(ctx context.Context, id int, cost int) error {
 	return nil
 }
 
-func (r *GoodRepo) AddQuantity(ctx context.Context, id int, amount int) error {
-	query := `
-        UPDATE good 
-        SET quantity = quantity + $1, updated_at = $2
-        WHERE id = $3
-    `
-
-	result, err := r.db.Exec(ctx, query, amount, time.Now(), id)
-	if err != nil {
-		return err
-	}
-
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("good with id=%d not found", id)
-	}
-
-	return nil
-}
-
 func (r *GoodRepo) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM good WHERE id = $1`
 	result, err := r.db.Exec(ctx, query, id)
@@ -276,3 +258,78 @@ func (r *GoodRepo) DeleteByUser(ctx context.Context, userID int64) error {
 
 	return nil
 }
+
+// Buy покупает одну единицу товара из магазина пользователя в одной транзакции:
+// товар блокируется, золото списывается, остаток уменьшается и покупка попадает в инвентарь.
+// При любой ошибке ничего не меняется.
+func (r *GoodRepo) Buy(ctx context.Context, userID int64, goodID int) (*model.GoodPurchase, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var purchase model.GoodPurchase
+	good := &purchase.Good
+	err = tx.QueryRow(ctx, `
+		SELECT id, user_id, type, id_good, quantity, cost, created_at, updated_at
+		FROM good
+		WHERE id = $1
+		FOR UPDATE
+	`, goodID).Scan(
+		&good.ID, &good.UserID, &good.Type, &good.IDGood,
+		&good.Quantity, &good.Cost, &good.CreatedAt, &good.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("good with id=%d not found", goodID)
+		}
+		return nil, err
+	}
+
+	// Товар должен принадлежать магазину пользователя
+	if good.UserID != userID {
+		return nil, fmt.Errorf("access denied")
+	}
+	if good.Quantity < 1 {
+		return nil, fmt.Errorf("good is out of stock")
+	}
+
+	description := fmt.Sprintf("Покупка товара %d (%s)", good.ID, good.Type)
+	if _, err := changeGold(ctx, tx, userID, -int64(good.Cost), goldChangeRequireBalance,
+		constants.GoldSourceShopPurchase, description); err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE good SET quantity = quantity - 1, updated_at = NOW()
+		WHERE id = $1
+		RETURNING quantity, updated_at
+	`, goodID).Scan(&good.Quantity, &good.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	switch good.Type {
+	case "seed":
+		if err := putInventory(ctx, tx, userID, constants.ExchangeKindSeed, good.IDGood, 1); err != nil {
+			return nil, err
+		}
+	case "bed":
+		purchase.UnlockedBed, err = unlockNextBed(ctx, tx, userID)
+		if err != nil {
+			return nil, err
+		}
+	case "consumable", "fertilizer", "tool":
+		if _, err := addUserItem(ctx, tx, userID, good.IDGood, 1); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported good type")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &purchase, nil
+}
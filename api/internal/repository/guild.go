@@ -0,0 +1,496 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type GuildRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewGuildRepo(db *pgxpool.Pool) *GuildRepo {
+	return &GuildRepo{db: db}
+}
+
+const guildQuery = `
+	SELECT g.id, g.name, g.owner_id, (SELECT COUNT(*) FROM guild_member m WHERE m.guild_id = g.id), g.created_at
+	FROM guild g
+`
+
+const guildGoalColumns = `
+	gg.id, gg.template_id, gg.week_start, gg.title, gg.seed_id, s.name, gg.target, gg.progress,
+	gg.completed_at, gg.reward_gold, gg.reward_xp
+`
+
+func scanGuild(row pgx.Row) (*model.Guild, error) {
+	var guild model.Guild
+	if err := row.Scan(&guild.ID, &guild.Name, &guild.OwnerID, &guild.MemberCount, &guild.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &guild, nil
+}
+
+func scanGuildGoal(row pgx.Row) (*model.GuildGoal, error) {
+	var goal model.GuildGoal
+	err := row.Scan(
+		&goal.ID, &goal.TemplateID, &goal.WeekStart, &goal.Title, &goal.SeedID, &goal.SeedName, &goal.Target, &goal.Progress,
+		&goal.CompletedAt, &goal.RewardGold, &goal.RewardXP,
+	)
+	if err != nil {
+		return nil, err
+	}
+	goal.Completed = goal.CompletedAt != nil
+	return &goal, nil
+}
+
+// GetByID возвращает гильдию по ID
+func (r *GuildRepo) GetByID(ctx context.Context, guildID int) (*model.Guild, error) {
+	guild, err := scanGuild(r.db.QueryRow(ctx, guildQuery+` WHERE g.id = $1`, guildID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("guild with id=%d not found", guildID)
+		}
+		return nil, err
+	}
+	return guild, nil
+}
+
+// GetGuildIDByUser возвращает ID гильдии пользователя или nil, если он не состоит в гильдии
+func (r *GuildRepo) GetGuildIDByUser(ctx context.Context, userID int64) (*int, error) {
+	var guildID int
+	err := r.db.QueryRow(ctx, `SELECT guild_id FROM guild_member WHERE user_id = $1`, userID).Scan(&guildID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &guildID, nil
+}
+
+// GetMembers возвращает участников гильдии и их вклад в цель goalID (если цель есть)
+func (r *GuildRepo) GetMembers(ctx context.Context, guildID int, goalID *int) ([]model.GuildMember, error) {
+	query := `
+		SELECT m.user_id, u.username, m.role,
+		       COALESCE((SELECT SUM(c.amount) FROM guild_contribution c WHERE c.goal_id = $2 AND c.user_id = m.user_id), 0),
+		       m.joined_at
+		FROM guild_member m
+		INNER JOIN user_info u ON u.id = m.user_id
+		WHERE m.guild_id = $1
+		ORDER BY m.role = 'owner' DESC, m.joined_at
+	`
+	rows, err := r.db.Query(ctx, query, guildID, goalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []model.GuildMember{}
+	for rows.Next() {
+		var member model.GuildMember
+		if err := rows.Scan(&member.UserID, &member.Username, &member.Role, &member.Contributed, &member.JoinedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// Create создает гильдию, владельцем и первым участником которой становится userID
+func (r *GuildRepo) Create(ctx context.Context, userID int64, name string) (*model.Guild, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockGuildlessUser(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	var taken bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM guild WHERE LOWER(name) = LOWER($1))`, name).Scan(&taken)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, fmt.Errorf("guild name already taken")
+	}
+
+	var guildID int
+	err = tx.QueryRow(ctx, `
+		INSERT INTO guild (name, owner_id, created_at)
+		VALUES ($1, $2, NOW())
+		RETURNING id
+	`, name, userID).Scan(&guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO guild_member (user_id, guild_id, role, joined_at)
+		VALUES ($1, $2, $3, NOW())
+	`, userID, guildID, constants.GuildRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	guild, err := scanGuild(tx.QueryRow(ctx, guildQuery+` WHERE g.id = $1`, guildID))
+	if err != nil {
+		return nil, err
+	}
+	return guild, tx.Commit(ctx)
+}
+
+// Join добавляет пользователя в гильдию, если в ней меньше maxMembers участников
+func (r *GuildRepo) Join(ctx context.Context, userID int64, guildID int, maxMembers int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockGuildlessUser(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	// Блокировка гильдии не дает параллельным вступлениям превысить лимит участников
+	var members int
+	err = tx.QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM guild_member WHERE guild_id = g.id)
+		FROM guild g
+		WHERE g.id = $1
+		FOR UPDATE
+	`, guildID).Scan(&members)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("guild with id=%d not found", guildID)
+		}
+		return err
+	}
+	if members >= maxMembers {
+		return fmt.Errorf("guild is full (%d members)", maxMembers)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO guild_member (user_id, guild_id, role, joined_at)
+		VALUES ($1, $2, $3, NOW())
+	`, userID, guildID, constants.GuildRoleMember)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Leave удаляет пользователя из гильдии. Если уходит владелец, владельцем становится участник,
+// вступивший раньше остальных; гильдия без участников удаляется.
+func (r *GuildRepo) Leave(ctx context.Context, userID int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var guildID int
+	var role string
+	err = tx.QueryRow(ctx, `
+		SELECT guild_id, role FROM guild_member WHERE user_id = $1 FOR UPDATE
+	`, userID).Scan(&guildID, &role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("user is not in a guild")
+		}
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT id FROM guild WHERE id = $1 FOR UPDATE`, guildID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM guild_member WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	if role == constants.GuildRoleOwner {
+		var successorID int64
+		err := tx.QueryRow(ctx, `
+			SELECT user_id FROM guild_member WHERE guild_id = $1 ORDER BY joined_at, user_id LIMIT 1
+		`, guildID).Scan(&successorID)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			if _, err := tx.Exec(ctx, `DELETE FROM guild WHERE id = $1`, guildID); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		default:
+			if _, err := tx.Exec(ctx, `UPDATE guild_member SET role = $1 WHERE user_id = $2`, constants.GuildRoleOwner, successorID); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(ctx, `UPDATE guild SET owner_id = $1 WHERE id = $2`, successorID, guildID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetTemplates возвращает активные шаблоны недельных целей
+func (r *GuildRepo) GetTemplates(ctx context.Context) ([]model.GuildGoalTemplate, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, code, title, seed_id, target, reward_gold, reward_xp
+		FROM guild_goal_template
+		WHERE is_active = true
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []model.GuildGoalTemplate{}
+	for rows.Next() {
+		var template model.GuildGoalTemplate
+		if err := rows.Scan(
+			&template.ID, &template.Code, &template.Title, &template.SeedID, &template.Target,
+			&template.RewardGold, &template.RewardXP,
+		); err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+// GetGoal возвращает цель гильдии на неделю weekStart или nil, если цель еще не выдана
+func (r *GuildRepo) GetGoal(ctx context.Context, guildID int, weekStart time.Time) (*model.GuildGoal, error) {
+	goal, err := scanGuildGoal(r.db.QueryRow(ctx, `
+		SELECT `+guildGoalColumns+`
+		FROM guild_goal gg
+		INNER JOIN seed s ON s.id = gg.seed_id
+		WHERE gg.guild_id = $1 AND gg.week_start = $2
+	`, guildID, weekStart.Format("2006-01-02")))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return goal, nil
+}
+
+// CreateGoal выдает гильдии цель на неделю weekStart по шаблону.
+// Если цель на эту неделю уже выдана (например, параллельным запросом), ничего не делает.
+func (r *GuildRepo) CreateGoal(ctx context.Context, guildID int, weekStart time.Time, template model.GuildGoalTemplate) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO guild_goal (guild_id, template_id, week_start, title, seed_id, target, reward_gold, reward_xp, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		ON CONFLICT (guild_id, week_start) DO NOTHING
+	`, guildID, template.ID, weekStart.Format("2006-01-02"), template.Title, template.SeedID, template.Target,
+		template.RewardGold, template.RewardXP)
+	return err
+}
+
+// GetGuildsWithoutGoal возвращает ID гильдий, которым еще не выдана цель на неделю weekStart
+func (r *GuildRepo) GetGuildsWithoutGoal(ctx context.Context, weekStart time.Time) ([]int, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT g.id
+		FROM guild g
+		WHERE NOT EXISTS (SELECT 1 FROM guild_goal gg WHERE gg.guild_id = g.id AND gg.week_start = $1)
+		ORDER BY g.id
+	`, weekStart.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Contribute сдает урожай из амбара пользователя в цель его гильдии на неделю weekStart.
+// Вносить вклад могут только участники, вступившие в гильдию до выдачи цели. Принимается не больше,
+// чем осталось до цели. Если вклад завершает цель, в той же транзакции награду вместе с наградами
+// за достигнутые уровни получает каждый такой участник, внесший не меньше минимального вклада.
+func (r *GuildRepo) Contribute(ctx context.Context, userID int64, weekStart time.Time, amount int) (*model.GuildContribution, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var guildID int
+	err = tx.QueryRow(ctx, `SELECT guild_id FROM guild_member WHERE user_id = $1 FOR SHARE`, userID).Scan(&guildID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("user is not in a guild")
+		}
+		return nil, err
+	}
+
+	goal, err := scanGuildGoal(tx.QueryRow(ctx, `
+		SELECT `+guildGoalColumns+`
+		FROM guild_goal gg
+		INNER JOIN seed s ON s.id = gg.seed_id
+		WHERE gg.guild_id = $1 AND gg.week_start = $2
+		FOR UPDATE OF gg
+	`, guildID, weekStart.Format("2006-01-02")))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("guild goal for this week not found")
+		}
+		return nil, err
+	}
+	if goal.Completed {
+		return nil, fmt.Errorf("guild goal is already completed")
+	}
+
+	// Вступившие после выдачи цели вносят вклад со следующей недели: иначе можно было бы
+	// переходить из гильдии в гильдию и получать награды за чужие почти выполненные цели
+	var eligible bool
+	err = tx.QueryRow(ctx, `
+		SELECT m.joined_at <= gg.created_at
+		FROM guild_member m, guild_goal gg
+		WHERE m.user_id = $1 AND gg.id = $2
+	`, userID, goal.ID).Scan(&eligible)
+	if err != nil {
+		return nil, err
+	}
+	if !eligible {
+		return nil, fmt.Errorf("user joined the guild after this week's goal was set and can contribute from next week")
+	}
+
+	accepted := min(amount, goal.Target-goal.Progress)
+	if err := takeInventory(ctx, tx, userID, constants.ExchangeKindProduce, goal.SeedID, accepted); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO guild_contribution (goal_id, user_id, amount, created_at)
+		VALUES ($1, $2, $3, NOW())
+	`, goal.ID, userID, accepted)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	goal.Progress += accepted
+	if goal.Progress >= goal.Target {
+		goal.Completed = true
+		goal.CompletedAt = &now
+	}
+	_, err = tx.Exec(ctx, `UPDATE guild_goal SET progress = $1, completed_at = $2 WHERE id = $3`,
+		goal.Progress, goal.CompletedAt, goal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	contribution := &model.GuildContribution{Amount: accepted}
+	if goal.Completed {
+		contribution.Rewards, err = rewardGuildGoal(ctx, tx, guildID, goal)
+		if err != nil {
+			return nil, err
+		}
+	}
+	contribution.Goal = *goal
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return contribution, nil
+}
+
+// rewardGuildGoal выдает награду за выполненную цель каждому текущему участнику гильдии, который
+// вступил в нее до выдачи цели и внес не меньше GuildMinContributionPercent процентов цели
+func rewardGuildGoal(ctx context.Context, tx pgx.Tx, guildID int, goal *model.GuildGoal) ([]model.GuildReward, error) {
+	minContribution := utils.GuildMinContribution(goal.Target)
+	rows, err := tx.Query(ctx, `
+		SELECT c.user_id
+		FROM guild_contribution c
+		INNER JOIN guild_goal gg ON gg.id = c.goal_id
+		INNER JOIN guild_member m ON m.user_id = c.user_id AND m.guild_id = $2 AND m.joined_at <= gg.created_at
+		WHERE c.goal_id = $1
+		GROUP BY c.user_id
+		HAVING SUM(c.amount) >= $3
+		ORDER BY c.user_id
+	`, goal.ID, guildID, minContribution)
+	if err != nil {
+		return nil, err
+	}
+	contributors := []int64{}
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		contributors = append(contributors, userID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("Цель гильдии «%s»", goal.Title)
+	rewards := []model.GuildReward{}
+	for _, userID := range contributors {
+		reward := model.GuildReward{UserID: userID, Gold: goal.RewardGold, XP: goal.RewardXP}
+
+		var err error
+		reward.Experience, err = addExperience(ctx, tx, userID, int64(goal.RewardXP))
+		if err != nil {
+			return nil, err
+		}
+
+		if goal.RewardGold > 0 {
+			if _, err := changeGold(ctx, tx, userID, int64(goal.RewardGold), goldChangeAllowNegative,
+				constants.GoldSourceGuildGoal, description); err != nil {
+				return nil, err
+			}
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO progress_log (user_id, xp_earned, gold_earned, source, description, created_at)
+			VALUES ($1, $2, $3, $4, $5, NOW())
+		`, userID, goal.RewardXP, goal.RewardGold, constants.ProgressSourceGuildGoal, description)
+		if err != nil {
+			return nil, err
+		}
+
+		rewards = append(rewards, reward)
+	}
+	return rewards, nil
+}
+
+// lockGuildlessUser блокирует пользователя и проверяет, что он еще не состоит в гильдии
+func lockGuildlessUser(ctx context.Context, tx pgx.Tx, userID int64) error {
+	if _, err := tx.Exec(ctx, `SELECT id FROM user_info WHERE id = $1 FOR UPDATE`, userID); err != nil {
+		return err
+	}
+
+	var member bool
+	err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM guild_member WHERE user_id = $1)`, userID).Scan(&member)
+	if err != nil {
+		return err
+	}
+	if member {
+		return fmt.Errorf("user is already in a guild")
+	}
+	return nil
+}
@@ -31,38 +31,55 @@ func (r *HabitRepo) HasCompletedHabitToday(ctx context.Context, userID int64) (b
 	return exists, err
 }
 
+// Create создает привычку вместе с привязками к тегам в одной транзакции
 func (r *HabitRepo) Create(ctx context.Context, habit *model.Habit) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO habit (user_id, title, description, difficulty, tag_id, 
+		INSERT INTO habit (user_id, title, description, difficulty,
 		                  done, count, period, every, start_date, xp_reward, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id
 	`
-	return r.db.QueryRow(ctx, query,
-		habit.UserID, habit.Title, habit.Description, habit.Difficulty, habit.TagID,
+	err = tx.QueryRow(ctx, query,
+		habit.UserID, habit.Title, habit.Description, habit.Difficulty,
 		habit.Done, habit.Count, habit.Period, habit.Every, habit.StartDate,
 		habit.XPReward, habit.CreatedAt,
 	).Scan(&habit.ID)
+	if err != nil {
+		return err
+	}
+
+	habit.TagIDs = normalizeTagIDs(habit.TagIDs)
+	if err := replaceTags(ctx, tx, habitTagLink, habit.ID, habit.UserID, habit.TagIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	return r.attachTags(ctx, []*model.Habit{habit})
 }
 
 func (r *HabitRepo) GetByID(ctx context.Context, id int, userID int64) (*model.Habit, error) {
 	var habit model.Habit
-	var tagName, tagColor *string
 
 	query := `
-		SELECT h.id, h.user_id, h.title, h.description, h.difficulty, h.tag_id, 
-		       h.done, h.count, h.period, h.every, h.start_date, h.xp_reward, h.created_at,
-		       tag.name, tag.color
+		SELECT h.id, h.user_id, h.title, h.description, h.difficulty,
+		       h.done, h.count, h.period, h.every, h.start_date, h.xp_reward, h.created_at
 		FROM habit h
-		LEFT JOIN tag ON h.tag_id = tag.id
 		WHERE h.id = $1 AND h.user_id = $2
 	`
 
 	err := r.db.QueryRow(ctx, query, id, userID).Scan(
 		&habit.ID, &habit.UserID, &habit.Title, &habit.Description, &habit.Difficulty,
-		&habit.TagID, &habit.Done, &habit.Count, &habit.Period, &habit.Every,
+		&habit.Done, &habit.Count, &habit.Period, &habit.Every,
 		&habit.StartDate, &habit.XPReward, &habit.CreatedAt,
-		&tagName, &tagColor,
 	)
 
 	if err != nil {
@@ -72,13 +89,8 @@ func (r *HabitRepo) GetByID(ctx context.Context, id int, userID int64) (*model.H
 		return nil, err
 	}
 
-	// Если есть тег, заполняем структуру Tag
-	if tagName != nil && habit.TagID != nil {
-		habit.Tag = &model.Tag{
-			ID:    *habit.TagID,
-			Name:  *tagName,
-			Color: *tagColor,
-		}
+	if err := r.attachTags(ctx, []*model.Habit{&habit}); err != nil {
+		return nil, err
 	}
 
 	return &habit, nil
@@ -86,69 +98,53 @@ func (r *HabitRepo) GetByID(ctx context.Context, id int, userID int64) (*model.H
 
 func (r *HabitRepo) GetAll(ctx context.Context, userID int64) ([]model.Habit, error) {
 	query := `
-		SELECT h.id, h.user_id, h.title, h.description, h.difficulty, h.tag_id, 
-		       h.done, h.count, h.period, h.every, h.start_date, h.xp_reward, h.created_at,
-		       tag.name, tag.color
+		SELECT h.id, h.user_id, h.title, h.description, h.difficulty,
+		       h.done, h.count, h.period, h.every, h.start_date, h.xp_reward, h.created_at
 		FROM habit h
-		LEFT JOIN tag ON h.tag_id = tag.id
 		WHERE h.user_id = $1
 		ORDER BY h.created_at DESC
 	`
-	rows, err := r.db.Query(ctx, query, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	habits := []model.Habit{}
-	for rows.Next() {
-		var habit model.Habit
-		var tagName, tagColor *string
-
-		if err := rows.Scan(
-			&habit.ID, &habit.UserID, &habit.Title, &habit.Description, &habit.Difficulty,
-			&habit.TagID, &habit.Done, &habit.Count, &habit.Period, &habit.Every,
-			&habit.StartDate, &habit.XPReward, &habit.CreatedAt,
-			&tagName, &tagColor,
-		); err != nil {
-			return nil, err
-		}
-
-		// Если есть тег, заполняем структуру Tag
-		if tagName != nil && habit.TagID != nil {
-			habit.Tag = &model.Tag{
-				ID:    *habit.TagID,
-				Name:  *tagName,
-				Color: *tagColor,
-			}
-		}
-
-		habits = append(habits, habit)
-	}
-	return habits, nil
+	return r.queryHabits(ctx, query, userID)
 }
 
+// Update обновляет привычку и полностью заменяет набор ее тегов
 func (r *HabitRepo) Update(ctx context.Context, habit *model.Habit) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Отметка выполнения и счетчик меняются только выполнением и его отменой
 	query := `
 		UPDATE habit
-		SET title = $1, description = $2, difficulty = $3, tag_id = $4, 
-		    done = $5, count = $6, period = $7, every = $8, start_date = $9, xp_reward = $10
-		WHERE id = $11 AND user_id = $12
+		SET title = $1, description = $2, difficulty = $3,
+		    period = $4, every = $5, start_date = $6, xp_reward = $7
+		WHERE id = $8 AND user_id = $9
+		RETURNING done, count, created_at
 	`
-	result, err := r.db.Exec(ctx, query,
-		habit.Title, habit.Description, habit.Difficulty, habit.TagID,
-		habit.Done, habit.Count, habit.Period, habit.Every, habit.StartDate,
+	err = tx.QueryRow(ctx, query,
+		habit.Title, habit.Description, habit.Difficulty,
+		habit.Period, habit.Every, habit.StartDate,
 		habit.XPReward, habit.ID, habit.UserID,
-	)
+	).Scan(&habit.Done, &habit.Count, &habit.CreatedAt)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("habit with id=%d not found or does not belong to user", habit.ID)
+		}
 		return err
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("habit with id=%d not found or does not belong to user", habit.ID)
+	habit.TagIDs = normalizeTagIDs(habit.TagIDs)
+	if err := replaceTags(ctx, tx, habitTagLink, habit.ID, habit.UserID, habit.TagIDs); err != nil {
+		return err
 	}
-	return nil
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	return r.attachTags(ctx, []*model.Habit{habit})
 }
 
 func (r *HabitRepo) Delete(ctx context.Context, id int, userID int64) error {
@@ -167,45 +163,13 @@ func (r *HabitRepo) Delete(ctx context.Context, id int, userID int64) error {
 
 func (r *HabitRepo) GetByStatus(ctx context.Context, userID int64, done bool) ([]model.Habit, error) {
 	query := `
-		SELECT h.id, h.user_id, h.title, h.description, h.difficulty, h.tag_id, 
-		       h.done, h.count, h.period, h.every, h.start_date, h.xp_reward, h.created_at,
-		       tag.name, tag.color
+		SELECT h.id, h.user_id, h.title, h.description, h.difficulty,
+		       h.done, h.count, h.period, h.every, h.start_date, h.xp_reward, h.created_at
 		FROM habit h
-		LEFT JOIN tag ON h.tag_id = tag.id
 		WHERE h.user_id = $1 AND h.done = $2
 		ORDER BY h.created_at DESC
 	`
-	rows, err := r.db.Query(ctx, query, userID, done)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	habits := []model.Habit{}
-	for rows.Next() {
-		var habit model.Habit
-		var tagName, tagColor *string
-
-		if err := rows.Scan(
-			&habit.ID, &habit.UserID, &habit.Title, &habit.Description, &habit.Difficulty,
-			&habit.TagID, &habit.Done, &habit.Count, &habit.Period, &habit.Every,
-			&habit.StartDate, &habit.XPReward, &habit.CreatedAt,
-			&tagName, &tagColor,
-		); err != nil {
-			return nil, err
-		}
-
-		if tagName != nil && habit.TagID != nil {
-			habit.Tag = &model.Tag{
-				ID:    *habit.TagID,
-				Name:  *tagName,
-				Color: *tagColor,
-			}
-		}
-
-		habits = append(habits, habit)
-	}
-	return habits, nil
+	return r.queryHabits(ctx, query, userID, done)
 }
 
 func (r *HabitRepo) MarkAsDone(ctx context.Context, id int, userID int64) error {
@@ -236,118 +200,34 @@ func (r *HabitRepo) MarkAsUndone(ctx context.Context, id int, userID int64) erro
 	return nil
 }
 
-func (r *HabitRepo) IncrementCount(ctx context.Context, id int, userID int64) error {
-	query := `UPDATE habit SET count = count + 1 WHERE id = $1 AND user_id = $2`
-	result, err := r.db.Exec(ctx, query, id, userID)
-	if err != nil {
-		return err
-	}
-
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("habit with id=%d not found or does not belong to user", id)
-	}
-	return nil
-}
-
-func (r *HabitRepo) ResetCount(ctx context.Context, id int, userID int64) error {
-	query := `UPDATE habit SET count = 0 WHERE id = $1 AND user_id = $2`
-	result, err := r.db.Exec(ctx, query, id, userID)
-	if err != nil {
-		return err
-	}
-
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("habit with id=%d not found or does not belong to user", id)
-	}
-	return nil
-}
-
 func (r *HabitRepo) GetByPeriod(ctx context.Context, userID int64, period string) ([]model.Habit, error) {
 	query := `
-		SELECT h.id, h.user_id, h.title, h.description, h.difficulty, h.tag_id, 
-		       h.done, h.count, h.period, h.every, h.start_date, h.xp_reward, h.created_at,
-		       tag.name, tag.color
+		SELECT h.id, h.user_id, h.title, h.description, h.difficulty,
+		       h.done, h.count, h.period, h.every, h.start_date, h.xp_reward, h.created_at
 		FROM habit h
-		LEFT JOIN tag ON h.tag_id = tag.id
 		WHERE h.user_id = $1 AND h.period = $2
 		ORDER BY h.created_at DESC
 	`
-	rows, err := r.db.Query(ctx, query, userID, period)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	habits := []model.Habit{}
-	for rows.Next() {
-		var habit model.Habit
-		var tagName, tagColor *string
-
-		if err := rows.Scan(
-			&habit.ID, &habit.UserID, &habit.Title, &habit.Description, &habit.Difficulty,
-			&habit.TagID, &habit.Done, &habit.Count, &habit.Period, &habit.Every,
-			&habit.StartDate, &habit.XPReward, &habit.CreatedAt,
-			&tagName, &tagColor,
-		); err != nil {
-			return nil, err
-		}
-
-		if tagName != nil && habit.TagID != nil {
-			habit.Tag = &model.Tag{
-				ID:    *habit.TagID,
-				Name:  *tagName,
-				Color: *tagColor,
-			}
-		}
+	return r.queryHabits(ctx, query, userID, period)
+}
 
-		habits = append(habits, habit)
+// GetByTags возвращает привычки с указанными тегами.
+// matchAll = false — привычка содержит хотя бы один из тегов (any-of),
+// matchAll = true — привычка содержит все указанные теги (all-of).
+func (r *HabitRepo) GetByTags(ctx context.Context, userID int64, tagIDs []int, matchAll bool) ([]model.Habit, error) {
+	tagIDs = normalizeTagIDs(tagIDs)
+	if len(tagIDs) == 0 {
+		return []model.Habit{}, nil
 	}
-	return habits, nil
-}
 
-func (r *HabitRepo) GetByTag(ctx context.Context, userID int64, tagID int) ([]model.Habit, error) {
 	query := `
-		SELECT h.id, h.user_id, h.title, h.description, h.difficulty, h.tag_id, 
-		       h.done, h.count, h.period, h.every, h.start_date, h.xp_reward, h.created_at,
-		       tag.name, tag.color
+		SELECT h.id, h.user_id, h.title, h.description, h.difficulty,
+		       h.done, h.count, h.period, h.every, h.start_date, h.xp_reward, h.created_at
 		FROM habit h
-		LEFT JOIN tag ON h.tag_id = tag.id
-		WHERE h.user_id = $1 AND h.tag_id = $2
+		WHERE h.user_id = $1 AND ` + tagFilterCondition(habitTagLink, "h.id", matchAll) + `
 		ORDER BY h.created_at DESC
 	`
-	rows, err := r.db.Query(ctx, query, userID, tagID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	habits := []model.Habit{}
-	for rows.Next() {
-		var habit model.Habit
-		var tagName, tagColor *string
-
-		if err := rows.Scan(
-			&habit.ID, &habit.UserID, &habit.Title, &habit.Description, &habit.Difficulty,
-			&habit.TagID, &habit.Done, &habit.Count, &habit.Period, &habit.Every,
-			&habit.StartDate, &habit.XPReward, &habit.CreatedAt,
-			&tagName, &tagColor,
-		); err != nil {
-			return nil, err
-		}
-
-		if tagName != nil && habit.TagID != nil {
-			habit.Tag = &model.Tag{
-				ID:    *habit.TagID,
-				Name:  *tagName,
-				Color: *tagColor,
-			}
-		}
-
-		habits = append(habits, habit)
-	}
-	return habits, nil
+	return r.queryHabits(ctx, query, userID, tagIDs)
 }
 
 func (r *HabitRepo) GetAllUsersWithHabits(ctx context.Context) ([]model.User, error) {
@@ -383,38 +263,72 @@ func (r *HabitRepo) GetAllUsersWithHabits(ctx context.Context) ([]model.User, er
 	return users, nil
 }
 
-// MarkAsDoneAndIncrementCount помечает привычку как выполненную и увеличивает счетчик
-func (r *HabitRepo) MarkAsDoneAndIncrementCount(ctx context.Context, id int, userID int64) error {
-	query := `UPDATE habit SET done = true, count = count + 1 WHERE id = $1 AND user_id = $2`
-	result, err := r.db.Exec(ctx, query, id, userID)
+// ResetTag отвязывает тег от всех привычек пользователя
+func (r *HabitRepo) ResetTag(ctx context.Context, userID int64, tagID int) error {
+	query := `
+		DELETE FROM habit_tag ht
+		USING habit h
+		WHERE ht.habit_id = h.id AND h.user_id = $1 AND ht.tag_id = $2
+	`
+	_, err := r.db.Exec(ctx, query, userID, tagID)
+	return err
+}
+
+// queryHabits выполняет запрос, сканирует привычки и подгружает их теги
+func (r *HabitRepo) queryHabits(ctx context.Context, query string, args ...interface{}) ([]model.Habit, error) {
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("habit with id=%d not found or does not belong to user", id)
+	habits := []model.Habit{}
+	for rows.Next() {
+		var habit model.Habit
+		if err := rows.Scan(
+			&habit.ID, &habit.UserID, &habit.Title, &habit.Description, &habit.Difficulty,
+			&habit.Done, &habit.Count, &habit.Period, &habit.Every,
+			&habit.StartDate, &habit.XPReward, &habit.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		habits = append(habits, habit)
 	}
-	return nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*model.Habit, len(habits))
+	for i := range habits {
+		ptrs[i] = &habits[i]
+	}
+	if err := r.attachTags(ctx, ptrs); err != nil {
+		return nil, err
+	}
+	return habits, nil
 }
 
-// MarkAsUndoneAndDecrementCount помечает привычку как невыполненную и уменьшает счетчик
-func (r *HabitRepo) MarkAsUndoneAndDecrementCount(ctx context.Context, id int, userID int64) error {
-	query := `UPDATE habit SET done = false, count = GREATEST(0, count - 1) WHERE id = $1 AND user_id = $2`
-	result, err := r.db.Exec(ctx, query, id, userID)
+// attachTags заполняет TagIDs и Tags у переданных привычек
+func (r *HabitRepo) attachTags(ctx context.Context, habits []*model.Habit) error {
+	ids := make([]int, len(habits))
+	for i, habit := range habits {
+		ids[i] = habit.ID
+	}
+
+	tagsByHabit, err := loadTags(ctx, r.db, habitTagLink, ids)
 	if err != nil {
 		return err
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("habit with id=%d not found or does not belong to user", id)
+	for _, habit := range habits {
+		habit.Tags = tagsByHabit[habit.ID]
+		if habit.Tags == nil {
+			habit.Tags = []model.Tag{}
+		}
+		habit.TagIDs = make([]int, len(habit.Tags))
+		for i, tag := range habit.Tags {
+			habit.TagIDs[i] = tag.ID
+		}
 	}
 	return nil
 }
-
-func (r *HabitRepo) ResetTag(ctx context.Context, userID int64, tagID int) error {
-	query := `UPDATE habit SET tag_id = NULL WHERE user_id = $1 AND tag_id = $2`
-	_, err := r.db.Exec(ctx, query, userID, tagID)
-	return err
-}
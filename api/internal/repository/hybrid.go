@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HybridRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewHybridRepo(db *pgxpool.Pool) *HybridRepo {
+	return &HybridRepo{db: db}
+}
+
+// hybridSeedColumns — колонки гибридного семени s в порядке scanHybridSeed
+const hybridSeedColumns = `s.id, s.name, s.icon, s.img_plant, s.level_required, s.target_growth, s.rarity,
+	s.modification, s.gold_reward, s.xp_reward, s.is_hybrid, s.stage_thresholds, s.created_at`
+
+func hybridSeedDest(seed *model.Seed) []any {
+	return []any{
+		&seed.ID, &seed.Name, &seed.Icon, &seed.ImgPlant, &seed.LevelRequired, &seed.TargetGrowth, &seed.Rarity,
+		&seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.IsHybrid, &seed.StageThresholds, &seed.CreatedAt,
+	}
+}
+
+// GetRecipes возвращает все рецепты скрещивания
+func (r *HybridRepo) GetRecipes(ctx context.Context) ([]model.SeedRecipe, error) {
+	query := `
+		SELECT id, parent_a_seed_id, parent_b_seed_id, result_seed_id, chance::float8, created_at
+		FROM seed_recipe
+		ORDER BY id
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recipes := []model.SeedRecipe{}
+	for rows.Next() {
+		var recipe model.SeedRecipe
+		if err := rows.Scan(
+			&recipe.ID, &recipe.ParentASeedID, &recipe.ParentBSeedID, &recipe.ResultSeedID, &recipe.Chance, &recipe.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		recipes = append(recipes, recipe)
+	}
+	return recipes, rows.Err()
+}
+
+// GetRecipeInfos возвращает рецепты скрещивания с отметкой, открыл ли их пользователь.
+// Результат рецепта заполняется только для открытых гибридов.
+func (r *HybridRepo) GetRecipeInfos(ctx context.Context, userID int64) ([]model.HybridRecipeInfo, error) {
+	query := `
+		SELECT sr.id, sr.parent_a_seed_id, pa.name, sr.parent_b_seed_id, pb.name, sr.chance::float8,
+		       uh.user_id IS NOT NULL, ` + hybridSeedColumns + `
+		FROM seed_recipe sr
+		INNER JOIN seed pa ON pa.id = sr.parent_a_seed_id
+		INNER JOIN seed pb ON pb.id = sr.parent_b_seed_id
+		INNER JOIN seed s ON s.id = sr.result_seed_id
+		LEFT JOIN user_hybrid uh ON uh.recipe_id = sr.id AND uh.user_id = $1
+		ORDER BY sr.id
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	infos := []model.HybridRecipeInfo{}
+	for rows.Next() {
+		var info model.HybridRecipeInfo
+		var seed model.Seed
+		dest := append([]any{
+			&info.ID, &info.ParentASeedID, &info.ParentASeedName, &info.ParentBSeedID, &info.ParentBSeedName, &info.Chance,
+			&info.Discovered,
+		}, hybridSeedDest(&seed)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		if info.Discovered {
+			info.ResultSeed = &seed
+		}
+		infos = append(infos, info)
+	}
+	return infos, rows.Err()
+}
+
+// GetDiscoveries возвращает гибриды, открытые пользователем, начиная с последних
+func (r *HybridRepo) GetDiscoveries(ctx context.Context, userID int64) ([]model.HybridDiscovery, error) {
+	query := `
+		SELECT sr.id, sr.parent_a_seed_id, sr.parent_b_seed_id, uh.times_bred, uh.discovered_at, uh.last_bred_at,
+		       ` + hybridSeedColumns + `
+		FROM user_hybrid uh
+		INNER JOIN seed_recipe sr ON sr.id = uh.recipe_id
+		INNER JOIN seed s ON s.id = sr.result_seed_id
+		WHERE uh.user_id = $1
+		ORDER BY uh.discovered_at DESC, sr.id DESC
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	discoveries := []model.HybridDiscovery{}
+	for rows.Next() {
+		var d model.HybridDiscovery
+		dest := append([]any{
+			&d.RecipeID, &d.ParentASeedID, &d.ParentBSeedID, &d.TimesBred, &d.DiscoveredAt, &d.LastBredAt,
+		}, hybridSeedDest(&d.Seed)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		discoveries = append(discoveries, d)
+	}
+	return discoveries, rows.Err()
+}
+
+// recordBreed выдает пользователю гибридное семя по рецепту и отмечает гибрид открытым.
+// Возвращает true, если гибрид открыт впервые.
+func recordBreed(ctx context.Context, q DBTX, userID int64, recipeID int, resultSeedID int) (bool, error) {
+	_, err := q.Exec(ctx, `
+		INSERT INTO user_seed (user_id, seed_id, quantity, created_at)
+		VALUES ($1, $2, 1, NOW())
+		ON CONFLICT (user_id, seed_id)
+		DO UPDATE SET quantity = user_seed.quantity + 1
+	`, userID, resultSeedID)
+	if err != nil {
+		return false, err
+	}
+
+	var discovered bool
+	err = q.QueryRow(ctx, `
+		INSERT INTO user_hybrid (user_id, recipe_id, times_bred, discovered_at, last_bred_at)
+		VALUES ($1, $2, 1, NOW(), NOW())
+		ON CONFLICT (user_id, recipe_id)
+		DO UPDATE SET times_bred = user_hybrid.times_bred + 1, last_bred_at = NOW()
+		RETURNING xmax = 0
+	`, userID, recipeID).Scan(&discovered)
+	return discovered, err
+}
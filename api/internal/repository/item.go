@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ItemRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewItemRepo(db *pgxpool.Pool) *ItemRepo {
+	return &ItemRepo{db: db}
+}
+
+const itemColumns = `id, code, name, description, icon, type, effect_type, effect_value, duration_hours, max_stacks, created_at`
+
+func scanItem(row pgx.Row) (*model.Item, error) {
+	var item model.Item
+	if err := row.Scan(
+		&item.ID, &item.Code, &item.Name, &item.Description, &item.Icon, &item.Type,
+		&item.EffectType, &item.EffectValue, &item.DurationHours, &item.MaxStacks, &item.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *ItemRepo) GetAll(ctx context.Context) ([]model.Item, error) {
+	query := `SELECT ` + itemColumns + ` FROM item ORDER BY id`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []model.Item{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	return items, rows.Err()
+}
+
+func (r *ItemRepo) GetByID(ctx context.Context, id int) (*model.Item, error) {
+	query := `SELECT ` + itemColumns + ` FROM item WHERE id = $1`
+	item, err := scanItem(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("item with id=%d not found", id)
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+func (r *ItemRepo) GetByCode(ctx context.Context, code string) (*model.Item, error) {
+	query := `SELECT ` + itemColumns + ` FROM item WHERE code = $1`
+	item, err := scanItem(r.db.QueryRow(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("item with code=%s not found", code)
+		}
+		return nil, err
+	}
+	return item, nil
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LeaderboardRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewLeaderboardRepo(db *pgxpool.Pool) *LeaderboardRepo {
+	return &LeaderboardRepo{db: db}
+}
+
+// leaderboardValues — значения метрик активных пользователей за сезон [$3, $4): опыт из progress_log,
+// самая длинная серия дней подряд с неотмененными выполнениями задач и привычек внутри сезона
+// и количество растений, собранных за сезон, из plant_history
+var leaderboardValues = map[string]string{
+	constants.LeaderboardWeeklyXP: `
+		SELECT pl.user_id, SUM(pl.xp_earned) AS value
+		FROM progress_log pl
+		INNER JOIN user_info u ON u.id = pl.user_id AND u.is_active = true
+		WHERE pl.created_at >= $3 AND pl.created_at < $4
+		GROUP BY pl.user_id
+	`,
+	constants.LeaderboardLongestStreak: `
+		SELECT runs.user_id, MAX(runs.days) AS value
+		FROM (
+			SELECT d.user_id, COUNT(*) AS days
+			FROM (
+				SELECT a.user_id, a.day, a.day - (ROW_NUMBER() OVER (PARTITION BY a.user_id ORDER BY a.day))::int AS run
+				FROM (
+					SELECT DISTINCT pl.user_id, DATE(pl.created_at) AS day
+					FROM progress_log pl
+					INNER JOIN user_info u ON u.id = pl.user_id AND u.is_active = true
+					WHERE pl.created_at >= $3 AND pl.created_at < $4
+					  AND pl.source IN ('task', 'habit')
+					  AND pl.xp_earned > 0 AND pl.undone_at IS NULL
+				) a
+			) d
+			GROUP BY d.user_id, d.run
+		) runs
+		GROUP BY runs.user_id
+	`,
+	constants.LeaderboardTotalHarvests: `
+		SELECT ph.user_id, COUNT(*) AS value
+		FROM plant_history ph
+		INNER JOIN user_info u ON u.id = ph.user_id AND u.is_active = true
+		WHERE ph.event = 'harvested' AND ph.created_at >= $3 AND ph.created_at < $4
+		GROUP BY ph.user_id
+	`,
+}
+
+// Refresh пересчитывает снимки всех рейтингов сезона, начавшегося в seasonStart, в одной транзакции.
+// В рейтинг попадают пользователи с ненулевым значением метрики; при равных значениях места совпадают.
+// final отмечает итоговый пересчет уже закончившегося сезона.
+func (r *LeaderboardRepo) Refresh(ctx context.Context, seasonStart time.Time, final bool) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	season := seasonStart.Format("2006-01-02")
+	_, err = tx.Exec(ctx, `
+		INSERT INTO leaderboard_season (season_start, computed_at, finalized_at)
+		VALUES ($1, NOW(), CASE WHEN $2 THEN NOW() END)
+		ON CONFLICT (season_start)
+		DO UPDATE SET computed_at = EXCLUDED.computed_at, finalized_at = EXCLUDED.finalized_at
+	`, season, final)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM leaderboard_entry WHERE season_start = $1`, season); err != nil {
+		return err
+	}
+
+	for _, metric := range constants.ValidLeaderboardMetrics {
+		query := `
+			INSERT INTO leaderboard_entry (season_start, metric, user_id, value, rank)
+			SELECT $1, $2, v.user_id, v.value, RANK() OVER (ORDER BY v.value DESC)
+			FROM (` + leaderboardValues[metric] + `) v
+			WHERE v.value > 0
+		`
+		if _, err := tx.Exec(ctx, query, season, metric, seasonStart, utils.SeasonEnd(seasonStart)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// IsFinalized проверяет, выполнен ли итоговый пересчет сезона, начавшегося в seasonStart
+func (r *LeaderboardRepo) IsFinalized(ctx context.Context, seasonStart time.Time) (bool, error) {
+	var finalized bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM leaderboard_season WHERE season_start = $1 AND finalized_at IS NOT NULL)
+	`, seasonStart.Format("2006-01-02")).Scan(&finalized)
+	return finalized, err
+}
+
+// GetComputedAt возвращает время последнего снимка сезона или nil, если сезон еще не посчитан
+func (r *LeaderboardRepo) GetComputedAt(ctx context.Context, seasonStart time.Time) (*time.Time, error) {
+	var computedAt time.Time
+	err := r.db.QueryRow(ctx, `
+		SELECT computed_at FROM leaderboard_season WHERE season_start = $1
+	`, seasonStart.Format("2006-01-02")).Scan(&computedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &computedAt, nil
+}
+
+// GetGlobal возвращает первые limit мест глобального рейтинга и место пользователя userID.
+// Место пользователя возвращается отдельно (nil, если его нет в рейтинге).
+func (r *LeaderboardRepo) GetGlobal(ctx context.Context, seasonStart time.Time, metric string, userID int64, limit int) ([]model.LeaderboardEntry, *model.LeaderboardEntry, error) {
+	query := `
+		SELECT le.rank, le.user_id, u.username, le.value
+		FROM leaderboard_entry le
+		INNER JOIN user_info u ON u.id = le.user_id
+		WHERE le.season_start = $1 AND le.metric = $2 AND (le.rank <= $4 OR le.user_id = $3)
+		ORDER BY le.rank, le.user_id
+	`
+	rows, err := r.db.Query(ctx, query, seasonStart.Format("2006-01-02"), metric, userID, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return collectLeaderboard(rows, userID, limit)
+}
+
+// GetFriends возвращает рейтинг среди друзей пользователя userID и его самого: места пересчитываются
+// внутри этого круга по глобальному снимку. Место пользователя возвращается отдельно.
+func (r *LeaderboardRepo) GetFriends(ctx context.Context, seasonStart time.Time, metric string, userID int64, limit int) ([]model.LeaderboardEntry, *model.LeaderboardEntry, error) {
+	query := `
+		WITH circle AS (
+			SELECT $3::bigint AS user_id
+			UNION
+			SELECT CASE WHEN f.requester_id = $3 THEN f.addressee_id ELSE f.requester_id END
+			FROM friendship f
+			WHERE f.status = $5 AND (f.requester_id = $3 OR f.addressee_id = $3)
+		),
+		ranked AS (
+			SELECT RANK() OVER (ORDER BY le.value DESC) AS rank, le.user_id, le.value
+			FROM leaderboard_entry le
+			INNER JOIN circle c ON c.user_id = le.user_id
+			WHERE le.season_start = $1 AND le.metric = $2
+		)
+		SELECT r.rank, r.user_id, u.username, r.value
+		FROM ranked r
+		INNER JOIN user_info u ON u.id = r.user_id
+		WHERE r.rank <= $4 OR r.user_id = $3
+		ORDER BY r.rank, r.user_id
+	`
+	rows, err := r.db.Query(ctx, query, seasonStart.Format("2006-01-02"), metric, userID, limit, constants.FriendshipAccepted)
+	if err != nil {
+		return nil, nil, err
+	}
+	return collectLeaderboard(rows, userID, limit)
+}
+
+// collectLeaderboard разделяет строки рейтинга на первые limit мест и место пользователя userID
+func collectLeaderboard(rows pgx.Rows, userID int64, limit int) ([]model.LeaderboardEntry, *model.LeaderboardEntry, error) {
+	defer rows.Close()
+
+	entries := []model.LeaderboardEntry{}
+	var me *model.LeaderboardEntry
+	for rows.Next() {
+		var entry model.LeaderboardEntry
+		if err := rows.Scan(&entry.Rank, &entry.UserID, &entry.Username, &entry.Value); err != nil {
+			return nil, nil, err
+		}
+		if entry.UserID == userID {
+			me = &entry
+		}
+		if entry.Rank <= limit {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, me, rows.Err()
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LevelRewardRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewLevelRewardRepo(db *pgxpool.Pool) *LevelRewardRepo {
+	return &LevelRewardRepo{db: db}
+}
+
+// GetByLevel возвращает награды, настроенные для уровня
+func (r *LevelRewardRepo) GetByLevel(ctx context.Context, level int) ([]model.LevelReward, error) {
+	return getLevelRewards(ctx, r.db, level)
+}
+
+func getLevelRewards(ctx context.Context, q DBTX, level int) ([]model.LevelReward, error) {
+	query := `
+		SELECT lr.id, lr.level, lr.reward_type, lr.seed_id, s.name, lr.item_id, i.name, lr.amount
+		FROM level_reward lr
+		LEFT JOIN seed s ON s.id = lr.seed_id
+		LEFT JOIN item i ON i.id = lr.item_id
+		WHERE lr.level = $1
+		ORDER BY lr.id
+	`
+	rows, err := q.Query(ctx, query, level)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rewards := []model.LevelReward{}
+	for rows.Next() {
+		var reward model.LevelReward
+		if err := rows.Scan(
+			&reward.ID, &reward.Level, &reward.RewardType, &reward.SeedID, &reward.SeedName,
+			&reward.ItemID, &reward.ItemName, &reward.Amount,
+		); err != nil {
+			return nil, err
+		}
+		rewards = append(rewards, reward)
+	}
+	return rewards, rows.Err()
+}
+
+// AddExperience начисляет опыт и выдает награды за каждый достигнутый уровень в одной транзакции,
+// чтобы опыт не мог сохраниться без наград за уровень
+func (r *LevelRewardRepo) AddExperience(ctx context.Context, userID int64, amount int64) (*model.ExperienceChange, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	change, err := addExperience(ctx, tx, userID, amount)
+	if err != nil {
+		return nil, err
+	}
+	return change, tx.Commit(ctx)
+}
+
+// addExperience начисляет опыт в транзакции tx и выдает награды за достигнутые уровни
+func addExperience(ctx context.Context, tx pgx.Tx, userID int64, amount int64) (*model.ExperienceChange, error) {
+	change := &model.ExperienceChange{}
+	err := tx.QueryRow(ctx, `
+		UPDATE user_stat SET experience = experience + $1, updated_at = NOW() WHERE user_id = $2 RETURNING experience
+	`, amount, userID).Scan(&change.After)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("user stat not found for user_id=%d", userID)
+		}
+		return nil, err
+	}
+	change.Before = change.After - amount
+
+	change.Rewards, err = grantLevelUp(ctx, tx, userID, change.Before, change.After)
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// grantLevelUp выдает в транзакции tx награды за каждый уровень между oldExperience и newExperience
+func grantLevelUp(ctx context.Context, tx pgx.Tx, userID int64, oldExperience, newExperience int64) ([]model.LevelReward, error) {
+	rewards := []model.LevelReward{}
+	oldLevel := utils.CalculateLevel(oldExperience)
+	newLevel := utils.CalculateLevel(newExperience)
+	for level := oldLevel + 1; level <= newLevel; level++ {
+		granted, err := grantLevel(ctx, tx, userID, level)
+		if err != nil {
+			return nil, err
+		}
+		rewards = append(rewards, granted...)
+	}
+	return rewards, nil
+}
+
+// grantLevel выдает награды за уровень в транзакции tx; повторная выдача исключена
+func grantLevel(ctx context.Context, tx pgx.Tx, userID int64, level int) ([]model.LevelReward, error) {
+	claim, err := tx.Exec(ctx, `
+		INSERT INTO user_level_reward (user_id, level, granted_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id, level) DO NOTHING
+	`, userID, level)
+	if err != nil {
+		return nil, err
+	}
+	if claim.RowsAffected() == 0 {
+		return []model.LevelReward{}, nil
+	}
+
+	rewards, err := getLevelRewards(ctx, tx, level)
+	if err != nil {
+		return nil, err
+	}
+
+	granted := []model.LevelReward{}
+	descriptions := []string{}
+	totalGold := 0
+
+	for _, reward := range rewards {
+		applied, err := applyLevelReward(ctx, tx, userID, reward)
+		if err != nil {
+			return nil, fmt.Errorf("failed to grant level reward %d: %w", reward.ID, err)
+		}
+		if !applied {
+			continue
+		}
+		if reward.RewardType == constants.LevelRewardGold {
+			totalGold += reward.Amount
+		}
+		granted = append(granted, reward)
+		descriptions = append(descriptions, describeLevelReward(reward))
+	}
+
+	if len(granted) > 0 {
+		description := fmt.Sprintf("Награда за %d уровень: %s", level, strings.Join(descriptions, ", "))
+		_, err = tx.Exec(ctx, `
+			INSERT INTO progress_log (user_id, xp_earned, gold_earned, source, description, created_at)
+			VALUES ($1, 0, $2, $3, $4, $5)
+		`, userID, totalGold, constants.ProgressSourceLevelReward, description, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return granted, nil
+}
+
+// applyLevelReward применяет одну награду. Возвращает false, если награду выдать нечего
+// (например, у пользователя не осталось закрытых грядок).
+func applyLevelReward(ctx context.Context, tx pgx.Tx, userID int64, reward model.LevelReward) (bool, error) {
+	switch reward.RewardType {
+	case constants.LevelRewardGold:
+		_, err := changeGold(ctx, tx, userID, int64(reward.Amount), goldChangeAllowNegative,
+			constants.GoldSourceLevelReward, fmt.Sprintf("Награда за %d уровень", reward.Level))
+		return err == nil, err
+
+	case constants.LevelRewardSeed:
+		_, err := tx.Exec(ctx, `
+			INSERT INTO user_seed (user_id, seed_id, quantity, created_at)
+			VALUES ($1, $2, $3, NOW())
+			ON CONFLICT (user_id, seed_id)
+			DO UPDATE SET quantity = user_seed.quantity + EXCLUDED.quantity
+		`, userID, *reward.SeedID, reward.Amount)
+		return err == nil, err
+
+	case constants.LevelRewardBed:
+		result, err := tx.Exec(ctx, `
+			UPDATE bed SET is_locked = false
+			WHERE id IN (
+				SELECT id FROM bed
+				WHERE user_id = $1 AND is_locked = true
+				ORDER BY y, x
+				LIMIT $2
+			)
+		`, userID, reward.Amount)
+		if err != nil {
+			return false, err
+		}
+		return result.RowsAffected() > 0, nil
+
+	case constants.LevelRewardItem:
+		_, err := addUserItem(ctx, tx, userID, *reward.ItemID, reward.Amount)
+		return err == nil, err
+
+	default:
+		return false, fmt.Errorf("unknown level reward type: %s", reward.RewardType)
+	}
+}
+
+// describeLevelReward формирует описание награды для лога прогресса
+func describeLevelReward(reward model.LevelReward) string {
+	switch reward.RewardType {
+	case constants.LevelRewardGold:
+		return fmt.Sprintf("%d золота", reward.Amount)
+	case constants.LevelRewardSeed:
+		name := "семена"
+		if reward.SeedName != nil {
+			name = *reward.SeedName
+		}
+		return fmt.Sprintf("семена «%s» x%d", name, reward.Amount)
+	case constants.LevelRewardBed:
+		return fmt.Sprintf("грядки x%d", reward.Amount)
+	case constants.LevelRewardItem:
+		name := "предмет"
+		if reward.ItemName != nil {
+			name = *reward.ItemName
+		}
+		return fmt.Sprintf("«%s» x%d", name, reward.Amount)
+	default:
+		return reward.RewardType
+	}
+}
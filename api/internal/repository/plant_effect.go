@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PlantEffectRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPlantEffectRepo(db *pgxpool.Pool) *PlantEffectRepo {
+	return &PlantEffectRepo{db: db}
+}
+
+// activePlantEffectCondition отбирает эффекты, срок действия которых не истек
+const activePlantEffectCondition = `(pe.expires_at IS NULL OR pe.expires_at > NOW())`
+
+// GetActiveByPlant возвращает действующие эффекты растения
+func (r *PlantEffectRepo) GetActiveByPlant(ctx context.Context, plantID int) ([]model.PlantEffect, error) {
+	query := `
+		SELECT pe.id, pe.user_id, pe.user_plant_id, pe.item_id, i.code, i.name, pe.effect_type, pe.value,
+		       pe.expires_at, pe.created_at
+		FROM plant_effect pe
+		INNER JOIN item i ON i.id = pe.item_id
+		WHERE pe.user_plant_id = $1 AND ` + activePlantEffectCondition + `
+		ORDER BY pe.created_at
+	`
+	rows, err := r.db.Query(ctx, query, plantID)
+	if err != nil {
+		return nil, err
+	}
+	return scanPlantEffects(rows)
+}
+
+// GetActiveByUser возвращает действующие эффекты всех растений пользователя, сгруппированные по растению
+func (r *PlantEffectRepo) GetActiveByUser(ctx context.Context, userID int64) (map[int][]model.PlantEffect, error) {
+	query := `
+		SELECT pe.id, pe.user_id, pe.user_plant_id, pe.item_id, i.code, i.name, pe.effect_type, pe.value,
+		       pe.expires_at, pe.created_at
+		FROM plant_effect pe
+		INNER JOIN item i ON i.id = pe.item_id
+		WHERE pe.user_id = $1 AND ` + activePlantEffectCondition + `
+		ORDER BY pe.created_at
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	effects, err := scanPlantEffects(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	byPlant := make(map[int][]model.PlantEffect)
+	for _, effect := range effects {
+		byPlant[effect.UserPlantID] = append(byPlant[effect.UserPlantID], effect)
+	}
+	return byPlant, nil
+}
+
+// ApplyInstantGrowth списывает предмет и сразу добавляет растению item.EffectValue очков роста.
+// Возвращает новый рост растения и оставшееся количество предмета.
+func (r *PlantEffectRepo) ApplyInstantGrowth(ctx context.Context, userID int64, plantID int, item *model.Item) (int, int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var newGrowth int
+	err = tx.QueryRow(ctx, `
+		UPDATE user_plant
+		SET current_growth = current_growth + ROUND($1::numeric)::int
+		WHERE id = $2 AND user_id = $3 AND is_withered = false
+		RETURNING current_growth
+	`, item.EffectValue, plantID, userID).Scan(&newGrowth)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, 0, fmt.Errorf("growing plant with id=%d not found", plantID)
+		}
+		return 0, 0, err
+	}
+
+	remaining, err := consumeUserItem(ctx, tx, userID, item.Code, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, err
+	}
+	return newGrowth, remaining, nil
+}
+
+// AddEffect списывает предмет и добавляет растению эффект с учетом ограничения стаков.
+// Эффекты одного предмета складываются; срок действия отсчитывается от момента применения.
+// Возвращает добавленный эффект и оставшееся количество предмета.
+func (r *PlantEffectRepo) AddEffect(ctx context.Context, userID int64, plantID int, item *model.Item) (*model.PlantEffect, int, error) {
+	if item.EffectType == nil {
+		return nil, 0, fmt.Errorf("item %s has no effect", item.Code)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Блокируем растение, чтобы параллельные применения не превысили лимит стаков
+	var lockedID int
+	err = tx.QueryRow(ctx, `
+		SELECT id FROM user_plant
+		WHERE id = $1 AND user_id = $2 AND is_withered = false
+		FOR UPDATE
+	`, plantID, userID).Scan(&lockedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, fmt.Errorf("growing plant with id=%d not found", plantID)
+		}
+		return nil, 0, err
+	}
+
+	if item.MaxStacks > 0 {
+		var stacks int
+		err = tx.QueryRow(ctx, `
+			SELECT COUNT(*) FROM plant_effect pe
+			WHERE pe.user_plant_id = $1 AND pe.item_id = $2 AND `+activePlantEffectCondition,
+			plantID, item.ID).Scan(&stacks)
+		if err != nil {
+			return nil, 0, err
+		}
+		if stacks >= item.MaxStacks {
+			return nil, 0, fmt.Errorf("maximum stacks reached for %s (%d)", item.Code, item.MaxStacks)
+		}
+	}
+
+	remaining, err := consumeUserItem(ctx, tx, userID, item.Code, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	effect := &model.PlantEffect{
+		UserID:      userID,
+		UserPlantID: plantID,
+		ItemID:      item.ID,
+		ItemCode:    item.Code,
+		ItemName:    item.Name,
+		EffectType:  *item.EffectType,
+		Value:       item.EffectValue,
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO plant_effect (user_id, user_plant_id, item_id, effect_type, value, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5,
+		        CASE WHEN $6::int IS NULL THEN NULL ELSE NOW() + make_interval(hours => $6::int) END,
+		        NOW())
+		RETURNING id, expires_at, created_at
+	`, userID, plantID, item.ID, effect.EffectType, effect.Value, item.DurationHours,
+	).Scan(&effect.ID, &effect.ExpiresAt, &effect.CreatedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, 0, err
+	}
+	return effect, remaining, nil
+}
+
+func scanPlantEffects(rows pgx.Rows) ([]model.PlantEffect, error) {
+	defer rows.Close()
+
+	effects := []model.PlantEffect{}
+	for rows.Next() {
+		var effect model.PlantEffect
+		if err := rows.Scan(
+			&effect.ID, &effect.UserID, &effect.UserPlantID, &effect.ItemID, &effect.ItemCode, &effect.ItemName,
+			&effect.EffectType, &effect.Value, &effect.ExpiresAt, &effect.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		effects = append(effects, effect)
+	}
+	return effects, rows.Err()
+}
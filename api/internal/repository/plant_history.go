@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PlantHistoryRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPlantHistoryRepo(db *pgxpool.Pool) *PlantHistoryRepo {
+	return &PlantHistoryRepo{db: db}
+}
+
+// GetByUser возвращает историю растений пользователя, начиная с последних событий.
+// Пустой event возвращает события всех типов.
+func (r *PlantHistoryRepo) GetByUser(ctx context.Context, userID int64, event string, limit int) ([]model.PlantHistory, error) {
+	query := `
+		SELECT ph.id, ph.user_id, ph.seed_id, s.name, ph.bed_id, ph.event, ph.reason,
+		       ph.final_growth, ph.planted_at, ph.withered_at, ph.created_at
+		FROM plant_history ph
+		INNER JOIN seed s ON s.id = ph.seed_id
+		WHERE ph.user_id = $1 AND ($2 = '' OR ph.event = $2)
+		ORDER BY ph.created_at DESC, ph.id DESC
+		LIMIT $3
+	`
+	rows, err := r.db.Query(ctx, query, userID, event, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []model.PlantHistory{}
+	for rows.Next() {
+		var record model.PlantHistory
+		if err := rows.Scan(
+			&record.ID, &record.UserID, &record.SeedID, &record.SeedName, &record.BedID, &record.Event, &record.Reason,
+			&record.FinalGrowth, &record.PlantedAt, &record.WitheredAt, &record.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		history = append(history, record)
+	}
+	return history, rows.Err()
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PlantRevivalRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPlantRevivalRepo(db *pgxpool.Pool) *PlantRevivalRepo {
+	return &PlantRevivalRepo{db: db}
+}
+
+// ReviveWithWateringCan оживляет одно засохшее растение пользователя, списывая лейку.
+// Возвращает запись журнала и оставшееся количество леек.
+func (r *PlantRevivalRepo) ReviveWithWateringCan(ctx context.Context, userID int64, plantID int) (*model.PlantRevival, int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var seedID int
+	err = tx.QueryRow(ctx, `
+		UPDATE user_plant
+		SET is_withered = false, withered_at = NULL
+		WHERE id = $1 AND user_id = $2 AND is_withered = true
+		RETURNING seed_id
+	`, plantID, userID).Scan(&seedID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, fmt.Errorf("withered plant with id=%d not found", plantID)
+		}
+		return nil, 0, err
+	}
+
+	remaining, err := consumeUserItem(ctx, tx, userID, constants.ItemWateringCan, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	revival := &model.PlantRevival{
+		UserID:      userID,
+		UserPlantID: plantID,
+		SeedID:      seedID,
+		Method:      constants.RevivalMethodWateringCan,
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO plant_revival (user_id, user_plant_id, seed_id, method, drought_event_id, created_at)
+		VALUES ($1, $2, $3, $4,
+		        (SELECT id FROM drought_event WHERE user_id = $1 ORDER BY started_at DESC LIMIT 1),
+		        NOW())
+		RETURNING id, drought_event_id, created_at
+	`, userID, plantID, seedID, revival.Method).Scan(&revival.ID, &revival.DroughtEventID, &revival.CreatedAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, 0, err
+	}
+	return revival, remaining, nil
+}
+
+// ReviveAll бесплатно оживляет все засохшие растения пользователя.
+// Доступно один раз за засуху: используется последняя засуха пользователя.
+func (r *PlantRevivalRepo) ReviveAll(ctx context.Context, userID int64) ([]model.PlantRevival, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var eventID int
+	var bulkUsed bool
+	err = tx.QueryRow(ctx, `
+		SELECT id, bulk_recovered_at IS NOT NULL
+		FROM drought_event
+		WHERE user_id = $1
+		ORDER BY started_at DESC
+		LIMIT 1
+		FOR UPDATE
+	`, userID).Scan(&eventID, &bulkUsed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("bulk recovery is not available: no drought recorded")
+		}
+		return nil, err
+	}
+	if bulkUsed {
+		return nil, fmt.Errorf("bulk recovery is not available: already used for this drought")
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE drought_event SET bulk_recovered_at = NOW() WHERE id = $1`, eventID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+		WITH revived AS (
+			UPDATE user_plant
+			SET is_withered = false, withered_at = NULL
+			WHERE user_id = $1 AND is_withered = true
+			RETURNING id, seed_id
+		)
+		INSERT INTO plant_revival (user_id, user_plant_id, seed_id, method, drought_event_id, created_at)
+		SELECT $1, id, seed_id, $2, $3, NOW() FROM revived
+		RETURNING id, user_id, user_plant_id, seed_id, method, drought_event_id, created_at
+	`, userID, constants.RevivalMethodBulk, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	revivals, err := scanPlantRevivals(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return revivals, nil
+}
+
+// CanReviveAll проверяет, доступно ли бесплатное массовое восстановление для последней засухи
+func (r *PlantRevivalRepo) CanReviveAll(ctx context.Context, userID int64) (bool, error) {
+	query := `
+		SELECT COALESCE((
+			SELECT bulk_recovered_at IS NULL
+			FROM drought_event
+			WHERE user_id = $1
+			ORDER BY started_at DESC
+			LIMIT 1
+		), false)
+	`
+	var available bool
+	err := r.db.QueryRow(ctx, query, userID).Scan(&available)
+	return available, err
+}
+
+// GetByUser возвращает журнал оживления растений пользователя, начиная с последних
+func (r *PlantRevivalRepo) GetByUser(ctx context.Context, userID int64, limit int) ([]model.PlantRevival, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, user_plant_id, seed_id, method, drought_event_id, created_at
+		FROM plant_revival
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanPlantRevivals(rows)
+}
+
+func scanPlantRevivals(rows pgx.Rows) ([]model.PlantRevival, error) {
+	defer rows.Close()
+
+	revivals := []model.PlantRevival{}
+	for rows.Next() {
+		var revival model.PlantRevival
+		if err := rows.Scan(
+			&revival.ID, &revival.UserID, &revival.UserPlantID, &revival.SeedID, &revival.Method,
+			&revival.DroughtEventID, &revival.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		revivals = append(revivals, revival)
+	}
+	return revivals, rows.Err()
+}
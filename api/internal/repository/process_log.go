@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -20,17 +21,23 @@ func NewProgressLogRepo(db *pgxpool.Pool) *ProgressLogRepo {
 
 func (r *ProgressLogRepo) Create(ctx context.Context, log *model.ProgressLog) error {
 	query := `
-		INSERT INTO progress_log (user_id, task_id, habit_id, xp_earned, gold_earned, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO progress_log (user_id, task_id, habit_id, xp_earned, gold_earned, source, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id
 	`
 
+	if log.Source == "" {
+		log.Source = progressLogSource(log)
+	}
+
 	err := r.db.QueryRow(ctx, query,
 		log.UserID,
 		log.TaskID,
 		log.HabitID,
 		log.XPEarned,
 		log.GoldEarned,
+		log.Source,
+		log.Description,
 		log.CreatedAt,
 	).Scan(&log.ID)
 
@@ -41,7 +48,7 @@ func (r *ProgressLogRepo) GetByID(ctx context.Context, id int) (*model.ProgressL
 	var log model.ProgressLog
 
 	query := `
-		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, created_at
+		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, source, description, created_at
 		FROM progress_log
 		WHERE id = $1
 	`
@@ -53,6 +60,8 @@ func (r *ProgressLogRepo) GetByID(ctx context.Context, id int) (*model.ProgressL
 		&log.HabitID,
 		&log.XPEarned,
 		&log.GoldEarned,
+		&log.Source,
+		&log.Description,
 		&log.CreatedAt,
 	)
 
@@ -68,7 +77,7 @@ func (r *ProgressLogRepo) GetByID(ctx context.Context, id int) (*model.ProgressL
 
 func (r *ProgressLogRepo) GetByUser(ctx context.Context, userID int64, fromDate, toDate *time.Time) ([]model.ProgressLog, error) {
 	query := `
-		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, created_at
+		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, source, description, created_at
 		FROM progress_log
 		WHERE user_id = $1
 	`
@@ -107,6 +116,8 @@ func (r *ProgressLogRepo) GetByUser(ctx context.Context, userID int64, fromDate,
 			&log.HabitID,
 			&log.XPEarned,
 			&log.GoldEarned,
+			&log.Source,
+			&log.Description,
 			&log.CreatedAt,
 		)
 
@@ -129,12 +140,15 @@ func (r *ProgressLogRepo) GetByUserWithDetails(ctx context.Context, userID int64
 			pl.habit_id, 
 			pl.xp_earned, 
 			pl.gold_earned, 
+			pl.source,
+			pl.description,
 			pl.created_at,
 			t.title as task_title,
 			h.title as habit_title,
 			CASE 
 				WHEN pl.task_id IS NOT NULL THEN 'task'
 				WHEN pl.habit_id IS NOT NULL THEN 'habit'
+				ELSE pl.source
 			END as type
 		FROM progress_log pl
 		LEFT JOIN task t ON pl.task_id = t.id
@@ -177,6 +191,8 @@ func (r *ProgressLogRepo) GetByUserWithDetails(ctx context.Context, userID int64
 			&log.HabitID,
 			&log.XPEarned,
 			&log.GoldEarned,
+			&log.Source,
+			&log.Description,
 			&log.CreatedAt,
 			&taskTitle,
 			&habitTitle,
@@ -198,7 +214,7 @@ func (r *ProgressLogRepo) GetByUserWithDetails(ctx context.Context, userID int64
 
 func (r *ProgressLogRepo) GetByTask(ctx context.Context, taskID int) ([]model.ProgressLog, error) {
 	query := `
-		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, created_at
+		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, source, description, created_at
 		FROM progress_log
 		WHERE task_id = $1
 		ORDER BY created_at DESC
@@ -221,6 +237,8 @@ func (r *ProgressLogRepo) GetByTask(ctx context.Context, taskID int) ([]model.Pr
 			&log.HabitID,
 			&log.XPEarned,
 			&log.GoldEarned,
+			&log.Source,
+			&log.Description,
 			&log.CreatedAt,
 		)
 
@@ -236,7 +254,7 @@ func (r *ProgressLogRepo) GetByTask(ctx context.Context, taskID int) ([]model.Pr
 
 func (r *ProgressLogRepo) GetByHabit(ctx context.Context, habitID int) ([]model.ProgressLog, error) {
 	query := `
-		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, created_at
+		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, source, description, created_at
 		FROM progress_log
 		WHERE habit_id = $1
 		ORDER BY created_at DESC
@@ -259,6 +277,8 @@ func (r *ProgressLogRepo) GetByHabit(ctx context.Context, habitID int) ([]model.
 			&log.HabitID,
 			&log.XPEarned,
 			&log.GoldEarned,
+			&log.Source,
+			&log.Description,
 			&log.CreatedAt,
 		)
 
@@ -274,7 +294,7 @@ func (r *ProgressLogRepo) GetByHabit(ctx context.Context, habitID int) ([]model.
 
 func (r *ProgressLogRepo) GetUserProgressForDate(ctx context.Context, userID int64, date time.Time) ([]model.ProgressLog, error) {
 	query := `
-		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, created_at
+		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, source, description, created_at
 		FROM progress_log
 		WHERE user_id = $1 AND DATE(created_at) = $2
 		ORDER BY created_at DESC
@@ -297,6 +317,8 @@ func (r *ProgressLogRepo) GetUserProgressForDate(ctx context.Context, userID int
 			&log.HabitID,
 			&log.XPEarned,
 			&log.GoldEarned,
+			&log.Source,
+			&log.Description,
 			&log.CreatedAt,
 		)
 
@@ -384,7 +406,8 @@ func (r *ProgressLogRepo) HasUserCompletedTaskToday(ctx context.Context, userID
 	query := `
 		SELECT EXISTS(
 			SELECT 1 FROM progress_log 
-			WHERE user_id = $1 AND DATE(created_at) = $2 AND task_id IS NOT NULL
+			WHERE user_id = $1 AND DATE(created_at) = $2 AND source = 'task'
+			  AND xp_earned > 0 AND undone_at IS NULL
 		)`
 	var exists bool
 	err := r.db.QueryRow(ctx, query, userID, today).Scan(&exists)
@@ -396,103 +419,14 @@ func (r *ProgressLogRepo) HasUserCompletedHabitToday(ctx context.Context, userID
 	query := `
 		SELECT EXISTS(
 			SELECT 1 FROM progress_log 
-			WHERE user_id = $1 AND DATE(created_at) = $2 AND habit_id IS NOT NULL
+			WHERE user_id = $1 AND DATE(created_at) = $2 AND source = 'habit'
+			  AND xp_earned > 0 AND undone_at IS NULL
 		)`
 	var exists bool
 	err := r.db.QueryRow(ctx, query, userID, today).Scan(&exists)
 	return exists, err
 }
 
-// GetLastByTaskID возвращает последнюю запись progress_log для задачи
-func (r *ProgressLogRepo) GetLastByTaskID(ctx context.Context, taskID int) (*model.ProgressLog, error) {
-	query := `
-		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, created_at
-		FROM progress_log
-		WHERE task_id = $1 AND xp_earned > 0
-		ORDER BY created_at DESC
-		LIMIT 1
-	`
-
-	var log model.ProgressLog
-	err := r.db.QueryRow(ctx, query, taskID).Scan(
-		&log.ID,
-		&log.UserID,
-		&log.TaskID,
-		&log.HabitID,
-		&log.XPEarned,
-		&log.GoldEarned,
-		&log.CreatedAt,
-	)
-
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("progress log for task_id=%d not found", taskID)
-		}
-		return nil, err
-	}
-
-	return &log, nil
-}
-
-// GetLastByHabitID возвращает последнюю запись progress_log для привычки
-func (r *ProgressLogRepo) GetLastByHabitID(ctx context.Context, habitID int) (*model.ProgressLog, error) {
-	query := `
-		SELECT id, user_id, task_id, habit_id, xp_earned, gold_earned, created_at
-		FROM progress_log
-		WHERE habit_id = $1 AND xp_earned > 0
-		ORDER BY created_at DESC
-		LIMIT 1
-	`
-
-	var log model.ProgressLog
-	err := r.db.QueryRow(ctx, query, habitID).Scan(
-		&log.ID,
-		&log.UserID,
-		&log.TaskID,
-		&log.HabitID,
-		&log.XPEarned,
-		&log.GoldEarned,
-		&log.CreatedAt,
-	)
-
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("progress log for habit_id=%d not found", habitID)
-		}
-		return nil, err
-	}
-
-	return &log, nil
-}
-
-// DeleteByTaskID удаляет все записи progress_log для указанной задачи
-func (r *ProgressLogRepo) DeleteByTaskID(ctx context.Context, taskID int) error {
-	query := `DELETE FROM progress_log WHERE task_id = $1`
-	result, err := r.db.Exec(ctx, query, taskID)
-	if err != nil {
-		return err
-	}
-
-	rowsAffected := result.RowsAffected()
-	fmt.Printf("Deleted %d progress logs for task %d\n", rowsAffected, taskID)
-
-	return nil
-}
-
-// DeleteByHabitID удаляет все записи progress_log для указанной привычки
-func (r *ProgressLogRepo) DeleteByHabitID(ctx context.Context, habitID int) error {
-	query := `DELETE FROM progress_log WHERE habit_id = $1`
-	result, err := r.db.Exec(ctx, query, habitID)
-	if err != nil {
-		return err
-	}
-
-	rowsAffected := result.RowsAffected()
-	fmt.Printf("Deleted %d progress logs for habit %d\n", rowsAffected, habitID)
-
-	return nil
-}
-
 // В ProgressRepo добавьте метод:
 func (r *ProgressLogRepo) GetLastActivityDate(ctx context.Context, userID int64) (time.Time, error) {
 	var lastActivity time.Time
@@ -507,3 +441,11 @@ func (r *ProgressLogRepo) GetLastActivityDate(ctx context.Context, userID int64)
 	}
 	return lastActivity, nil
 }
+
+// progressLogSource определяет источник записи по заполненным полям
+func progressLogSource(log *model.ProgressLog) string {
+	if log.HabitID != nil {
+		return constants.ProgressSourceHabit
+	}
+	return constants.ProgressSourceTask
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ProduceRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewProduceRepo(db *pgxpool.Pool) *ProduceRepo {
+	return &ProduceRepo{db: db}
+}
+
+// GetByUser возвращает урожай в амбаре пользователя (только то, что есть в наличии)
+func (r *ProduceRepo) GetByUser(ctx context.Context, userID int64) ([]model.Produce, error) {
+	query := `
+		SELECT p.seed_id, s.name, COALESCE(s.icon, ''), p.quantity, p.updated_at
+		FROM user_produce p
+		INNER JOIN seed s ON s.id = p.seed_id
+		WHERE p.user_id = $1 AND p.quantity > 0
+		ORDER BY s.name
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	produce := []model.Produce{}
+	for rows.Next() {
+		var item model.Produce
+		if err := rows.Scan(&item.SeedID, &item.SeedName, &item.SeedIcon, &item.Quantity, &item.UpdatedAt); err != nil {
+			return nil, err
+		}
+		produce = append(produce, item)
+	}
+	return produce, rows.Err()
+}
+
+// addProduce добавляет урожай в амбар и возвращает новое количество
+func addProduce(ctx context.Context, q DBTX, userID int64, seedID int, quantity int) (int, error) {
+	query := `
+		INSERT INTO user_produce (user_id, seed_id, quantity, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id, seed_id)
+		DO UPDATE SET quantity = user_produce.quantity + EXCLUDED.quantity, updated_at = NOW()
+		RETURNING quantity
+	`
+	var total int
+	err := q.QueryRow(ctx, query, userID, seedID, quantity).Scan(&total)
+	return total, err
+}
+
+// Sell продает урожай по цене unitPrice: списывает его из амбара, начисляет золото
+// и записывает продажу в журнал золота в одной транзакции
+func (r *ProduceRepo) Sell(ctx context.Context, userID int64, seedID int, quantity int, unitPrice float64, description string) (*model.MarketSale, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	sale := &model.MarketSale{
+		SeedID:     seedID,
+		Quantity:   quantity,
+		UnitPrice:  unitPrice,
+		GoldEarned: utils.SaleGold(unitPrice, quantity),
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE user_produce
+		SET quantity = quantity - $3, updated_at = NOW()
+		WHERE user_id = $1 AND seed_id = $2 AND quantity >= $3
+		RETURNING quantity
+	`, userID, seedID, quantity).Scan(&sale.Remaining)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("not enough produce of seed_id=%d", seedID)
+		}
+		return nil, err
+	}
+
+	sale.Balance, err = changeGold(ctx, tx, userID, int64(sale.GoldEarned), goldChangeAllowNegative,
+		constants.GoldSourceMarketSale, description)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return sale, nil
+}
@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type QuestRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewQuestRepo(db *pgxpool.Pool) *QuestRepo {
+	return &QuestRepo{db: db}
+}
+
+const userQuestColumns = `
+	uq.id, uq.template_id, uq.quest_date, uq.title, uq.kind, uq.difficulty, uq.seed_id, uq.tag_name,
+	uq.target, uq.progress, uq.completed_at, uq.claimed_at,
+	qt.reward_gold, qt.reward_xp, qt.reward_item_id, qt.reward_item_amount
+`
+
+func scanUserQuest(row pgx.Row) (*model.UserQuest, error) {
+	var quest model.UserQuest
+	err := row.Scan(
+		&quest.ID, &quest.TemplateID, &quest.QuestDate, &quest.Title, &quest.Kind, &quest.Difficulty, &quest.SeedID, &quest.TagName,
+		&quest.Target, &quest.Progress, &quest.CompletedAt, &quest.ClaimedAt,
+		&quest.RewardGold, &quest.RewardXP, &quest.RewardItemID, &quest.RewardItemAmount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	quest.Completed = quest.CompletedAt != nil
+	quest.Claimed = quest.ClaimedAt != nil
+	return &quest, nil
+}
+
+func collectUserQuests(rows pgx.Rows) ([]model.UserQuest, error) {
+	defer rows.Close()
+
+	quests := []model.UserQuest{}
+	for rows.Next() {
+		quest, err := scanUserQuest(rows)
+		if err != nil {
+			return nil, err
+		}
+		quests = append(quests, *quest)
+	}
+	return quests, rows.Err()
+}
+
+// GetTemplates возвращает активные шаблоны заданий, доступные на уровне level
+func (r *QuestRepo) GetTemplates(ctx context.Context, level int) ([]model.QuestTemplate, error) {
+	query := `
+		SELECT id, code, title, kind, target, difficulty, seed_id, tag_name, min_level, weight,
+		       reward_gold, reward_xp, reward_item_id, reward_item_amount
+		FROM quest_template
+		WHERE is_active = true AND min_level <= $1
+		ORDER BY id
+	`
+	rows, err := r.db.Query(ctx, query, level)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []model.QuestTemplate{}
+	for rows.Next() {
+		var template model.QuestTemplate
+		if err := rows.Scan(
+			&template.ID, &template.Code, &template.Title, &template.Kind, &template.Target,
+			&template.Difficulty, &template.SeedID, &template.TagName, &template.MinLevel, &template.Weight,
+			&template.RewardGold, &template.RewardXP, &template.RewardItemID, &template.RewardItemAmount,
+		); err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+// CountHabitsWithTag возвращает количество привычек пользователя с тегом tagName (без учета регистра)
+func (r *QuestRepo) CountHabitsWithTag(ctx context.Context, userID int64, tagName string) (int, error) {
+	query := `
+		SELECT COUNT(DISTINCT h.id)
+		FROM habit h
+		INNER JOIN habit_tag ht ON ht.habit_id = h.id
+		INNER JOIN tag t ON t.id = ht.tag_id
+		WHERE h.user_id = $1 AND LOWER(t.name) = LOWER($2)
+	`
+	var count int
+	err := r.db.QueryRow(ctx, query, userID, tagName).Scan(&count)
+	return count, err
+}
+
+// GetByDate возвращает задания пользователя на день date
+func (r *QuestRepo) GetByDate(ctx context.Context, userID int64, date time.Time) ([]model.UserQuest, error) {
+	query := `
+		SELECT ` + userQuestColumns + `
+		FROM user_quest uq
+		INNER JOIN quest_template qt ON qt.id = uq.template_id
+		WHERE uq.user_id = $1 AND uq.quest_date = $2
+		ORDER BY uq.id
+	`
+	rows, err := r.db.Query(ctx, query, userID, date.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	return collectUserQuests(rows)
+}
+
+// CreateDaily выдает пользователю задания на день date.
+// Если задания на этот день уже выданы (например, параллельным запросом), ничего не делает и возвращает false.
+func (r *QuestRepo) CreateDaily(ctx context.Context, userID int64, date time.Time, quests []model.UserQuest) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Блокировка пользователя не дает выдать два набора заданий одновременно
+	if _, err := tx.Exec(ctx, `SELECT id FROM user_info WHERE id = $1 FOR UPDATE`, userID); err != nil {
+		return false, err
+	}
+
+	day := date.Format("2006-01-02")
+	var exists bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM user_quest WHERE user_id = $1 AND quest_date = $2)
+	`, userID, day).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	for _, quest := range quests {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO user_quest (user_id, template_id, quest_date, title, kind, difficulty, seed_id, tag_name, target, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+			ON CONFLICT (user_id, quest_date, template_id) DO NOTHING
+		`, userID, quest.TemplateID, day, quest.Title, quest.Kind, quest.Difficulty, quest.SeedID, quest.TagName, quest.Target)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// questEventCondition отбирает задания uq, которым соответствует событие с задачей $4, привычкой $5
+// или семенем собранного растения $6
+const questEventCondition = `(
+		      (uq.kind = 'complete_tasks' AND $4::int IS NOT NULL
+		          AND (uq.difficulty IS NULL OR uq.difficulty = (SELECT difficulty FROM task WHERE id = $4)))
+		      OR (uq.kind = 'complete_habits' AND $5::int IS NOT NULL
+		          AND (uq.difficulty IS NULL OR uq.difficulty = (SELECT difficulty FROM habit WHERE id = $5)))
+		      OR (uq.kind = 'complete_tagged_habits' AND EXISTS (
+		          SELECT 1 FROM habit_tag ht
+		          INNER JOIN tag t ON t.id = ht.tag_id
+		          WHERE ht.habit_id = $5 AND LOWER(t.name) = LOWER(uq.tag_name)
+		      ))
+		      OR (uq.kind = 'harvest_plants' AND $6::int IS NOT NULL
+		          AND (uq.seed_id IS NULL OR uq.seed_id = $6))
+		  )`
+
+// advanceQuests изменяет на delta прогресс неполученных заданий дня date, которым соответствует событие.
+// При delta > 0 возвращает задания, выполненные этим событием; при delta < 0 снимает отметку выполнения.
+func advanceQuests(ctx context.Context, q DBTX, userID int64, date time.Time, event model.QuestEvent, delta int) ([]model.UserQuest, error) {
+	progressCondition := `uq.progress < uq.target`
+	if delta < 0 {
+		progressCondition = `uq.progress > 0`
+	}
+
+	query := `
+		UPDATE user_quest uq
+		SET progress = GREATEST(0, LEAST(uq.target, uq.progress + $3)),
+		    completed_at = CASE WHEN uq.progress + $3 >= uq.target THEN NOW() ELSE NULL END
+		FROM quest_template qt
+		WHERE qt.id = uq.template_id
+		  AND uq.user_id = $1 AND uq.quest_date = $2 AND uq.claimed_at IS NULL
+		  AND ` + progressCondition + `
+		  AND ` + questEventCondition + `
+		RETURNING ` + userQuestColumns
+	rows, err := q.Query(ctx, query, userID, date.Format("2006-01-02"), delta, event.TaskID, event.HabitID, event.SeedID)
+	if err != nil {
+		return nil, err
+	}
+	quests, err := collectUserQuests(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := []model.UserQuest{}
+	for _, quest := range quests {
+		if quest.Completed {
+			completed = append(completed, quest)
+		}
+	}
+	return completed, nil
+}
+
+// hasClaimedQuestSince сообщает, есть ли у пользователя задание дня date, которому соответствует событие
+// и награда за которое получена после since, то есть задание могло быть выполнено этим событием
+func hasClaimedQuestSince(ctx context.Context, q DBTX, userID int64, date time.Time, event model.QuestEvent, since time.Time) (bool, error) {
+	var claimed bool
+	err := q.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM user_quest uq
+			WHERE uq.user_id = $1 AND uq.quest_date = $2 AND uq.claimed_at >= $3
+			  AND `+questEventCondition+`
+		)
+	`, userID, date.Format("2006-01-02"), since, event.TaskID, event.HabitID, event.SeedID).Scan(&claimed)
+	return claimed, err
+}
+
+// Claim выдает награду за выполненное задание в одной транзакции: золото, опыт с наградами за достигнутые
+// уровни и предмет. Награда выдается один раз.
+func (r *QuestRepo) Claim(ctx context.Context, userID int64, questID int) (*model.QuestClaim, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	quest, err := scanUserQuest(tx.QueryRow(ctx, `
+		SELECT `+userQuestColumns+`
+		FROM user_quest uq
+		INNER JOIN quest_template qt ON qt.id = uq.template_id
+		WHERE uq.id = $1 AND uq.user_id = $2
+		FOR UPDATE OF uq
+	`, questID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("quest with id=%d not found", questID)
+		}
+		return nil, err
+	}
+	if quest.Claimed {
+		return nil, fmt.Errorf("quest reward already claimed")
+	}
+	if !quest.Completed {
+		return nil, fmt.Errorf("quest is not completed")
+	}
+
+	now := time.Now()
+	if _, err := tx.Exec(ctx, `UPDATE user_quest SET claimed_at = $1 WHERE id = $2`, now, quest.ID); err != nil {
+		return nil, err
+	}
+	quest.ClaimedAt = &now
+	quest.Claimed = true
+
+	claim := &model.QuestClaim{Quest: *quest, Gold: quest.RewardGold, XP: quest.RewardXP}
+	description := fmt.Sprintf("Задание «%s»", quest.Title)
+
+	claim.Experience, err = addExperience(ctx, tx, userID, int64(quest.RewardXP))
+	if err != nil {
+		return nil, err
+	}
+
+	if quest.RewardGold > 0 {
+		if _, err := changeGold(ctx, tx, userID, int64(quest.RewardGold), goldChangeAllowNegative,
+			constants.GoldSourceQuest, description); err != nil {
+			return nil, err
+		}
+	}
+
+	if quest.RewardItemID != nil && quest.RewardItemAmount > 0 {
+		quantity, err := addUserItem(ctx, tx, userID, *quest.RewardItemID, quest.RewardItemAmount)
+		if err != nil {
+			return nil, err
+		}
+		grant := &model.ItemGrant{ItemID: *quest.RewardItemID, Amount: quest.RewardItemAmount, Quantity: quantity}
+		err = tx.QueryRow(ctx, `SELECT code, name FROM item WHERE id = $1`, grant.ItemID).Scan(&grant.Code, &grant.Name)
+		if err != nil {
+			return nil, err
+		}
+		claim.Item = grant
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO progress_log (user_id, xp_earned, gold_earned, source, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, userID, quest.RewardXP, quest.RewardGold, constants.ProgressSourceQuest, description, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -20,26 +21,26 @@ func NewSeedRepo(db *pgxpool.Pool) *SeedRepo {
 
 func (r *SeedRepo) Create(ctx context.Context, seed *model.Seed) error {
 	query := `
-		INSERT INTO seed (name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO seed (name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, is_hybrid, stage_thresholds, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id
 	`
 	return r.db.QueryRow(ctx, query,
 		seed.Name, seed.Icon, seed.ImgPlant, seed.LevelRequired, seed.TargetGrowth, seed.Rarity,
-		seed.Modification, seed.GoldReward, seed.XPReward, seed.CreatedAt,
+		seed.Modification, seed.GoldReward, seed.XPReward, seed.IsHybrid, stageThresholdsOrDefault(seed.StageThresholds), seed.CreatedAt,
 	).Scan(&seed.ID)
 }
 
 func (r *SeedRepo) GetByID(ctx context.Context, id int) (*model.Seed, error) {
 	var seed model.Seed
 	query := `
-		SELECT id, name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, created_at
+		SELECT id, name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, is_hybrid, stage_thresholds, created_at
 		FROM seed
 		WHERE id = $1
 	`
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&seed.ID, &seed.Name, &seed.Icon, &seed.ImgPlant, &seed.LevelRequired, &seed.TargetGrowth,
-		&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.CreatedAt,
+		&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.IsHybrid, &seed.StageThresholds, &seed.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -52,7 +53,7 @@ func (r *SeedRepo) GetByID(ctx context.Context, id int) (*model.Seed, error) {
 
 func (r *SeedRepo) GetAll(ctx context.Context) ([]model.Seed, error) {
 	query := `
-		SELECT id, name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, created_at
+		SELECT id, name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, is_hybrid, stage_thresholds, created_at
 		FROM seed
 		ORDER BY level_required, name
 	`
@@ -67,7 +68,7 @@ func (r *SeedRepo) GetAll(ctx context.Context) ([]model.Seed, error) {
 		var seed model.Seed
 		if err := rows.Scan(
 			&seed.ID, &seed.Name, &seed.Icon, &seed.ImgPlant, &seed.LevelRequired, &seed.TargetGrowth,
-			&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.CreatedAt,
+			&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.IsHybrid, &seed.StageThresholds, &seed.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -78,7 +79,7 @@ func (r *SeedRepo) GetAll(ctx context.Context) ([]model.Seed, error) {
 
 func (r *SeedRepo) GetByLevel(ctx context.Context, level int) ([]model.Seed, error) {
 	query := `
-		SELECT id, name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, created_at
+		SELECT id, name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, is_hybrid, stage_thresholds, created_at
 		FROM seed
 		WHERE level_required <= $1
 		ORDER BY level_required, rarity DESC, name
@@ -94,7 +95,35 @@ func (r *SeedRepo) GetByLevel(ctx context.Context, level int) ([]model.Seed, err
 		var seed model.Seed
 		if err := rows.Scan(
 			&seed.ID, &seed.Name, &seed.Icon, &seed.ImgPlant, &seed.LevelRequired, &seed.TargetGrowth,
-			&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.CreatedAt,
+			&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.IsHybrid, &seed.StageThresholds, &seed.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		seeds = append(seeds, seed)
+	}
+	return seeds, nil
+}
+
+// GetUnlockedBetween возвращает семена, открывающиеся на уровнях (fromLevel, toLevel]
+func (r *SeedRepo) GetUnlockedBetween(ctx context.Context, fromLevel, toLevel int) ([]model.Seed, error) {
+	query := `
+		SELECT id, name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, is_hybrid, stage_thresholds, created_at
+		FROM seed
+		WHERE level_required > $1 AND level_required <= $2 AND NOT is_hybrid
+		ORDER BY level_required, name
+	`
+	rows, err := r.db.Query(ctx, query, fromLevel, toLevel)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seeds := []model.Seed{}
+	for rows.Next() {
+		var seed model.Seed
+		if err := rows.Scan(
+			&seed.ID, &seed.Name, &seed.Icon, &seed.ImgPlant, &seed.LevelRequired, &seed.TargetGrowth,
+			&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.IsHybrid, &seed.StageThresholds, &seed.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -105,7 +134,7 @@ func (r *SeedRepo) GetByLevel(ctx context.Context, level int) ([]model.Seed, err
 
 func (r *SeedRepo) GetByRarity(ctx context.Context, rarity string) ([]model.Seed, error) {
 	query := `
-		SELECT id, name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, created_at
+		SELECT id, name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, is_hybrid, stage_thresholds, created_at
 		FROM seed
 		WHERE rarity = $1
 		ORDER BY level_required, name
@@ -121,7 +150,7 @@ func (r *SeedRepo) GetByRarity(ctx context.Context, rarity string) ([]model.Seed
 		var seed model.Seed
 		if err := rows.Scan(
 			&seed.ID, &seed.Name, &seed.Icon, &seed.ImgPlant, &seed.LevelRequired, &seed.TargetGrowth,
-			&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.CreatedAt,
+			&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.IsHybrid, &seed.StageThresholds, &seed.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -134,16 +163,16 @@ func (r *SeedRepo) Update(ctx context.Context, seed *model.Seed) error {
 	query := `
 		UPDATE seed
 		SET name = $1, icon = $2, img_plant = $3, level_required = $4, target_growth = $5, rarity = $6,
-			modification = $7, gold_reward = $8, xp_reward = $9
-		WHERE id = $10
-		RETURNING id, name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, created_at
+			modification = $7, gold_reward = $8, xp_reward = $9, is_hybrid = $10, stage_thresholds = $11
+		WHERE id = $12
+		RETURNING id, name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, is_hybrid, stage_thresholds, created_at
 	`
 	err := r.db.QueryRow(ctx, query,
 		seed.Name, seed.Icon, seed.ImgPlant, seed.LevelRequired, seed.TargetGrowth, seed.Rarity,
-		seed.Modification, seed.GoldReward, seed.XPReward, seed.ID,
+		seed.Modification, seed.GoldReward, seed.XPReward, seed.IsHybrid, stageThresholdsOrDefault(seed.StageThresholds), seed.ID,
 	).Scan(
 		&seed.ID, &seed.Name, &seed.Icon, &seed.ImgPlant, &seed.LevelRequired, &seed.TargetGrowth,
-		&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.CreatedAt,
+		&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.IsHybrid, &seed.StageThresholds, &seed.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -168,3 +197,11 @@ func (r *SeedRepo) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// stageThresholdsOrDefault возвращает стадии роста по умолчанию, если они не заданы
+func stageThresholdsOrDefault(thresholds []int) []int {
+	if len(thresholds) == 0 {
+		return constants.DefaultStageThresholds
+	}
+	return thresholds
+}
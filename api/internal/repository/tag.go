@@ -115,15 +115,21 @@ func (r *TagRepo) Update(ctx context.Context, tag *model.Tag) error {
 }
 
 func (r *TagRepo) Delete(ctx context.Context, id int, userID int64) error {
-	var taskCount int
-	checkQuery := `SELECT COUNT(*) FROM task WHERE tag_id = $1 AND user_id = $2`
-	err := r.db.QueryRow(ctx, checkQuery, id, userID).Scan(&taskCount)
+	var taskCount, habitCount int
+	checkQuery := `
+		SELECT
+			(SELECT COUNT(*) FROM task_tag tt JOIN task t ON t.id = tt.task_id
+			 WHERE tt.tag_id = $1 AND t.user_id = $2),
+			(SELECT COUNT(*) FROM habit_tag ht JOIN habit h ON h.id = ht.habit_id
+			 WHERE ht.tag_id = $1 AND h.user_id = $2)
+	`
+	err := r.db.QueryRow(ctx, checkQuery, id, userID).Scan(&taskCount, &habitCount)
 	if err != nil {
 		return err
 	}
 
-	if taskCount > 0 {
-		return fmt.Errorf("cannot delete tag: %d task(s) are still using it", taskCount)
+	if taskCount > 0 || habitCount > 0 {
+		return fmt.Errorf("cannot delete tag: %d task(s) and %d habit(s) are still using it", taskCount, habitCount)
 	}
 
 	query := `DELETE FROM tag WHERE id = $1 AND user_id = $2`
@@ -142,11 +148,10 @@ func (r *TagRepo) Delete(ctx context.Context, id int, userID int64) error {
 func (r *TagRepo) GetWithTaskCount(ctx context.Context, userID int64) ([]model.TagWithCount, error) {
 	query := `
 		SELECT t.id, t.user_id, t.name, t.color, t.created_at,
-		       COUNT(task.id) as task_count
+		       (SELECT COUNT(*) FROM task_tag tt WHERE tt.tag_id = t.id) AS task_count,
+		       (SELECT COUNT(*) FROM habit_tag ht WHERE ht.tag_id = t.id) AS habit_count
 		FROM tag t
-		LEFT JOIN task task ON t.id = task.tag_id AND task.user_id = t.user_id
 		WHERE t.user_id = $1
-		GROUP BY t.id, t.user_id, t.name, t.color, t.created_at
 		ORDER BY t.name ASC
 	`
 	rows, err := r.db.Query(ctx, query, userID)
@@ -159,18 +164,140 @@ func (r *TagRepo) GetWithTaskCount(ctx context.Context, userID int64) ([]model.T
 	for rows.Next() {
 		var tag model.TagWithCount
 		if err := rows.Scan(
-			&tag.ID, &tag.UserID, &tag.Name, &tag.Color, &tag.CreatedAt, &tag.TaskCount,
+			&tag.ID, &tag.UserID, &tag.Name, &tag.Color, &tag.CreatedAt,
+			&tag.TaskCount, &tag.HabitCount,
 		); err != nil {
 			return nil, err
 		}
+		tag.TotalCount = tag.TaskCount + tag.HabitCount
 		tags = append(tags, tag)
 	}
 	return tags, nil
 }
 
+// GetMissingIDs возвращает идентификаторы из списка, которые не принадлежат пользователю
+func (r *TagRepo) GetMissingIDs(ctx context.Context, userID int64, ids []int) ([]int, error) {
+	ids = normalizeTagIDs(ids)
+	if len(ids) == 0 {
+		return []int{}, nil
+	}
+
+	query := `
+		SELECT u.tag_id FROM unnest($1::int[]) AS u(tag_id)
+		WHERE NOT EXISTS (SELECT 1 FROM tag t WHERE t.id = u.tag_id AND t.user_id = $2)
+		ORDER BY u.tag_id
+	`
+	rows, err := r.db.Query(ctx, query, ids, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	missing := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		missing = append(missing, id)
+	}
+	return missing, rows.Err()
+}
+
 func (r *TagRepo) Exists(ctx context.Context, name string, userID int64) (bool, error) {
 	var exists bool
 	query := `SELECT EXISTS(SELECT 1 FROM tag WHERE name = $1 AND user_id = $2)`
 	err := r.db.QueryRow(ctx, query, name, userID).Scan(&exists)
 	return exists, err
 }
+
+// tagLink описывает таблицу связи сущности с тегами
+type tagLink struct {
+	table       string
+	ownerColumn string
+}
+
+var (
+	taskTagLink  = tagLink{table: "task_tag", ownerColumn: "task_id"}
+	habitTagLink = tagLink{table: "habit_tag", ownerColumn: "habit_id"}
+)
+
+// normalizeTagIDs убирает дубликаты и некорректные идентификаторы, сохраняя порядок
+func normalizeTagIDs(ids []int) []int {
+	result := make([]int, 0, len(ids))
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+// replaceTags заменяет набор тегов сущности. Привязываются только теги пользователя.
+func replaceTags(ctx context.Context, q DBTX, link tagLink, ownerID int, userID int64, tagIDs []int) error {
+	deleteQuery := fmt.Sprintf(`DELETE FROM %s WHERE %s = $1`, link.table, link.ownerColumn)
+	if _, err := q.Exec(ctx, deleteQuery, ownerID); err != nil {
+		return err
+	}
+
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	insertQuery := fmt.Sprintf(`
+		INSERT INTO %s (%s, tag_id)
+		SELECT $1, id FROM tag WHERE id = ANY($2) AND user_id = $3
+		ON CONFLICT DO NOTHING
+	`, link.table, link.ownerColumn)
+	_, err := q.Exec(ctx, insertQuery, ownerID, tagIDs, userID)
+	return err
+}
+
+// loadTags загружает теги для набора сущностей одним запросом
+func loadTags(ctx context.Context, q DBTX, link tagLink, ownerIDs []int) (map[int][]model.Tag, error) {
+	result := make(map[int][]model.Tag, len(ownerIDs))
+	if len(ownerIDs) == 0 {
+		return result, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT l.%s, tag.id, tag.user_id, tag.name, COALESCE(tag.color, ''), tag.created_at
+		FROM %s l
+		JOIN tag ON tag.id = l.tag_id
+		WHERE l.%s = ANY($1)
+		ORDER BY tag.name ASC
+	`, link.ownerColumn, link.table, link.ownerColumn)
+	rows, err := q.Query(ctx, query, ownerIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ownerID int
+		var tag model.Tag
+		if err := rows.Scan(&ownerID, &tag.ID, &tag.UserID, &tag.Name, &tag.Color, &tag.CreatedAt); err != nil {
+			return nil, err
+		}
+		result[ownerID] = append(result[ownerID], tag)
+	}
+	return result, rows.Err()
+}
+
+// tagFilterCondition строит условие фильтрации по тегам (параметр $2 — массив тегов).
+// matchAll = true требует наличия всех тегов, иначе достаточно любого.
+func tagFilterCondition(link tagLink, ownerRef string, matchAll bool) string {
+	if matchAll {
+		return fmt.Sprintf(`(
+			SELECT COUNT(DISTINCT l.tag_id) FROM %s l
+			WHERE l.%s = %s AND l.tag_id = ANY($2)
+		) = cardinality($2::int[])`, link.table, link.ownerColumn, ownerRef)
+	}
+	return fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM %s l
+			WHERE l.%s = %s AND l.tag_id = ANY($2)
+		)`, link.table, link.ownerColumn, ownerRef)
+}
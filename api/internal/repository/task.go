@@ -23,7 +23,7 @@ func (r *TaskRepo) HasCompletedTaskToday(ctx context.Context, userID int64) (boo
 	today := time.Now().Format("2006-01-02")
 	query := `
         SELECT EXISTS(
-            SELECT 1 FROM progress_log 
+            SELECT 1 FROM progress_log
             WHERE user_id = $1 AND DATE(created_at) = $2 AND task_id IS NOT NULL
         )`
 	var exists bool
@@ -31,35 +31,52 @@ func (r *TaskRepo) HasCompletedTaskToday(ctx context.Context, userID int64) (boo
 	return exists, err
 }
 
+// Create создает задачу вместе с привязками к тегам в одной транзакции
 func (r *TaskRepo) Create(ctx context.Context, task *model.Task) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO task (user_id, title, description, difficulty, tag_id, date, done, xp_reward, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO task (user_id, title, description, difficulty, date, done, xp_reward, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id
 	`
-	return r.db.QueryRow(ctx, query,
-		task.UserID, task.Title, task.Description, task.Difficulty, task.TagID,
+	err = tx.QueryRow(ctx, query,
+		task.UserID, task.Title, task.Description, task.Difficulty,
 		task.Date, task.Done, task.XPReward, task.CreatedAt,
 	).Scan(&task.ID)
+	if err != nil {
+		return err
+	}
+
+	task.TagIDs = normalizeTagIDs(task.TagIDs)
+	if err := replaceTags(ctx, tx, taskTagLink, task.ID, task.UserID, task.TagIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	return r.attachTags(ctx, []*model.Task{task})
 }
 
 func (r *TaskRepo) GetByID(ctx context.Context, id int, userID int64) (*model.Task, error) {
 	var task model.Task
-	var tagName, tagColor *string
 
 	query := `
-		SELECT t.id, t.user_id, t.title, t.description, t.difficulty, t.tag_id, 
-		       t.date, t.done, t.xp_reward, t.created_at,
-		       tag.name, tag.color
+		SELECT t.id, t.user_id, t.title, t.description, t.difficulty,
+		       t.date, t.done, t.xp_reward, t.created_at
 		FROM task t
-		LEFT JOIN tag ON t.tag_id = tag.id
 		WHERE t.id = $1 AND t.user_id = $2
 	`
 
 	err := r.db.QueryRow(ctx, query, id, userID).Scan(
 		&task.ID, &task.UserID, &task.Title, &task.Description, &task.Difficulty,
-		&task.TagID, &task.Date, &task.Done, &task.XPReward, &task.CreatedAt,
-		&tagName, &tagColor,
+		&task.Date, &task.Done, &task.XPReward, &task.CreatedAt,
 	)
 
 	if err != nil {
@@ -69,13 +86,8 @@ func (r *TaskRepo) GetByID(ctx context.Context, id int, userID int64) (*model.Ta
 		return nil, err
 	}
 
-	// Если есть тег, заполняем структуру Tag
-	if tagName != nil && task.TagID != nil {
-		task.Tag = &model.Tag{
-			ID:    *task.TagID,
-			Name:  *tagName,
-			Color: *tagColor,
-		}
+	if err := r.attachTags(ctx, []*model.Task{&task}); err != nil {
+		return nil, err
 	}
 
 	return &task, nil
@@ -83,67 +95,52 @@ func (r *TaskRepo) GetByID(ctx context.Context, id int, userID int64) (*model.Ta
 
 func (r *TaskRepo) GetAll(ctx context.Context, userID int64) ([]model.Task, error) {
 	query := `
-		SELECT t.id, t.user_id, t.title, t.description, t.difficulty, t.tag_id, 
-		       t.date, t.done, t.xp_reward, t.created_at,
-		       tag.name, tag.color
+		SELECT t.id, t.user_id, t.title, t.description, t.difficulty,
+		       t.date, t.done, t.xp_reward, t.created_at
 		FROM task t
-		LEFT JOIN tag ON t.tag_id = tag.id
 		WHERE t.user_id = $1
 		ORDER BY t.created_at DESC
 	`
-	rows, err := r.db.Query(ctx, query, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	tasks := []model.Task{}
-	for rows.Next() {
-		var task model.Task
-		var tagName, tagColor *string
-
-		if err := rows.Scan(
-			&task.ID, &task.UserID, &task.Title, &task.Description, &task.Difficulty,
-			&task.TagID, &task.Date, &task.Done, &task.XPReward, &task.CreatedAt,
-			&tagName, &tagColor,
-		); err != nil {
-			return nil, err
-		}
-
-		// Если есть тег, заполняем структуру Tag
-		if tagName != nil && task.TagID != nil {
-			task.Tag = &model.Tag{
-				ID:    *task.TagID,
-				Name:  *tagName,
-				Color: *tagColor,
-			}
-		}
-
-		tasks = append(tasks, task)
-	}
-	return tasks, nil
+	return r.queryTasks(ctx, query, userID)
 }
 
+// Update обновляет задачу и полностью заменяет набор ее тегов
 func (r *TaskRepo) Update(ctx context.Context, task *model.Task) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Отметка выполнения меняется только выполнением и его отменой
 	query := `
 		UPDATE task
-		SET title = $1, description = $2, difficulty = $3, tag_id = $4, 
-		    date = $5, done = $6, xp_reward = $7
-		WHERE id = $8 AND user_id = $9
+		SET title = $1, description = $2, difficulty = $3,
+		    date = $4, xp_reward = $5
+		WHERE id = $6 AND user_id = $7
+		RETURNING done, created_at
 	`
-	result, err := r.db.Exec(ctx, query,
-		task.Title, task.Description, task.Difficulty, task.TagID, task.Date,
-		task.Done, task.XPReward, task.ID, task.UserID,
-	)
+	err = tx.QueryRow(ctx, query,
+		task.Title, task.Description, task.Difficulty, task.Date,
+		task.XPReward, task.ID, task.UserID,
+	).Scan(&task.Done, &task.CreatedAt)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("task with id=%d not found or does not belong to user", task.ID)
+		}
 		return err
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("task with id=%d not found or does not belong to user", task.ID)
+	task.TagIDs = normalizeTagIDs(task.TagIDs)
+	if err := replaceTags(ctx, tx, taskTagLink, task.ID, task.UserID, task.TagIDs); err != nil {
+		return err
 	}
-	return nil
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	return r.attachTags(ctx, []*model.Task{task})
 }
 
 func (r *TaskRepo) Delete(ctx context.Context, id int, userID int64) error {
@@ -162,85 +159,59 @@ func (r *TaskRepo) Delete(ctx context.Context, id int, userID int64) error {
 
 func (r *TaskRepo) GetByStatus(ctx context.Context, userID int64, done bool) ([]model.Task, error) {
 	query := `
-		SELECT t.id, t.user_id, t.title, t.description, t.difficulty, t.tag_id, 
-		       t.date, t.done, t.xp_reward, t.created_at,
-		       tag.name, tag.color
+		SELECT t.id, t.user_id, t.title, t.description, t.difficulty,
+		       t.date, t.done, t.xp_reward, t.created_at
 		FROM task t
-		LEFT JOIN tag ON t.tag_id = tag.id
 		WHERE t.user_id = $1 AND t.done = $2
 		ORDER BY t.created_at DESC
 	`
-	rows, err := r.db.Query(ctx, query, userID, done)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	tasks := []model.Task{}
-	for rows.Next() {
-		var task model.Task
-		var tagName, tagColor *string
-
-		if err := rows.Scan(
-			&task.ID, &task.UserID, &task.Title, &task.Description, &task.Difficulty,
-			&task.TagID, &task.Date, &task.Done, &task.XPReward, &task.CreatedAt,
-			&tagName, &tagColor,
-		); err != nil {
-			return nil, err
-		}
-
-		if tagName != nil && task.TagID != nil {
-			task.Tag = &model.Tag{
-				ID:    *task.TagID,
-				Name:  *tagName,
-				Color: *tagColor,
-			}
-		}
-
-		tasks = append(tasks, task)
-	}
-	return tasks, nil
+	return r.queryTasks(ctx, query, userID, done)
 }
 
-func (r *TaskRepo) MarkAsDone(ctx context.Context, id int, userID int64) error {
-	query := `UPDATE task SET done = true WHERE id = $1 AND user_id = $2`
-	result, err := r.db.Exec(ctx, query, id, userID)
-	if err != nil {
-		return err
-	}
-
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("task with id=%d not found or does not belong to user", id)
-	}
-	return nil
+func (r *TaskRepo) GetByDate(ctx context.Context, userID int64, date time.Time) ([]model.Task, error) {
+	query := `
+		SELECT t.id, t.user_id, t.title, t.description, t.difficulty,
+		       t.date, t.done, t.xp_reward, t.created_at
+		FROM task t
+		WHERE t.user_id = $1 AND t.date = $2
+		ORDER BY t.created_at DESC
+	`
+	return r.queryTasks(ctx, query, userID, date)
 }
 
-func (r *TaskRepo) MarkAsUndone(ctx context.Context, id int, userID int64) error {
-	query := `UPDATE task SET done = false WHERE id = $1 AND user_id = $2`
-	result, err := r.db.Exec(ctx, query, id, userID)
-	if err != nil {
-		return err
-	}
-
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return fmt.Errorf("task with id=%d not found or does not belong to user", id)
+// GetByTags возвращает задачи с указанными тегами.
+// matchAll = false — задача содержит хотя бы один из тегов (any-of),
+// matchAll = true — задача содержит все указанные теги (all-of).
+func (r *TaskRepo) GetByTags(ctx context.Context, userID int64, tagIDs []int, matchAll bool) ([]model.Task, error) {
+	tagIDs = normalizeTagIDs(tagIDs)
+	if len(tagIDs) == 0 {
+		return []model.Task{}, nil
 	}
-	return nil
-}
 
-func (r *TaskRepo) GetByDate(ctx context.Context, userID int64, date time.Time) ([]model.Task, error) {
 	query := `
-		SELECT t.id, t.user_id, t.title, t.description, t.difficulty, t.tag_id, 
-		       t.date, t.done, t.xp_reward, t.created_at,
-		       tag.name, tag.color
+		SELECT t.id, t.user_id, t.title, t.description, t.difficulty,
+		       t.date, t.done, t.xp_reward, t.created_at
 		FROM task t
-		LEFT JOIN tag ON t.tag_id = tag.id
-		WHERE t.user_id = $1 AND t.date = $2
+		WHERE t.user_id = $1 AND ` + tagFilterCondition(taskTagLink, "t.id", matchAll) + `
 		ORDER BY t.created_at DESC
 	`
-	rows, err := r.db.Query(ctx, query, userID, date)
+	return r.queryTasks(ctx, query, userID, tagIDs)
+}
+
+// ResetTag отвязывает тег от всех задач пользователя
+func (r *TaskRepo) ResetTag(ctx context.Context, userID int64, tagID int) error {
+	query := `
+		DELETE FROM task_tag tt
+		USING task t
+		WHERE tt.task_id = t.id AND t.user_id = $1 AND tt.tag_id = $2
+	`
+	_, err := r.db.Exec(ctx, query, userID, tagID)
+	return err
+}
+
+// queryTasks выполняет запрос, сканирует задачи и подгружает их теги
+func (r *TaskRepo) queryTasks(ctx context.Context, query string, args ...interface{}) ([]model.Task, error) {
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -249,73 +220,49 @@ func (r *TaskRepo) GetByDate(ctx context.Context, userID int64, date time.Time)
 	tasks := []model.Task{}
 	for rows.Next() {
 		var task model.Task
-		var tagName, tagColor *string
-
 		if err := rows.Scan(
 			&task.ID, &task.UserID, &task.Title, &task.Description, &task.Difficulty,
-			&task.TagID, &task.Date, &task.Done, &task.XPReward, &task.CreatedAt,
-			&tagName, &tagColor,
+			&task.Date, &task.Done, &task.XPReward, &task.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
-
-		if tagName != nil && task.TagID != nil {
-			task.Tag = &model.Tag{
-				ID:    *task.TagID,
-				Name:  *tagName,
-				Color: *tagColor,
-			}
-		}
-
 		tasks = append(tasks, task)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*model.Task, len(tasks))
+	for i := range tasks {
+		ptrs[i] = &tasks[i]
+	}
+	if err := r.attachTags(ctx, ptrs); err != nil {
+		return nil, err
+	}
 	return tasks, nil
 }
 
-func (r *TaskRepo) GetByTag(ctx context.Context, userID int64, tagID int) ([]model.Task, error) {
-	query := `
-		SELECT t.id, t.user_id, t.title, t.description, t.difficulty, t.tag_id, 
-		       t.date, t.done, t.xp_reward, t.created_at,
-		       tag.name, tag.color
-		FROM task t
-		LEFT JOIN tag ON t.tag_id = tag.id
-		WHERE t.user_id = $1 AND t.tag_id = $2
-		ORDER BY t.created_at DESC
-	`
-	rows, err := r.db.Query(ctx, query, userID, tagID)
-	if err != nil {
-		return nil, err
+// attachTags заполняет TagIDs и Tags у переданных задач
+func (r *TaskRepo) attachTags(ctx context.Context, tasks []*model.Task) error {
+	ids := make([]int, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
 	}
-	defer rows.Close()
 
-	tasks := []model.Task{}
-	for rows.Next() {
-		var task model.Task
-		var tagName, tagColor *string
+	tagsByTask, err := loadTags(ctx, r.db, taskTagLink, ids)
+	if err != nil {
+		return err
+	}
 
-		if err := rows.Scan(
-			&task.ID, &task.UserID, &task.Title, &task.Description, &task.Difficulty,
-			&task.TagID, &task.Date, &task.Done, &task.XPReward, &task.CreatedAt,
-			&tagName, &tagColor,
-		); err != nil {
-			return nil, err
+	for _, task := range tasks {
+		task.Tags = tagsByTask[task.ID]
+		if task.Tags == nil {
+			task.Tags = []model.Tag{}
 		}
-
-		if tagName != nil && task.TagID != nil {
-			task.Tag = &model.Tag{
-				ID:    *task.TagID,
-				Name:  *tagName,
-				Color: *tagColor,
-			}
+		task.TagIDs = make([]int, len(task.Tags))
+		for i, tag := range task.Tags {
+			task.TagIDs[i] = tag.ID
 		}
-
-		tasks = append(tasks, task)
 	}
-	return tasks, nil
-}
-
-func (r *TaskRepo) ResetTag(ctx context.Context, userID int64, tagID int) error {
-	query := `UPDATE task SET tag_id = NULL WHERE user_id = $1 AND tag_id = $2`
-	_, err := r.db.Exec(ctx, query, userID, tagID)
-	return err
+	return nil
 }
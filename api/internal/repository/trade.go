@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TradeRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewTradeRepo(db *pgxpool.Pool) *TradeRepo {
+	return &TradeRepo{db: db}
+}
+
+const tradeOfferQuery = `
+	SELECT t.id, t.proposer_id, pu.username, t.recipient_id, ru.username, t.status, t.created_at, t.resolved_at
+	FROM trade_offer t
+	INNER JOIN user_info pu ON pu.id = t.proposer_id
+	INNER JOIN user_info ru ON ru.id = t.recipient_id
+`
+
+// Create создает предложение обмена после проверки дружбы и дневных лимитов.
+// Ничего не списывается: наличие семян и урожая у обеих сторон проверяется при принятии.
+func (r *TradeRepo) Create(ctx context.Context, trade *model.TradeOffer, sendLimit, receiveLimit int) (*model.TradeOffer, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := checkExchangeAllowed(ctx, tx, trade.ProposerID, trade.RecipientID, sendLimit, receiveLimit); err != nil {
+		return nil, err
+	}
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO trade_offer (proposer_id, recipient_id, status, created_at)
+		VALUES ($1, $2, $3, NOW())
+		RETURNING id
+	`, trade.ProposerID, trade.RecipientID, constants.ExchangePending).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	sides := map[string][]model.TradeLine{
+		constants.TradeSideOffer:   trade.Offer,
+		constants.TradeSideRequest: trade.Request,
+	}
+	for side, lines := range sides {
+		for _, line := range lines {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO trade_offer_line (trade_id, side, kind, seed_id, amount)
+				VALUES ($1, $2, $3, $4, $5)
+			`, id, side, line.Kind, line.SeedID, line.Amount)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	created, err := r.getByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	return created, tx.Commit(ctx)
+}
+
+// GetPending возвращает входящие и исходящие предложения обмена, ожидающие ответа
+func (r *TradeRepo) GetPending(ctx context.Context, userID int64) (*model.TradeOffers, error) {
+	query := tradeOfferQuery + `
+		WHERE t.status = $1 AND (t.proposer_id = $2 OR t.recipient_id = $2)
+		ORDER BY t.created_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, constants.ExchangePending, userID)
+	if err != nil {
+		return nil, err
+	}
+	trades, err := collectTradeOffers(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadLines(ctx, r.db, trades); err != nil {
+		return nil, err
+	}
+
+	offers := &model.TradeOffers{
+		Incoming: []model.TradeOffer{},
+		Outgoing: []model.TradeOffer{},
+	}
+	for _, trade := range trades {
+		if trade.RecipientID == userID {
+			offers.Incoming = append(offers.Incoming, trade)
+		} else {
+			offers.Outgoing = append(offers.Outgoing, trade)
+		}
+	}
+	return offers, nil
+}
+
+// Accept принимает входящее предложение обмена: в одной транзакции списывает позиции offer у автора
+// и позиции request у получателя и передает их другой стороне. Если у кого-то не хватает семян или урожая,
+// обмен не выполняется, а предложение остается ожидающим ответа.
+func (r *TradeRepo) Accept(ctx context.Context, userID int64, tradeID int64) (*model.TradeOffer, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	trade, err := lockPendingTrade(ctx, tx, tradeID, `recipient_id = $2`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Инвентари обоих пользователей меняются в одном порядке, чтобы встречные обмены не блокировали друг друга
+	_, err = tx.Exec(ctx, `
+		SELECT id FROM user_info WHERE id = ANY($1) ORDER BY id FOR UPDATE
+	`, []int64{trade.ProposerID, trade.RecipientID})
+	if err != nil {
+		return nil, err
+	}
+
+	trades := []model.TradeOffer{*trade}
+	if err := r.loadLines(ctx, tx, trades); err != nil {
+		return nil, err
+	}
+	trade = &trades[0]
+
+	for _, line := range trade.Offer {
+		if err := takeInventory(ctx, tx, trade.ProposerID, line.Kind, line.SeedID, line.Amount); err != nil {
+			return nil, err
+		}
+	}
+	for _, line := range trade.Request {
+		if err := takeInventory(ctx, tx, trade.RecipientID, line.Kind, line.SeedID, line.Amount); err != nil {
+			return nil, err
+		}
+	}
+	for _, line := range trade.Offer {
+		if err := putInventory(ctx, tx, trade.RecipientID, line.Kind, line.SeedID, line.Amount); err != nil {
+			return nil, err
+		}
+	}
+	for _, line := range trade.Request {
+		if err := putInventory(ctx, tx, trade.ProposerID, line.Kind, line.SeedID, line.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.resolve(ctx, tx, trade.ID, constants.ExchangeAccepted)
+}
+
+// Decline отклоняет входящее или отменяет исходящее предложение обмена
+func (r *TradeRepo) Decline(ctx context.Context, userID int64, tradeID int64) (*model.TradeOffer, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	trade, err := lockPendingTrade(ctx, tx, tradeID, `(recipient_id = $2 OR proposer_id = $2)`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := constants.ExchangeDeclined
+	if trade.ProposerID == userID {
+		status = constants.ExchangeCancelled
+	}
+	return r.resolve(ctx, tx, trade.ID, status)
+}
+
+// resolve завершает предложение обмена со статусом status и фиксирует транзакцию
+func (r *TradeRepo) resolve(ctx context.Context, tx pgx.Tx, tradeID int64, status string) (*model.TradeOffer, error) {
+	_, err := tx.Exec(ctx, `UPDATE trade_offer SET status = $1, resolved_at = $2 WHERE id = $3`, status, time.Now(), tradeID)
+	if err != nil {
+		return nil, err
+	}
+
+	trade, err := r.getByID(ctx, tx, tradeID)
+	if err != nil {
+		return nil, err
+	}
+	return trade, tx.Commit(ctx)
+}
+
+func (r *TradeRepo) getByID(ctx context.Context, q DBTX, tradeID int64) (*model.TradeOffer, error) {
+	rows, err := q.Query(ctx, tradeOfferQuery+` WHERE t.id = $1`, tradeID)
+	if err != nil {
+		return nil, err
+	}
+	trades, err := collectTradeOffers(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(trades) == 0 {
+		return nil, fmt.Errorf("trade offer with id=%d not found", tradeID)
+	}
+	if err := r.loadLines(ctx, q, trades); err != nil {
+		return nil, err
+	}
+	return &trades[0], nil
+}
+
+// loadLines заполняет позиции offer и request предложений обмена
+func (r *TradeRepo) loadLines(ctx context.Context, q DBTX, trades []model.TradeOffer) error {
+	if len(trades) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(trades))
+	index := make(map[int64]int, len(trades))
+	for i := range trades {
+		ids[i] = trades[i].ID
+		index[trades[i].ID] = i
+		trades[i].Offer = []model.TradeLine{}
+		trades[i].Request = []model.TradeLine{}
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT l.trade_id, l.side, l.kind, l.seed_id, s.name, l.amount
+		FROM trade_offer_line l
+		INNER JOIN seed s ON s.id = l.seed_id
+		WHERE l.trade_id = ANY($1)
+		ORDER BY l.id
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tradeID int64
+		var side string
+		var line model.TradeLine
+		if err := rows.Scan(&tradeID, &side, &line.Kind, &line.SeedID, &line.SeedName, &line.Amount); err != nil {
+			return err
+		}
+		trade := &trades[index[tradeID]]
+		if side == constants.TradeSideOffer {
+			trade.Offer = append(trade.Offer, line)
+		} else {
+			trade.Request = append(trade.Request, line)
+		}
+	}
+	return rows.Err()
+}
+
+func collectTradeOffers(rows pgx.Rows) ([]model.TradeOffer, error) {
+	defer rows.Close()
+
+	trades := []model.TradeOffer{}
+	for rows.Next() {
+		var trade model.TradeOffer
+		if err := rows.Scan(
+			&trade.ID, &trade.ProposerID, &trade.ProposerUsername, &trade.RecipientID, &trade.RecipientUsername,
+			&trade.Status, &trade.CreatedAt, &trade.ResolvedAt,
+		); err != nil {
+			return nil, err
+		}
+		trades = append(trades, trade)
+	}
+	return trades, rows.Err()
+}
+
+// lockPendingTrade блокирует ожидающее ответа предложение обмена, доступное пользователю по условию access
+func lockPendingTrade(ctx context.Context, tx pgx.Tx, tradeID int64, access string, userID int64) (*model.TradeOffer, error) {
+	var trade model.TradeOffer
+	err := tx.QueryRow(ctx, `
+		SELECT id, proposer_id, recipient_id
+		FROM trade_offer
+		WHERE id = $1 AND `+access+` AND status = $3
+		FOR UPDATE
+	`, tradeID, userID, constants.ExchangePending).Scan(&trade.ID, &trade.ProposerID, &trade.RecipientID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("trade offer with id=%d not found", tradeID)
+		}
+		return nil, err
+	}
+	return &trade, nil
+}
@@ -33,22 +33,22 @@ func (r *UserRepo) Create(ctx context.Context, user *model.User) error {
 	query := `
 		INSERT INTO "user_info" (max_id, username, created_at, last_login, is_active)
 		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id
+		RETURNING id, role, farm_privacy
 	`
 	return r.db.QueryRow(ctx, query,
 		user.MaxID, user.Username, user.CreatedAt, user.LastLogin, user.IsActive,
-	).Scan(&user.ID)
+	).Scan(&user.ID, &user.Role, &user.FarmPrivacy)
 }
 
 func (r *UserRepo) GetByID(ctx context.Context, id int64) (*model.User, error) {
 	var user model.User
 	query := `
-		SELECT id, max_id, username, created_at, last_login, is_active
+		SELECT id, max_id, username, created_at, last_login, is_active, role, farm_privacy
 		FROM "user_info"
 		WHERE id = $1
 	`
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&user.ID, &user.MaxID, &user.Username, &user.CreatedAt, &user.LastLogin, &user.IsActive,
+		&user.ID, &user.MaxID, &user.Username, &user.CreatedAt, &user.LastLogin, &user.IsActive, &user.Role, &user.FarmPrivacy,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -62,12 +62,12 @@ func (r *UserRepo) GetByID(ctx context.Context, id int64) (*model.User, error) {
 func (r *UserRepo) GetByMaxID(ctx context.Context, maxID int64) (*model.User, error) {
 	var user model.User
 	query := `
-		SELECT id, max_id, username, created_at, last_login, is_active
+		SELECT id, max_id, username, created_at, last_login, is_active, role, farm_privacy
 		FROM "user_info"
 		WHERE max_id = $1
 	`
 	err := r.db.QueryRow(ctx, query, maxID).Scan(
-		&user.ID, &user.MaxID, &user.Username, &user.CreatedAt, &user.LastLogin, &user.IsActive,
+		&user.ID, &user.MaxID, &user.Username, &user.CreatedAt, &user.LastLogin, &user.IsActive, &user.Role, &user.FarmPrivacy,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -83,12 +83,12 @@ func (r *UserRepo) Update(ctx context.Context, user *model.User) error {
 		UPDATE "user_info"
 		SET username = $1, last_login = $2, is_active = $3
 		WHERE id = $4
-		RETURNING id, max_id, username, created_at, last_login, is_active
+		RETURNING id, max_id, username, created_at, last_login, is_active, role, farm_privacy
 	`
 	err := r.db.QueryRow(ctx, query,
 		user.Username, user.LastLogin, user.IsActive, user.ID,
 	).Scan(
-		&user.ID, &user.MaxID, &user.Username, &user.CreatedAt, &user.LastLogin, &user.IsActive,
+		&user.ID, &user.MaxID, &user.Username, &user.CreatedAt, &user.LastLogin, &user.IsActive, &user.Role, &user.FarmPrivacy,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -99,6 +99,18 @@ func (r *UserRepo) Update(ctx context.Context, user *model.User) error {
 	return nil
 }
 
+// SetFarmPrivacy изменяет настройку приватности фермы пользователя
+func (r *UserRepo) SetFarmPrivacy(ctx context.Context, userID int64, privacy string) error {
+	result, err := r.db.Exec(ctx, `UPDATE user_info SET farm_privacy = $1 WHERE id = $2`, privacy, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user with id=%d not found", userID)
+	}
+	return nil
+}
+
 func (r *UserRepo) Delete(ctx context.Context, id int64) error {
 	query := `DELETE FROM "user_info" WHERE id = $1`
 	result, err := r.db.Exec(ctx, query, id)
@@ -116,7 +128,7 @@ func (r *UserRepo) Delete(ctx context.Context, id int64) error {
 
 func (r *UserRepo) GetAllActiveUsers(ctx context.Context) ([]model.User, error) {
 	query := `
-		SELECT id, max_id, username, created_at, last_login, is_active 
+		SELECT id, max_id, username, created_at, last_login, is_active, role, farm_privacy
 		FROM user_info 
 		WHERE is_active = true
 	`
@@ -137,6 +149,8 @@ func (r *UserRepo) GetAllActiveUsers(ctx context.Context) ([]model.User, error)
 			&user.CreatedAt,
 			&user.LastLogin,
 			&user.IsActive,
+			&user.Role,
+			&user.FarmPrivacy,
 		)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UserItemRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewUserItemRepo(db *pgxpool.Pool) *UserItemRepo {
+	return &UserItemRepo{db: db}
+}
+
+// GetByUser возвращает инвентарь предметов пользователя (только предметы в наличии)
+func (r *UserItemRepo) GetByUser(ctx context.Context, userID int64) ([]model.UserItemWithDetails, error) {
+	query := `
+		SELECT i.id, i.code, i.name, i.description, i.icon, i.type, ui.quantity, ui.updated_at
+		FROM user_item ui
+		INNER JOIN item i ON i.id = ui.item_id
+		WHERE ui.user_id = $1 AND ui.quantity > 0
+		ORDER BY i.id
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []model.UserItemWithDetails{}
+	for rows.Next() {
+		var item model.UserItemWithDetails
+		if err := rows.Scan(
+			&item.ItemID, &item.Code, &item.Name, &item.Description, &item.Icon, &item.Type,
+			&item.Quantity, &item.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// GetQuantity возвращает количество предмета с кодом code в инвентаре пользователя
+func (r *UserItemRepo) GetQuantity(ctx context.Context, userID int64, code string) (int, error) {
+	query := `
+		SELECT COALESCE(SUM(ui.quantity), 0)
+		FROM user_item ui
+		INNER JOIN item i ON i.id = ui.item_id
+		WHERE ui.user_id = $1 AND i.code = $2
+	`
+	var quantity int
+	err := r.db.QueryRow(ctx, query, userID, code).Scan(&quantity)
+	return quantity, err
+}
+
+// Add добавляет предметы в инвентарь пользователя и возвращает новое количество
+func (r *UserItemRepo) Add(ctx context.Context, userID int64, itemID int, amount int) (int, error) {
+	return addUserItem(ctx, r.db, userID, itemID, amount)
+}
+
+// grantUserItem выдает предметы по коду в транзакции tx и фиксирует выдачу в логе прогресса
+func grantUserItem(ctx context.Context, tx pgx.Tx, userID int64, code string, amount int, source, description string) (*model.ItemGrant, error) {
+	grant := &model.ItemGrant{Code: code, Amount: amount}
+	err := tx.QueryRow(ctx, `SELECT id, name FROM item WHERE code = $1`, code).Scan(&grant.ItemID, &grant.Name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("item with code=%s not found", code)
+		}
+		return nil, err
+	}
+
+	grant.Quantity, err = addUserItem(ctx, tx, userID, grant.ItemID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO progress_log (user_id, xp_earned, gold_earned, source, description, created_at)
+		VALUES ($1, 0, 0, $2, $3, $4)
+	`, userID, source, description, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+// addUserItem увеличивает количество предмета в инвентаре и возвращает новое количество
+func addUserItem(ctx context.Context, q DBTX, userID int64, itemID int, amount int) (int, error) {
+	query := `
+		INSERT INTO user_item (user_id, item_id, quantity, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id, item_id)
+		DO UPDATE SET quantity = user_item.quantity + EXCLUDED.quantity, updated_at = NOW()
+		RETURNING quantity
+	`
+	var quantity int
+	err := q.QueryRow(ctx, query, userID, itemID, amount).Scan(&quantity)
+	return quantity, err
+}
+
+// consumeUserItem списывает предметы с кодом code и возвращает оставшееся количество.
+// Если предметов недостаточно, возвращает ошибку "not enough ...".
+func consumeUserItem(ctx context.Context, q DBTX, userID int64, code string, amount int) (int, error) {
+	query := `
+		UPDATE user_item ui
+		SET quantity = ui.quantity - $3, updated_at = NOW()
+		FROM item i
+		WHERE i.id = ui.item_id AND ui.user_id = $1 AND i.code = $2 AND ui.quantity >= $3
+		RETURNING ui.quantity
+	`
+	var remaining int
+	err := q.QueryRow(ctx, query, userID, code, amount).Scan(&remaining)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("not enough %s", code)
+		}
+		return 0, err
+	}
+	return remaining, nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/RinatHar/FarmFocus/api/internal/utils"
 	"github.com/jackc/pgx/v5"
@@ -19,20 +21,178 @@ func NewUserPlantRepo(db *pgxpool.Pool) *UserPlantRepo {
 	return &UserPlantRepo{db: db}
 }
 
-func (r *UserPlantRepo) MarkPlantsAsWithered(ctx context.Context, userID int64) error {
-	query := `UPDATE user_plants SET is_withered = true WHERE user_id = $1 AND is_withered = false`
-	_, err := r.db.Exec(ctx, query, userID)
-	return err
+// RemoveDeadPlants удаляет растения, засохшие более lifetimeDays дней назад, и записывает их гибель в историю
+func (r *UserPlantRepo) RemoveDeadPlants(ctx context.Context, userID int64, lifetimeDays int) (int64, error) {
+	query := archivePlantsQuery(`
+		up.user_id = $3 AND up.is_withered = true
+		AND up.withered_at <= NOW() - make_interval(days => $4)
+	`)
+	result, err := r.db.Exec(ctx, query, constants.PlantEventDied, constants.PlantDeathWithered, userID, lifetimeDays)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-func (r *UserPlantRepo) RemoveWitheredPlants(ctx context.Context, userID int64) error {
-	query := `DELETE FROM user_plants WHERE user_id = $1 AND is_withered = true`
-	_, err := r.db.Exec(ctx, query, userID)
-	return err
+// DecayWitheredPlants уменьшает рост засохших растений пользователя на decayPercent процентов
+// от целевого роста семени (минимум на 1, но не ниже нуля) и возвращает количество растений
+func (r *UserPlantRepo) DecayWitheredPlants(ctx context.Context, userID int64, decayPercent int) (int64, error) {
+	query := `
+		UPDATE user_plant up
+		SET current_growth = GREATEST(up.current_growth - GREATEST(s.target_growth * $2 / 100, 1), 0)
+		FROM seed s
+		WHERE s.id = up.seed_id AND up.user_id = $1 AND up.is_withered = true AND up.current_growth > 0
+	`
+	result, err := r.db.Exec(ctx, query, userID, decayPercent)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// Archive убирает растение с грядки и записывает событие в историю растений
+func (r *UserPlantRepo) Archive(ctx context.Context, id int, event string, reason *string) error {
+	query := archivePlantsQuery(`up.id = $3`)
+	result, err := r.db.Exec(ctx, query, event, reason, id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user plant with id=%d not found", id)
+	}
+	return nil
+}
+
+// Harvest собирает растение в одной транзакции: блокирует его, проверяет готовность,
+// убирает с грядки в историю растений и только затем начисляет урожай, опыт, выпавшие семена
+// и гибриды и продвигает задания на сбор. Повторный сбор того же растения не найдет его.
+func (r *UserPlantRepo) Harvest(ctx context.Context, harvest *model.Harvest) (*model.HarvestRecord, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var isWithered bool
+	var currentGrowth, targetGrowth int
+	var seedName string
+	err = tx.QueryRow(ctx, `
+		SELECT up.is_withered, up.current_growth, s.target_growth, s.name
+		FROM user_plant up
+		JOIN seed s ON s.id = up.seed_id
+		WHERE up.id = $1 AND up.user_id = $2
+		FOR UPDATE OF up
+	`, harvest.PlantID, harvest.UserID).Scan(&isWithered, &currentGrowth, &targetGrowth, &seedName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("user plant with id=%d not found", harvest.PlantID)
+		}
+		return nil, err
+	}
+	if isWithered {
+		return nil, fmt.Errorf("plant is withered and cannot be harvested")
+	}
+	if currentGrowth < targetGrowth {
+		return nil, fmt.Errorf("plant is not ready for harvest")
+	}
+
+	if _, err := tx.Exec(ctx, archivePlantsQuery(`up.id = $3`),
+		constants.PlantEventHarvested, nil, harvest.PlantID); err != nil {
+		return nil, err
+	}
+
+	record := &model.HarvestRecord{}
+	record.ProduceTotal, err = addProduce(ctx, tx, harvest.UserID, harvest.SeedID, harvest.Produce)
+	if err != nil {
+		return nil, err
+	}
+
+	record.Experience, err = addExperience(ctx, tx, harvest.UserID, int64(harvest.XP))
+	if err != nil {
+		return nil, err
+	}
+
+	// Опыт за сбор записывается в лог прогресса, по которому считается опыт за сезон
+	now := time.Now()
+	if harvest.XP > 0 {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO progress_log (user_id, xp_earned, gold_earned, source, description, created_at)
+			VALUES ($1, $2, 0, $3, $4, $5)
+		`, harvest.UserID, harvest.XP, constants.ProgressSourceHarvest, "Сбор урожая «"+seedName+"»", now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := incrementHarvested(ctx, tx, harvest.UserID); err != nil {
+		return nil, err
+	}
+
+	for _, drop := range harvest.SeedDrops {
+		if err := putInventory(ctx, tx, harvest.UserID, constants.ExchangeKindSeed, drop.SeedID, drop.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range harvest.Hybrids {
+		breed := &harvest.Hybrids[i]
+		breed.IsNewDiscovery, err = recordBreed(ctx, tx, harvest.UserID, breed.RecipeID, breed.SeedID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	record.CompletedQuests, err = advanceQuests(ctx, tx, harvest.UserID, now,
+		model.QuestEvent{SeedID: &harvest.SeedID}, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// plantEffectBonusColumns — суммарные бонусы растения up: действующие эффекты предметов
+// и пассивные бонусы декораций на соседних клетках поля
+var plantEffectBonusColumns = `
+	(COALESCE((SELECT SUM(pe.value) FROM plant_effect pe
+	           WHERE pe.user_plant_id = up.id AND pe.effect_type = '` + constants.EffectGrowthBoost + `'
+	             AND ` + activePlantEffectCondition + `), 0)
+	 + ` + decorationBonusQuery(constants.EffectGrowthBoost) + `)::float8 AS growth_bonus,
+	(COALESCE((SELECT SUM(pe.value) FROM plant_effect pe
+	           WHERE pe.user_plant_id = up.id AND pe.effect_type = '` + constants.EffectHarvestYield + `'
+	             AND ` + activePlantEffectCondition + `), 0)
+	 + ` + decorationBonusQuery(constants.EffectHarvestYield) + `)::float8 AS harvest_yield_bonus`
+
+// decorationBonusQuery возвращает сумму бонусов типа bonusType от декораций, стоящих рядом с грядкой растения up
+func decorationBonusQuery(bonusType string) string {
+	return `COALESCE((SELECT SUM(d.bonus_value) FROM user_decoration ud
+	           INNER JOIN decoration d ON d.id = ud.decoration_id
+	           INNER JOIN bed db ON db.id = up.bed_id
+	           WHERE ud.user_id = up.user_id AND d.bonus_type = '` + bonusType + `'
+	             AND ABS(ud.x - db.x) + ABS(ud.y - db.y) = 1), 0)`
+}
+
+// archivePlantsQuery строит запрос, который удаляет растения по условию и переносит их в plant_history.
+// Параметры: $1 — событие, $2 — причина, далее — параметры условия.
+func archivePlantsQuery(condition string) string {
+	return `
+		WITH removed AS (
+			DELETE FROM user_plant up
+			WHERE ` + condition + `
+			RETURNING up.id, up.user_id, up.seed_id, up.bed_id, up.current_growth, up.created_at, up.withered_at
+		)
+		INSERT INTO plant_history (user_plant_id, user_id, seed_id, bed_id, event, reason, final_growth, planted_at, withered_at)
+		SELECT id, user_id, seed_id, bed_id, $1::varchar, $2::varchar, current_growth, created_at, withered_at
+		FROM removed
+	`
 }
 
 func (r *UserPlantRepo) ResetWitheredStatus(ctx context.Context, userID int64) error {
-	query := `UPDATE user_plants SET is_withered = false WHERE user_id = $1`
+	query := `UPDATE user_plant SET is_withered = false, withered_at = NULL WHERE user_id = $1`
 	_, err := r.db.Exec(ctx, query, userID)
 	return err
 }
@@ -48,15 +208,70 @@ func (r *UserPlantRepo) Create(ctx context.Context, plant *model.UserPlant) erro
 	).Scan(&plant.ID)
 }
 
+// Plant сажает семя seedID на грядку в клетке (x, y) в одной транзакции: блокирует грядку,
+// как и расстановка декораций, проверяет, что она открыта и свободна от растения и декорации,
+// и списывает одно семя
+func (r *UserPlantRepo) Plant(ctx context.Context, userID int64, seedID int, x, y int) (*model.UserPlant, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	plant := &model.UserPlant{UserID: userID, SeedID: seedID}
+	var isLocked, hasPlant, decorated bool
+	err = tx.QueryRow(ctx, `
+		SELECT b.id, b.is_locked,
+		       EXISTS(SELECT 1 FROM user_plant up WHERE up.bed_id = b.id),
+		       EXISTS(SELECT 1 FROM user_decoration ud WHERE ud.user_id = b.user_id AND ud.x = b.x AND ud.y = b.y)
+		FROM bed b
+		WHERE b.user_id = $1 AND b.x = $2 AND b.y = $3
+		FOR UPDATE OF b
+	`, userID, x, y).Scan(&plant.BedID, &isLocked, &hasPlant, &decorated)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("bed at (%d, %d) not found", x, y)
+		}
+		return nil, err
+	}
+	if isLocked {
+		return nil, fmt.Errorf("bed is locked")
+	}
+	if hasPlant {
+		return nil, fmt.Errorf("bed is already occupied")
+	}
+	if decorated {
+		return nil, fmt.Errorf("cell is occupied by a decoration")
+	}
+
+	if err := takeInventory(ctx, tx, userID, constants.ExchangeKindSeed, seedID, 1); err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO user_plant (user_id, seed_id, bed_id, current_growth, created_at)
+		VALUES ($1, $2, $3, 0, NOW())
+		RETURNING id, created_at
+	`, userID, seedID, plant.BedID).Scan(&plant.ID, &plant.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return plant, nil
+}
+
 func (r *UserPlantRepo) GetByID(ctx context.Context, id int) (*model.UserPlant, error) {
 	var plant model.UserPlant
 	query := `
-		SELECT id, user_id, seed_id, bed_id, current_growth, created_at
+		SELECT id, user_id, seed_id, bed_id, current_growth, is_withered, withered_at, created_at
 		FROM user_plant
 		WHERE id = $1
 	`
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&plant.ID, &plant.UserID, &plant.SeedID, &plant.BedID, &plant.CurrentGrowth, &plant.CreatedAt,
+		&plant.ID, &plant.UserID, &plant.SeedID, &plant.BedID, &plant.CurrentGrowth, &plant.IsWithered, &plant.WitheredAt, &plant.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -69,7 +284,7 @@ func (r *UserPlantRepo) GetByID(ctx context.Context, id int) (*model.UserPlant,
 
 func (r *UserPlantRepo) GetByUser(ctx context.Context, userID int64) ([]model.UserPlant, error) {
 	query := `
-		SELECT id, user_id, seed_id, bed_id, current_growth, created_at
+		SELECT id, user_id, seed_id, bed_id, current_growth, is_withered, withered_at, created_at
 		FROM user_plant
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -84,7 +299,7 @@ func (r *UserPlantRepo) GetByUser(ctx context.Context, userID int64) ([]model.Us
 	for rows.Next() {
 		var plant model.UserPlant
 		if err := rows.Scan(
-			&plant.ID, &plant.UserID, &plant.SeedID, &plant.BedID, &plant.CurrentGrowth, &plant.CreatedAt,
+			&plant.ID, &plant.UserID, &plant.SeedID, &plant.BedID, &plant.CurrentGrowth, &plant.IsWithered, &plant.WitheredAt, &plant.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -96,12 +311,12 @@ func (r *UserPlantRepo) GetByUser(ctx context.Context, userID int64) ([]model.Us
 func (r *UserPlantRepo) GetByBed(ctx context.Context, bedID int) (*model.UserPlant, error) {
 	var plant model.UserPlant
 	query := `
-		SELECT id, user_id, seed_id, bed_id, current_growth, created_at
+		SELECT id, user_id, seed_id, bed_id, current_growth, is_withered, withered_at, created_at
 		FROM user_plant
 		WHERE bed_id = $1
 	`
 	err := r.db.QueryRow(ctx, query, bedID).Scan(
-		&plant.ID, &plant.UserID, &plant.SeedID, &plant.BedID, &plant.CurrentGrowth, &plant.CreatedAt,
+		&plant.ID, &plant.UserID, &plant.SeedID, &plant.BedID, &plant.CurrentGrowth, &plant.IsWithered, &plant.WitheredAt, &plant.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -130,22 +345,28 @@ func (r *UserPlantRepo) UpdateGrowth(ctx context.Context, id int, growth int) er
 	return nil
 }
 
-func (r *UserPlantRepo) AddGrowth(ctx context.Context, id int, amount int) (int, error) {
-	query := `
-		UPDATE user_plant
-		SET current_growth = current_growth + $1
-		WHERE id = $2
-		RETURNING id, current_growth
-	`
-	var updatedID, newGrowth int
-	err := r.db.QueryRow(ctx, query, amount, id).Scan(&updatedID, &newGrowth)
+// addPlantGrowth блокирует растение и прибавляет ему дробные очки роста gain
+func addPlantGrowth(ctx context.Context, q DBTX, id int, gain float64) (int, error) {
+	var growth int
+	var fraction float64
+	err := q.QueryRow(ctx, `
+		SELECT current_growth, growth_fraction FROM user_plant WHERE id = $1 FOR UPDATE
+	`, id).Scan(&growth, &fraction)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return 0, fmt.Errorf("user plant with id=%d not found", id)
 		}
 		return 0, err
 	}
-	return newGrowth, nil
+
+	newGrowth, newFraction := utils.AddPlantGrowth(growth, fraction, gain)
+	_, err = q.Exec(ctx, `
+		UPDATE user_plant SET current_growth = $1, growth_fraction = $2 WHERE id = $3
+	`, newGrowth, newFraction, id)
+	if err != nil {
+		return 0, err
+	}
+	return newGrowth - growth, nil
 }
 
 func (r *UserPlantRepo) Delete(ctx context.Context, id int) error {
@@ -178,8 +399,10 @@ func (r *UserPlantRepo) DeleteByBed(ctx context.Context, bedID int) error {
 
 func (r *UserPlantRepo) GetWithSeedDetails(ctx context.Context, userID int64) ([]model.UserPlantWithSeed, error) {
 	query := `
-		SELECT up.id, up.user_id, up.seed_id, up.bed_id, up.current_growth, up.is_withered, up.created_at,
-		       s.name as seed_name, s.icon as seed_icon, s.img_plant as seed_img_plant, s.target_growth, s.gold_reward, s.xp_reward
+		SELECT up.id, up.user_id, up.seed_id, up.bed_id, up.current_growth, up.is_withered, up.withered_at, up.created_at,
+		       s.name as seed_name, s.icon as seed_icon, s.img_plant as seed_img_plant, s.target_growth, s.gold_reward, s.xp_reward, s.modification,
+		       s.stage_thresholds,
+		       ` + plantEffectBonusColumns + `
 		FROM user_plant up
 		INNER JOIN seed s ON up.seed_id = s.id
 		WHERE up.user_id = $1
@@ -194,13 +417,17 @@ func (r *UserPlantRepo) GetWithSeedDetails(ctx context.Context, userID int64) ([
 	plants := []model.UserPlantWithSeed{}
 	for rows.Next() {
 		var plant model.UserPlantWithSeed
+		var thresholds []int
 		if err := rows.Scan(
-			&plant.ID, &plant.UserID, &plant.SeedID, &plant.BedID, &plant.CurrentGrowth, &plant.IsWithered, &plant.CreatedAt,
-			&plant.SeedName, &plant.SeedIcon, &plant.SeedImgPlant, &plant.TargetGrowth, &plant.GoldReward, &plant.XPReward,
+			&plant.ID, &plant.UserID, &plant.SeedID, &plant.BedID, &plant.CurrentGrowth, &plant.IsWithered, &plant.WitheredAt, &plant.CreatedAt,
+			&plant.SeedName, &plant.SeedIcon, &plant.SeedImgPlant, &plant.TargetGrowth, &plant.GoldReward, &plant.XPReward, &plant.Modification,
+			&thresholds, &plant.GrowthBonus, &plant.HarvestYieldBonus,
 		); err != nil {
 			return nil, err
 		}
 		plant.GrowthPercent = utils.CalculateGrowthPercent(plant.CurrentGrowth, plant.TargetGrowth)
+		setPlantStage(&plant, thresholds)
+		plant.GrowthMultiplier = utils.GrowthMultiplier(plant.Modification + plant.GrowthBonus)
 		plants = append(plants, plant)
 	}
 	return plants, nil
@@ -209,10 +436,11 @@ func (r *UserPlantRepo) GetWithSeedDetails(ctx context.Context, userID int64) ([
 func (r *UserPlantRepo) GetReadyForHarvest(ctx context.Context, userID int64) ([]model.UserPlantWithSeed, error) {
 	query := `
 		SELECT up.id, up.user_id, up.seed_id, up.bed_id, up.current_growth, up.created_at,
-		       s.name as seed_name, s.icon as seed_icon, s.target_growth, s.gold_reward, s.xp_reward
+		       s.name as seed_name, s.icon as seed_icon, s.img_plant as seed_img_plant, s.target_growth, s.gold_reward, s.xp_reward, s.modification,
+		       s.stage_thresholds
 		FROM user_plant up
 		INNER JOIN seed s ON up.seed_id = s.id
-		WHERE up.user_id = $1 AND up.current_growth >= s.target_growth
+		WHERE up.user_id = $1 AND up.is_withered = false AND up.current_growth >= s.target_growth
 		ORDER BY up.created_at DESC
 	`
 	rows, err := r.db.Query(ctx, query, userID)
@@ -224,13 +452,17 @@ func (r *UserPlantRepo) GetReadyForHarvest(ctx context.Context, userID int64) ([
 	plants := []model.UserPlantWithSeed{}
 	for rows.Next() {
 		var plant model.UserPlantWithSeed
+		var thresholds []int
 		if err := rows.Scan(
 			&plant.ID, &plant.UserID, &plant.SeedID, &plant.BedID, &plant.CurrentGrowth, &plant.CreatedAt,
-			&plant.SeedName, &plant.SeedIcon, &plant.TargetGrowth, &plant.GoldReward, &plant.XPReward,
+			&plant.SeedName, &plant.SeedIcon, &plant.SeedImgPlant, &plant.TargetGrowth, &plant.GoldReward, &plant.XPReward, &plant.Modification,
+			&thresholds,
 		); err != nil {
 			return nil, err
 		}
+		setPlantStage(&plant, thresholds)
 		plant.GrowthPercent = 100 // готовы к сбору
+		plant.GrowthMultiplier = utils.GrowthMultiplier(plant.Modification)
 		plants = append(plants, plant)
 	}
 	return plants, nil
@@ -239,10 +471,11 @@ func (r *UserPlantRepo) GetReadyForHarvest(ctx context.Context, userID int64) ([
 func (r *UserPlantRepo) GetGrowingPlants(ctx context.Context, userID int64) ([]model.UserPlantWithSeed, error) {
 	query := `
 		SELECT up.id, up.user_id, up.seed_id, up.bed_id, up.current_growth, up.created_at,
-		       s.name as seed_name, s.icon as seed_icon, s.target_growth, s.gold_reward, s.xp_reward
+		       s.name as seed_name, s.icon as seed_icon, s.img_plant as seed_img_plant, s.target_growth, s.gold_reward, s.xp_reward, s.modification,
+		       s.stage_thresholds
 		FROM user_plant up
 		INNER JOIN seed s ON up.seed_id = s.id
-		WHERE up.user_id = $1 AND up.current_growth < s.target_growth
+		WHERE up.user_id = $1 AND up.is_withered = false AND up.current_growth < s.target_growth
 		ORDER BY up.created_at DESC
 	`
 	rows, err := r.db.Query(ctx, query, userID)
@@ -254,20 +487,24 @@ func (r *UserPlantRepo) GetGrowingPlants(ctx context.Context, userID int64) ([]m
 	plants := []model.UserPlantWithSeed{}
 	for rows.Next() {
 		var plant model.UserPlantWithSeed
+		var thresholds []int
 		if err := rows.Scan(
 			&plant.ID, &plant.UserID, &plant.SeedID, &plant.BedID, &plant.CurrentGrowth, &plant.CreatedAt,
-			&plant.SeedName, &plant.SeedIcon, &plant.TargetGrowth, &plant.GoldReward, &plant.XPReward,
+			&plant.SeedName, &plant.SeedIcon, &plant.SeedImgPlant, &plant.TargetGrowth, &plant.GoldReward, &plant.XPReward, &plant.Modification,
+			&thresholds,
 		); err != nil {
 			return nil, err
 		}
+		setPlantStage(&plant, thresholds)
 		plant.GrowthPercent = utils.CalculateGrowthPercent(plant.CurrentGrowth, plant.TargetGrowth)
+		plant.GrowthMultiplier = utils.GrowthMultiplier(plant.Modification)
 		plants = append(plants, plant)
 	}
 	return plants, nil
 }
 
 func (r *UserPlantRepo) MarkAsWithered(ctx context.Context, plantID int) error {
-	query := `UPDATE user_plant SET is_withered = true WHERE id = $1`
+	query := `UPDATE user_plant SET is_withered = true, withered_at = COALESCE(withered_at, NOW()) WHERE id = $1`
 
 	result, err := r.db.Exec(ctx, query, plantID)
 	if err != nil {
@@ -281,3 +518,10 @@ func (r *UserPlantRepo) MarkAsWithered(ctx context.Context, plantID int) error {
 
 	return nil
 }
+
+// setPlantStage заполняет стадию роста растения и путь к картинке стадии по порогам семени
+func setPlantStage(plant *model.UserPlantWithSeed, thresholds []int) {
+	plant.Stage, plant.StageCount, plant.StageImgPath = utils.PlantStage(
+		plant.SeedImgPlant, plant.CurrentGrowth, plant.TargetGrowth, thresholds,
+	)
+}
@@ -183,11 +183,12 @@ func (r *UserSeedRepo) GetAvailableSeedsForUser(ctx context.Context, userID int6
 
 	query := `
         SELECT s.id, s.name, s.icon, s.level_required, s.target_growth, s.rarity, 
-               s.modification, s.gold_reward, s.xp_reward, s.created_at,
+               s.modification, s.gold_reward, s.xp_reward, s.is_hybrid, s.stage_thresholds, s.created_at,
                COALESCE(us.quantity, 0) as user_quantity
         FROM seed s
         LEFT JOIN user_seed us ON s.id = us.seed_id AND us.user_id = $1
         WHERE s.level_required <= $2
+          AND (NOT s.is_hybrid OR COALESCE(us.quantity, 0) > 0)
         ORDER BY s.level_required, s.rarity DESC, s.name
     `
 	rows, err := r.db.Query(ctx, query, userID, userLevel)
@@ -202,7 +203,7 @@ func (r *UserSeedRepo) GetAvailableSeedsForUser(ctx context.Context, userID int6
 		var userQuantity int64
 		if err := rows.Scan(
 			&seed.ID, &seed.Name, &seed.Icon, &seed.LevelRequired, &seed.TargetGrowth,
-			&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.CreatedAt,
+			&seed.Rarity, &seed.Modification, &seed.GoldReward, &seed.XPReward, &seed.IsHybrid, &seed.StageThresholds, &seed.CreatedAt,
 			&userQuantity,
 		); err != nil {
 			return nil, err
@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -39,14 +41,15 @@ func (r *UserStatRepo) GetByUserID(ctx context.Context, userID int64) (*model.Us
 	var stat model.UserStat
 	query := `
 		SELECT id, user_id, experience, gold, total_tasks_completed, total_plant_harvested, 
-		       current_streak, longest_streak, is_drought, updated_at
+		       current_streak, longest_streak, is_drought, drought_days, drought_severity, updated_at
 		FROM user_stat 
 		WHERE user_id = $1
 	`
 	err := r.db.QueryRow(ctx, query, userID).Scan(
 		&stat.ID, &stat.UserID, &stat.Experience, &stat.Gold,
 		&stat.TotalTasksCompleted, &stat.TotalPlantHarvested,
-		&stat.CurrentStreak, &stat.LongestStreak, &stat.IsDrought, &stat.UpdatedAt,
+		&stat.CurrentStreak, &stat.LongestStreak, &stat.IsDrought,
+		&stat.DroughtDays, &stat.DroughtSeverity, &stat.UpdatedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -75,62 +78,121 @@ func (r *UserStatRepo) Update(ctx context.Context, stat *model.UserStat) error {
 	return err
 }
 
-// RemoveExperience удаляет опыт у пользователя
-func (r *UserStatRepo) RemoveExperience(ctx context.Context, userID int64, amount int64) error {
-	query := `
-		UPDATE user_stat 
-		SET experience = GREATEST(0, experience - $1), updated_at = $2
-		WHERE user_id = $3
-	`
+// AddGold начисляет золото пользователю и записывает изменение в журнал золота
+func (r *UserStatRepo) AddGold(ctx context.Context, userID int64, amount int64, source, description string) error {
+	return r.changeGold(ctx, userID, amount, goldChangeAllowNegative, source, description)
+}
 
-	_, err := r.db.Exec(ctx, query, amount, time.Now(), userID)
-	return err
+// RemoveGold списывает золото у пользователя, не опуская баланс ниже нуля, и записывает изменение в журнал
+func (r *UserStatRepo) RemoveGold(ctx context.Context, userID int64, amount int64, source, description string) error {
+	return r.changeGold(ctx, userID, -amount, goldChangeClampToZero, source, description)
 }
 
-func (r *UserStatRepo) AddExperience(ctx context.Context, userID int64, amount int64) error {
-	query := `UPDATE user_stat SET experience = experience + $1, updated_at = NOW() WHERE user_id = $2`
-	_, err := r.db.Exec(ctx, query, amount, userID)
-	return err
+// SpendGold списывает золото, только если его достаточно; иначе возвращает ошибку "not enough gold"
+func (r *UserStatRepo) SpendGold(ctx context.Context, userID int64, amount int64, source, description string) error {
+	return r.changeGold(ctx, userID, -amount, goldChangeRequireBalance, source, description)
 }
 
-func (r *UserStatRepo) AddGold(ctx context.Context, userID int64, amount int64) error {
-	query := `UPDATE user_stat SET gold = gold + $1, updated_at = NOW() WHERE user_id = $2`
-	_, err := r.db.Exec(ctx, query, amount, userID)
-	return err
+func (r *UserStatRepo) changeGold(ctx context.Context, userID int64, amount int64, mode goldChangeMode, source, description string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := changeGold(ctx, tx, userID, amount, mode, source, description); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// incrementHarvested атомарно увеличивает счетчик собранных растений
+func incrementHarvested(ctx context.Context, q DBTX, userID int64) error {
+	result, err := q.Exec(ctx, `
+		UPDATE user_stat SET total_plant_harvested = total_plant_harvested + 1, updated_at = NOW() WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user stat not found for user_id=%d", userID)
+	}
+	return nil
 }
 
-func (r *UserStatRepo) IncrementStreak(ctx context.Context, userID int64) error {
+// incrementStreak увеличивает стрик в транзакции tx и выдает лейку за каждую полную серию дней
+func incrementStreak(ctx context.Context, tx pgx.Tx, userID int64) (*model.ItemGrant, error) {
 	query := `
-		UPDATE user_stat 
-		SET current_streak = current_streak + 1, 
+		UPDATE user_stat
+		SET current_streak = current_streak + 1,
 		    longest_streak = GREATEST(longest_streak, current_streak + 1),
-		    updated_at = NOW() 
+		    updated_at = NOW()
 		WHERE user_id = $1
+		RETURNING current_streak
 	`
-	_, err := r.db.Exec(ctx, query, userID)
-	return err
+	var streak int
+	if err := tx.QueryRow(ctx, query, userID).Scan(&streak); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("user stat not found for user_id=%d", userID)
+		}
+		return nil, err
+	}
+
+	if streak%constants.WateringCanStreakInterval != 0 {
+		return nil, nil
+	}
+
+	description := fmt.Sprintf("Награда за стрик %d дней: лейка x%d", streak, constants.StreakWateringCanReward)
+	return grantUserItem(ctx, tx, userID, constants.ItemWateringCan, constants.StreakWateringCanReward,
+		constants.ProgressSourceStreakReward, description)
 }
 
-func (r *UserStatRepo) ResetStreak(ctx context.Context, userID int64) error {
-	query := `UPDATE user_stat SET current_streak = 0, updated_at = NOW() WHERE user_id = $1`
-	_, err := r.db.Exec(ctx, query, userID)
-	return err
+// ResetStreakIfMissed обнуляет стрик, если за день day не было ни одного выполнения задачи или привычки.
+// Если выполнение уже есть сегодня (день day+1), стрик начинается заново с 1.
+func (r *UserStatRepo) ResetStreakIfMissed(ctx context.Context, userID int64, day time.Time) (bool, error) {
+	query := `
+		UPDATE user_stat
+		SET current_streak = CASE WHEN EXISTS (
+		        SELECT 1 FROM progress_log
+		        WHERE user_id = $1 AND DATE(created_at) = $3
+		          AND source IN ($4, $5) AND xp_earned > 0 AND undone_at IS NULL
+		    ) THEN 1 ELSE 0 END,
+		    updated_at = NOW()
+		WHERE user_id = $1 AND current_streak > 0
+		  AND NOT EXISTS (
+		        SELECT 1 FROM progress_log
+		        WHERE user_id = $1 AND DATE(created_at) = $2
+		          AND source IN ($4, $5) AND xp_earned > 0 AND undone_at IS NULL
+		    )
+	`
+	result, err := r.db.Exec(ctx, query, userID, day.Format("2006-01-02"), day.AddDate(0, 0, 1).Format("2006-01-02"),
+		constants.ProgressSourceTask, constants.ProgressSourceHabit)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
 }
 
-// Новые методы для работы с засухой
+// Методы для работы с засухой
+
+// SetDroughtState сохраняет состояние засухи пользователя
+func (r *UserStatRepo) SetDroughtState(ctx context.Context, userID int64, days, severity int) error {
+	return setDroughtState(ctx, r.db, userID, days, severity)
+}
 
-// SetDrought устанавливает состояние засухи для пользователя
-func (r *UserStatRepo) SetDrought(ctx context.Context, userID int64, isDrought bool) error {
-	query := `UPDATE user_stat SET is_drought = $1, updated_at = NOW() WHERE user_id = $2`
-	_, err := r.db.Exec(ctx, query, isDrought, userID)
+func setDroughtState(ctx context.Context, q DBTX, userID int64, days, severity int) error {
+	query := `
+		UPDATE user_stat
+		SET drought_days = $1, drought_severity = $2, is_drought = $2 > 0, updated_at = NOW()
+		WHERE user_id = $3
+	`
+	_, err := q.Exec(ctx, query, days, severity, userID)
 	return err
 }
 
 // ResetDrought сбрасывает засуху (устанавливает is_drought = false)
 func (r *UserStatRepo) ResetDrought(ctx context.Context, userID int64) error {
-	query := `UPDATE user_stat SET is_drought = false, updated_at = NOW() WHERE user_id = $1`
-	_, err := r.db.Exec(ctx, query, userID)
-	return err
+	return r.SetDroughtState(ctx, userID, 0, 0)
 }
 
 // GetDroughtStatus возвращает статус засухи пользователя
@@ -143,15 +205,3 @@ func (r *UserStatRepo) GetDroughtStatus(ctx context.Context, userID int64) (bool
 	}
 	return isDrought, nil
 }
-
-// CheckAndSetDrought проверяет и устанавливает засуху на основе последней активности
-func (r *UserStatRepo) CheckAndSetDrought(ctx context.Context, userID int64, lastActivity time.Time) error {
-	// Если с последней активности прошло больше 3 дней - устанавливаем засуху
-	query := `
-		UPDATE user_stat 
-		SET is_drought = (NOW() - $1::timestamp) > INTERVAL '3 days'
-		WHERE user_id = $2
-	`
-	_, err := r.db.Exec(ctx, query, lastActivity, userID)
-	return err
-}
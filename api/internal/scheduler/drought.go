@@ -7,29 +7,33 @@ import (
 
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
 )
 
 type DroughtScheduler struct {
-	taskRepo      *repository.TaskRepo
-	habitRepo     *repository.HabitRepo
-	userPlantRepo *repository.UserPlantRepo
-	userRepo      *repository.UserRepo
-	checkTime     string // Формат "HH:MM", например "03:00"
+	taskRepo       *repository.TaskRepo
+	habitRepo      *repository.HabitRepo
+	userRepo       *repository.UserRepo
+	userStatRepo   *repository.UserStatRepo
+	droughtService *service.DroughtService
+	checkTime      string // Формат "HH:MM", например "03:00"
 }
 
 func NewDroughtScheduler(
 	taskRepo *repository.TaskRepo,
 	habitRepo *repository.HabitRepo,
-	userPlantRepo *repository.UserPlantRepo,
 	userRepo *repository.UserRepo,
+	userStatRepo *repository.UserStatRepo,
+	droughtService *service.DroughtService,
 	checkTime string,
 ) *DroughtScheduler {
 	return &DroughtScheduler{
-		taskRepo:      taskRepo,
-		habitRepo:     habitRepo,
-		userPlantRepo: userPlantRepo,
-		userRepo:      userRepo,
-		checkTime:     checkTime,
+		taskRepo:       taskRepo,
+		habitRepo:      habitRepo,
+		userRepo:       userRepo,
+		userStatRepo:   userStatRepo,
+		droughtService: droughtService,
+		checkTime:      checkTime,
 	}
 }
 
@@ -93,16 +97,31 @@ func (s *DroughtScheduler) checkDroughtForAllUsers() {
 }
 
 func (s *DroughtScheduler) checkDroughtForUser(ctx context.Context, userID int64) {
+	s.resetStreakIfMissed(ctx, userID)
+
 	hasUncompletedTasks, err := s.hasUncompletedTasksFromYesterday(ctx, userID)
 	if err != nil {
 		log.Printf("Error checking uncompleted tasks for user %d: %v", userID, err)
 		return
 	}
 
+	// Засуха ослабевает только при активности пользователя (выполнение задач и привычек)
 	if hasUncompletedTasks {
 		s.applyDrought(ctx, userID)
-	} else {
-		s.removeDrought(ctx, userID)
+	}
+}
+
+// resetStreakIfMissed обнуляет стрик пользователя, если вчера не было ни одного выполнения
+func (s *DroughtScheduler) resetStreakIfMissed(ctx context.Context, userID int64) {
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	reset, err := s.userStatRepo.ResetStreakIfMissed(ctx, userID, yesterday)
+	if err != nil {
+		log.Printf("Error resetting streak for user %d: %v", userID, err)
+		return
+	}
+	if reset {
+		log.Printf("Streak reset for user %d - no completions yesterday", userID)
 	}
 }
 
@@ -160,31 +179,12 @@ func (s *DroughtScheduler) shouldHabitBeCompletedYesterday(habit model.Habit, ye
 }
 
 func (s *DroughtScheduler) applyDrought(ctx context.Context, userID int64) {
-	log.Printf("Applying drought for user %d", userID)
-
-	plants, err := s.userPlantRepo.GetByUser(ctx, userID)
+	event, err := s.droughtService.RegisterMissedDay(ctx, userID)
 	if err != nil {
-		log.Printf("Error getting plants for user %d: %v", userID, err)
+		log.Printf("Error applying drought for user %d: %v", userID, err)
 		return
 	}
 
-	witheredCount := 0
-	for _, plant := range plants {
-		if !plant.IsWithered {
-			err := s.userPlantRepo.MarkAsWithered(ctx, plant.ID)
-			if err != nil {
-				log.Printf("Error marking plant %d as withered: %v", plant.ID, err)
-			} else {
-				witheredCount++
-			}
-		}
-	}
-
-	log.Printf("Withered %d plants for user %d", witheredCount, userID)
-}
-
-func (s *DroughtScheduler) removeDrought(ctx context.Context, userID int64) {
-	// В будущем можно добавить логику восстановления растений
-	// Пока просто логируем
-	log.Printf("No drought for user %d - all tasks completed yesterday", userID)
+	log.Printf("Drought for user %d: day %d, severity %d (withered %d, died %d in total)",
+		userID, event.Days, event.Severity, event.PlantsWithered, event.PlantsDied)
 }
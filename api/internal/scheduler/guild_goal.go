@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+)
+
+type GuildGoalScheduler struct {
+	guildService *service.GuildService
+	rolloverTime string // Формат "HH:MM"
+}
+
+func NewGuildGoalScheduler(
+	guildService *service.GuildService,
+	rolloverTime string, // Время в формате "HH:MM"
+) *GuildGoalScheduler {
+	return &GuildGoalScheduler{
+		guildService: guildService,
+		rolloverTime: rolloverTime,
+	}
+}
+
+func (s *GuildGoalScheduler) Start() {
+	log.Printf("Starting guild goal scheduler, will run daily at %s", s.rolloverTime)
+
+	// Цели выдаются раз в неделю, но проверка выполняется ежедневно:
+	// если запуск в понедельник пропущен, гильдии получат цель на следующий день
+	go s.runDailyAt(s.rolloverTime)
+}
+
+func (s *GuildGoalScheduler) runDailyAt(timeStr string) {
+	for {
+		executionTime, err := time.Parse("15:04", timeStr)
+		if err != nil {
+			log.Printf("Error parsing time %s: %v", timeStr, err)
+			return
+		}
+
+		now := time.Now()
+		next := time.Date(
+			now.Year(), now.Month(), now.Day(),
+			executionTime.Hour(), executionTime.Minute(), 0, 0,
+			now.Location(),
+		)
+
+		if now.After(next) {
+			next = next.Add(24 * time.Hour)
+		}
+
+		duration := next.Sub(now)
+		log.Printf("Next guild goal rollover at: %s (in %v)", next.Format("2006-01-02 15:04:05"), duration)
+
+		time.Sleep(duration)
+		s.rollover()
+	}
+}
+
+func (s *GuildGoalScheduler) rollover() {
+	rolled, err := s.guildService.RolloverGoals(context.Background())
+	if err != nil {
+		log.Printf("Error rolling guild goals: %v", err)
+		return
+	}
+	log.Printf("Guild goals rolled for %d guilds", rolled)
+}
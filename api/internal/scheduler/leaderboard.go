@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+)
+
+type LeaderboardScheduler struct {
+	leaderboardService *service.LeaderboardService
+	interval           time.Duration
+}
+
+func NewLeaderboardScheduler(
+	leaderboardService *service.LeaderboardService,
+	interval time.Duration,
+) *LeaderboardScheduler {
+	return &LeaderboardScheduler{
+		leaderboardService: leaderboardService,
+		interval:           interval,
+	}
+}
+
+func (s *LeaderboardScheduler) Start() {
+	log.Printf("Starting leaderboard scheduler, will run every %v", s.interval)
+
+	// Считаем снимки сразу при старте, затем с заданным интервалом
+	go s.runEvery(s.interval)
+}
+
+func (s *LeaderboardScheduler) runEvery(interval time.Duration) {
+	s.refresh()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.refresh()
+	}
+}
+
+func (s *LeaderboardScheduler) refresh() {
+	start := time.Now()
+	if err := s.leaderboardService.Refresh(context.Background()); err != nil {
+		log.Printf("Error refreshing leaderboards: %v", err)
+		return
+	}
+	log.Printf("Leaderboards refreshed in %v", time.Since(start))
+}
@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+)
+
+type QuestScheduler struct {
+	userRepo     *repository.UserRepo
+	questService *service.QuestService
+	rollTime     string // Формат "HH:MM"
+}
+
+func NewQuestScheduler(
+	userRepo *repository.UserRepo,
+	questService *service.QuestService,
+	rollTime string, // Время в формате "HH:MM"
+) *QuestScheduler {
+	return &QuestScheduler{
+		userRepo:     userRepo,
+		questService: questService,
+		rollTime:     rollTime,
+	}
+}
+
+func (s *QuestScheduler) Start() {
+	log.Printf("Starting daily quest scheduler, will run daily at %s", s.rollTime)
+
+	// Запускаем ежедневную выдачу заданий в указанное время
+	go s.runDailyAt(s.rollTime)
+}
+
+func (s *QuestScheduler) runDailyAt(timeStr string) {
+	for {
+		executionTime, err := time.Parse("15:04", timeStr)
+		if err != nil {
+			log.Printf("Error parsing time %s: %v", timeStr, err)
+			return
+		}
+
+		now := time.Now()
+		next := time.Date(
+			now.Year(), now.Month(), now.Day(),
+			executionTime.Hour(), executionTime.Minute(), 0, 0,
+			now.Location(),
+		)
+
+		if now.After(next) {
+			next = next.Add(24 * time.Hour)
+		}
+
+		duration := next.Sub(now)
+		log.Printf("Next daily quest roll at: %s (in %v)", next.Format("2006-01-02 15:04:05"), duration)
+
+		time.Sleep(duration)
+		s.rollForAllUsers()
+	}
+}
+
+func (s *QuestScheduler) rollForAllUsers() {
+	ctx := context.Background()
+
+	users, err := s.userRepo.GetAllActiveUsers(ctx)
+	if err != nil {
+		log.Printf("Error getting active users for daily quests: %v", err)
+		return
+	}
+
+	log.Printf("Rolling daily quests for %d users", len(users))
+	rolledCount := 0
+	for _, user := range users {
+		if err := s.questService.RollDaily(ctx, user.ID); err != nil {
+			log.Printf("Error rolling daily quests for user %d: %v", user.ID, err)
+			continue
+		}
+		rolledCount++
+	}
+	log.Printf("Daily quests rolled for %d users", rolledCount)
+}
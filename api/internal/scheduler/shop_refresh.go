@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
 	"github.com/RinatHar/FarmFocus/api/internal/model"
 	"github.com/RinatHar/FarmFocus/api/internal/repository"
 )
@@ -13,17 +14,20 @@ import (
 type ShopRefreshScheduler struct {
 	goodRepo *repository.GoodRepo
 	userRepo *repository.UserRepo
+	itemRepo *repository.ItemRepo
 	shopTime string // Формат "HH:MM"
 }
 
 func NewShopRefreshScheduler(
 	goodRepo *repository.GoodRepo,
 	userRepo *repository.UserRepo,
+	itemRepo *repository.ItemRepo,
 	shopTime string, // Время в формате "HH:MM"
 ) *ShopRefreshScheduler {
 	return &ShopRefreshScheduler{
 		goodRepo: goodRepo,
 		userRepo: userRepo,
+		itemRepo: itemRepo,
 		shopTime: shopTime,
 	}
 }
@@ -97,18 +101,29 @@ func (s *ShopRefreshScheduler) refreshShopForUser(ctx context.Context, userID in
 	return nil
 }
 
+type shopQuantity struct {
+	goodType string
+	idGood   int
+	quantity int
+	cost     int
+}
+
 func (s *ShopRefreshScheduler) updateShopQuantities(ctx context.Context, userID int64) error {
 	// Фиксированные количества для товаров
-	shopQuantities := []struct {
-		goodType string
-		idGood   int
-		quantity int
-		cost     int
-	}{
+	shopQuantities := []shopQuantity{
 		{"seed", 2, 5, 4},  // Баклажан
 		{"seed", 1, 10, 1}, // Пшеница
 	}
 
+	// Предметы ищутся по коду, а не по идентификатору
+	for _, shopItem := range constants.ShopItemGoods {
+		item, err := s.itemRepo.GetByCode(ctx, shopItem.Code)
+		if err != nil {
+			return fmt.Errorf("failed to get shop item %s: %w", shopItem.Code, err)
+		}
+		shopQuantities = append(shopQuantities, shopQuantity{item.Type, item.ID, shopItem.Quantity, shopItem.Cost})
+	}
+
 	for _, item := range shopQuantities {
 		// Пытаемся найти существующий товар
 		existingGood, err := s.goodRepo.GetByUserTypeAndIDGood(ctx, userID, item.goodType, item.idGood)
@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/service"
+)
+
+type WitheredPlantScheduler struct {
+	userRepo         *repository.UserRepo
+	lifecycleService *service.PlantLifecycleService
+	checkTime        string // Формат "HH:MM"
+}
+
+func NewWitheredPlantScheduler(
+	userRepo *repository.UserRepo,
+	lifecycleService *service.PlantLifecycleService,
+	checkTime string, // Время в формате "HH:MM"
+) *WitheredPlantScheduler {
+	return &WitheredPlantScheduler{
+		userRepo:         userRepo,
+		lifecycleService: lifecycleService,
+		checkTime:        checkTime,
+	}
+}
+
+func (s *WitheredPlantScheduler) Start() {
+	log.Printf("Starting withered plant scheduler, will run daily at %s (withered plants die after %d days)",
+		s.checkTime, s.lifecycleService.WitheredLifetimeDays())
+
+	// Запускаем ежедневную обработку в указанное время
+	go s.runDailyAt(s.checkTime)
+}
+
+func (s *WitheredPlantScheduler) runDailyAt(timeStr string) {
+	for {
+		executionTime, err := time.Parse("15:04", timeStr)
+		if err != nil {
+			log.Printf("Error parsing time %s: %v", timeStr, err)
+			return
+		}
+
+		now := time.Now()
+		next := time.Date(
+			now.Year(), now.Month(), now.Day(),
+			executionTime.Hour(), executionTime.Minute(), 0, 0,
+			now.Location(),
+		)
+
+		if now.After(next) {
+			next = next.Add(24 * time.Hour)
+		}
+
+		duration := next.Sub(now)
+		log.Printf("Next withered plant check at: %s (in %v)", next.Format("2006-01-02 15:04:05"), duration)
+
+		time.Sleep(duration)
+		s.processAllUsers()
+		time.Sleep(24 * time.Hour)
+	}
+}
+
+func (s *WitheredPlantScheduler) processAllUsers() {
+	ctx := context.Background()
+
+	users, err := s.userRepo.GetAllActiveUsers(ctx)
+	if err != nil {
+		log.Printf("Error getting active users for withered plant check: %v", err)
+		return
+	}
+
+	log.Printf("Processing withered plants for %d users", len(users))
+	for _, user := range users {
+		decayed, died, err := s.lifecycleService.ProcessWithered(ctx, user.ID)
+		if err != nil {
+			log.Printf("Error processing withered plants for user %d: %v", user.ID, err)
+			continue
+		}
+
+		if decayed > 0 || died > 0 {
+			log.Printf("User %d: %d withered plants decayed, %d died", user.ID, decayed, died)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+)
+
+// AchievementService проверяет и открывает достижения по событиям предметной области.
+// Определения достижений (счетчики, пороги, события, награды) хранятся в таблице achievement.
+type AchievementService struct {
+	achievementRepo *repository.AchievementRepo
+}
+
+func NewAchievementService(achievementRepo *repository.AchievementRepo) *AchievementService {
+	return &AchievementService{achievementRepo: achievementRepo}
+}
+
+// GetForUser возвращает все достижения с прогрессом пользователя
+func (s *AchievementService) GetForUser(ctx context.Context, userID int64) ([]model.UserAchievement, error) {
+	return s.achievementRepo.GetForUser(ctx, userID)
+}
+
+// Evaluate вызывается после событий, меняющих счетчики пользователя (выполнение, сбор урожая).
+// Открывает достижения, чей счетчик достиг порога, а также достижения событий events,
+// и выдает награды. Уже открытые достижения повторно не открываются.
+func (s *AchievementService) Evaluate(ctx context.Context, userID int64, events ...string) ([]model.AchievementUnlock, error) {
+	return s.achievementRepo.UnlockEligible(ctx, userID, events)
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+)
+
+// AdminService выполняет ручные изменения прогресса пользователей администратором.
+// Каждое изменение записывается в admin_audit_log вместе с причиной.
+type AdminService struct {
+	auditRepo    *repository.AdminAuditRepo
+	levelService *LevelService
+}
+
+func NewAdminService(auditRepo *repository.AdminAuditRepo, levelService *LevelService) *AdminService {
+	return &AdminService{
+		auditRepo:    auditRepo,
+		levelService: levelService,
+	}
+}
+
+// AdjustExperience изменяет опыт пользователя; при повышении уровня выдаются обычные награды за уровень
+func (s *AdminService) AdjustExperience(ctx context.Context, adminID, userID, amount int64, reason string) (*model.AdminOverrideResult, error) {
+	entry, rewards, err := s.auditRepo.AdjustExperience(ctx, adminID, userID, amount, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	levelUp, err := s.levelService.LevelUp(ctx, &model.ExperienceChange{
+		Before:  entry.ValueBefore,
+		After:   entry.ValueAfter,
+		Rewards: rewards,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AdminOverrideResult{Entry: *entry, LevelUp: levelUp}, nil
+}
+
+// AdjustGold изменяет золото пользователя
+func (s *AdminService) AdjustGold(ctx context.Context, adminID, userID, amount int64, reason string) (*model.AdminOverrideResult, error) {
+	entry, err := s.auditRepo.AdjustGold(ctx, adminID, userID, amount, reason)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AdminOverrideResult{Entry: *entry}, nil
+}
+
+// SetStreak устанавливает стрик пользователя
+func (s *AdminService) SetStreak(ctx context.Context, adminID, userID int64, streak int, reason string) (*model.AdminOverrideResult, error) {
+	entry, err := s.auditRepo.SetStreak(ctx, adminID, userID, streak, reason)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AdminOverrideResult{Entry: *entry}, nil
+}
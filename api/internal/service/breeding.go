@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+)
+
+// BreedingService скрещивает зрелые растения на соседних грядках при сборе урожая
+type BreedingService struct {
+	hybridRepo *repository.HybridRepo
+	bedRepo    *repository.BedRepo
+	seedRepo   *repository.SeedRepo
+
+	mu  sync.Mutex
+	rng utils.RandomSource
+}
+
+// NewBreedingService создает сервис с источником случайных чисел rng.
+// С фиксированным seed броски скрещивания детерминированы.
+func NewBreedingService(
+	hybridRepo *repository.HybridRepo,
+	bedRepo *repository.BedRepo,
+	seedRepo *repository.SeedRepo,
+	rng utils.RandomSource,
+) *BreedingService {
+	return &BreedingService{
+		hybridRepo: hybridRepo,
+		bedRepo:    bedRepo,
+		seedRepo:   seedRepo,
+		rng:        rng,
+	}
+}
+
+type recipeKey struct {
+	a, b int
+}
+
+func newRecipeKey(seedA, seedB int) recipeKey {
+	if seedA > seedB {
+		seedA, seedB = seedB, seedA
+	}
+	return recipeKey{a: seedA, b: seedB}
+}
+
+// BreedOnHarvest скрещивает собираемое растение harvested с каждым зрелым незасохшим растением
+// на соседней грядке. Если для пары семян есть рецепт, с шансом рецепта выводится гибридное семя.
+// plants — все растения пользователя на поле. Гибриды выдаются вместе с остальными наградами
+// за сбор (UserPlantRepo.Harvest), там же заполняется IsNewDiscovery.
+func (s *BreedingService) BreedOnHarvest(
+	ctx context.Context,
+	userID int64,
+	harvested *model.UserPlantWithSeed,
+	plants []model.UserPlantWithSeed,
+) ([]model.HybridBreed, error) {
+	breeds := []model.HybridBreed{}
+
+	recipes, err := s.hybridRepo.GetRecipes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipes) == 0 {
+		return breeds, nil
+	}
+	recipeByParents := make(map[recipeKey]model.SeedRecipe, len(recipes))
+	for _, recipe := range recipes {
+		recipeByParents[newRecipeKey(recipe.ParentASeedID, recipe.ParentBSeedID)] = recipe
+	}
+
+	beds, err := s.bedRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	bedByID := make(map[int]model.Bed, len(beds))
+	for _, bed := range beds {
+		bedByID[bed.ID] = bed
+	}
+	harvestedBed, ok := bedByID[harvested.BedID]
+	if !ok {
+		return breeds, nil
+	}
+
+	for _, partner := range plants {
+		if partner.ID == harvested.ID || partner.IsWithered || partner.CurrentGrowth < partner.TargetGrowth {
+			continue
+		}
+		partnerBed, ok := bedByID[partner.BedID]
+		if !ok || !utils.CellsAdjacent(harvestedBed.X, harvestedBed.Y, partnerBed.X, partnerBed.Y) {
+			continue
+		}
+
+		recipe, ok := recipeByParents[newRecipeKey(harvested.SeedID, partner.SeedID)]
+		if !ok || !s.roll(recipe.Chance) {
+			continue
+		}
+
+		seed, err := s.seedRepo.GetByID(ctx, recipe.ResultSeedID)
+		if err != nil {
+			return nil, err
+		}
+
+		breeds = append(breeds, model.HybridBreed{
+			SeedDrop:       newSeedDrop(seed, 1, false),
+			RecipeID:       recipe.ID,
+			PartnerPlantID: partner.ID,
+		})
+	}
+	return breeds, nil
+}
+
+func (s *BreedingService) roll(chance float64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Float64() < chance
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+)
+
+// DroughtService управляет состоянием засухи пользователя.
+//
+// Правила:
+//   - каждый пропущенный день (ежедневная проверка) увеличивает тяжесть на 1, максимум DroughtSeverityMax;
+//   - тяжесть 1 замедляет рост растений, 2 — растения засыхают, 3 — засохшие растения погибают;
+//   - первое выполнение задачи или привычки за день снижает тяжесть на 1;
+//   - при тяжести 0 засуха завершается и закрывается запись в истории drought_event.
+//
+// Каждый переход выполняется в одной транзакции вместе с записью drought_event и последствиями для растений.
+type DroughtService struct {
+	droughtEventRepo *repository.DroughtEventRepo
+}
+
+func NewDroughtService(droughtEventRepo *repository.DroughtEventRepo) *DroughtService {
+	return &DroughtService{
+		droughtEventRepo: droughtEventRepo,
+	}
+}
+
+// RegisterMissedDay усиливает засуху после пропущенного дня и применяет ее последствия к растениям
+func (s *DroughtService) RegisterMissedDay(ctx context.Context, userID int64) (*model.DroughtEvent, error) {
+	return s.droughtEventRepo.RegisterMissedDay(ctx, userID)
+}
+
+// State возвращает текущее состояние засухи по статистике пользователя
+func (s *DroughtService) State(stats *model.UserStat) *model.DroughtState {
+	return &model.DroughtState{
+		IsDrought:        stats.DroughtSeverity > constants.DroughtSeverityNone,
+		Days:             stats.DroughtDays,
+		Severity:         stats.DroughtSeverity,
+		GrowthMultiplier: utils.DroughtGrowthMultiplier(stats.DroughtSeverity),
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+)
+
+// FarmService строит сетку поля и расширяет поле
+type FarmService struct {
+	bedRepo        *repository.BedRepo
+	userPlantRepo  *repository.UserPlantRepo
+	decorationRepo *repository.DecorationRepo
+}
+
+func NewFarmService(
+	bedRepo *repository.BedRepo,
+	userPlantRepo *repository.UserPlantRepo,
+	decorationRepo *repository.DecorationRepo,
+) *FarmService {
+	return &FarmService{
+		bedRepo:        bedRepo,
+		userPlantRepo:  userPlantRepo,
+		decorationRepo: decorationRepo,
+	}
+}
+
+// Layout возвращает поле пользователя в виде сетки: все грядки, включая закрытые и пустые, с растениями и декорациями
+func (s *FarmService) Layout(ctx context.Context, userID int64) (*model.FarmLayout, error) {
+	beds, err := s.bedRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plants, err := s.userPlantRepo.GetWithSeedDetails(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	plantByBed := make(map[int]*model.UserPlantWithSeed, len(plants))
+	for i := range plants {
+		plantByBed[plants[i].BedID] = &plants[i]
+	}
+
+	decorations, err := s.decorationRepo.GetPlaced(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	decorationByCell := make(map[[2]int]*model.PlacedDecoration, len(decorations))
+	for i := range decorations {
+		decorationByCell[[2]int{decorations[i].X, decorations[i].Y}] = &decorations[i]
+	}
+
+	expansions, err := s.bedRepo.GetExpansionCount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := &model.FarmLayout{
+		MaxWidth:          constants.MaxFarmWidth,
+		MaxHeight:         constants.MaxFarmHeight,
+		NextExpansionCost: utils.FarmExpansionCost(expansions),
+		Cells:             make([]model.FarmCell, 0, len(beds)),
+	}
+	for _, bed := range beds {
+		layout.Width = max(layout.Width, bed.X+1)
+		layout.Height = max(layout.Height, bed.Y+1)
+
+		plant := plantByBed[bed.ID]
+		decoration := decorationByCell[[2]int{bed.X, bed.Y}]
+		layout.Cells = append(layout.Cells, model.FarmCell{
+			Bed:        bed,
+			IsEmpty:    plant == nil && decoration == nil && !bed.IsLocked,
+			Plant:      plant,
+			Decoration: decoration,
+		})
+	}
+	layout.CanExpandRow = layout.Height < constants.MaxFarmHeight
+	layout.CanExpandColumn = layout.Width < constants.MaxFarmWidth
+
+	return layout, nil
+}
+
+// Expand покупает расширение поля на один ряд или столбец
+func (s *FarmService) Expand(ctx context.Context, userID int64, direction string) (*model.FarmExpansion, error) {
+	return s.bedRepo.Expand(ctx, userID, direction)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+)
+
+// FriendService управляет заявками в друзья и показывает фермы друзей с учетом их настроек приватности
+type FriendService struct {
+	friendRepo   *repository.FriendRepo
+	userRepo     *repository.UserRepo
+	userStatRepo *repository.UserStatRepo
+	bedRepo      *repository.BedRepo
+}
+
+func NewFriendService(
+	friendRepo *repository.FriendRepo,
+	userRepo *repository.UserRepo,
+	userStatRepo *repository.UserStatRepo,
+	bedRepo *repository.BedRepo,
+) *FriendService {
+	return &FriendService{
+		friendRepo:   friendRepo,
+		userRepo:     userRepo,
+		userStatRepo: userStatRepo,
+		bedRepo:      bedRepo,
+	}
+}
+
+// SendRequest отправляет заявку в друзья пользователю с Max ID maxID.
+// Если этот пользователь уже отправил встречную заявку, пользователи сразу становятся друзьями (accepted = true).
+func (s *FriendService) SendRequest(ctx context.Context, userID int64, maxID int64) (*model.FriendRequest, bool, error) {
+	target, err := s.userRepo.GetByMaxID(ctx, maxID)
+	if err != nil {
+		return nil, false, err
+	}
+	if target == nil {
+		return nil, false, fmt.Errorf("user with max_id=%d not found", maxID)
+	}
+	if target.ID == userID {
+		return nil, false, fmt.Errorf("cannot send friend request to yourself")
+	}
+	return s.friendRepo.CreateRequest(ctx, userID, target.ID)
+}
+
+// GetFarm возвращает ферму друга friendID только для просмотра.
+// При приватности level_only возвращаются только уровень и статистика, при hidden — ошибка.
+func (s *FriendService) GetFarm(ctx context.Context, userID, friendID int64) (*model.FriendFarm, error) {
+	isFriend, err := s.friendRepo.AreFriends(ctx, userID, friendID)
+	if err != nil {
+		return nil, err
+	}
+	if !isFriend {
+		return nil, fmt.Errorf("friend with id=%d not found", friendID)
+	}
+
+	friend, err := s.userRepo.GetByID(ctx, friendID)
+	if err != nil {
+		return nil, err
+	}
+	if friend.FarmPrivacy == constants.FarmPrivacyHidden {
+		return nil, fmt.Errorf("farm is hidden by privacy settings")
+	}
+
+	stats, err := s.userStatRepo.GetByUserID(ctx, friendID)
+	if err != nil {
+		return nil, err
+	}
+
+	farm := &model.FriendFarm{
+		UserID:      friend.ID,
+		Username:    friend.Username,
+		FarmPrivacy: friend.FarmPrivacy,
+		Level:       stats.Level(),
+		Stats: model.FriendFarmStats{
+			CurrentStreak:       stats.CurrentStreak,
+			LongestStreak:       stats.LongestStreak,
+			TotalPlantHarvested: stats.TotalPlantHarvested,
+		},
+	}
+
+	if friend.FarmPrivacy == constants.FarmPrivacyFull {
+		farm.Beds, err = s.bedRepo.GetWithPlants(ctx, friendID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return farm, nil
+}
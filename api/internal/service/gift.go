@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+)
+
+// GiftLimits — настраиваемые дневные лимиты подарков и предложений обмена
+type GiftLimits struct {
+	DailySendLimit    int
+	DailyReceiveLimit int
+}
+
+// GiftService отправляет подарки и предложения обмена друзьям.
+// Подарки и обмены возможны только между друзьями и ограничены дневными лимитами на отправку и получение,
+// чтобы нельзя было перекачивать ресурсы со вторых аккаунтов.
+type GiftService struct {
+	giftRepo  *repository.GiftRepo
+	tradeRepo *repository.TradeRepo
+	seedRepo  *repository.SeedRepo
+	limits    GiftLimits
+}
+
+func NewGiftService(
+	giftRepo *repository.GiftRepo,
+	tradeRepo *repository.TradeRepo,
+	seedRepo *repository.SeedRepo,
+	limits GiftLimits,
+) *GiftService {
+	return &GiftService{
+		giftRepo:  giftRepo,
+		tradeRepo: tradeRepo,
+		seedRepo:  seedRepo,
+		limits:    limits,
+	}
+}
+
+// SendGift отправляет подарок другу. Подарок сразу списывается у отправителя
+// и возвращается ему, если получатель отклонит подарок или отправитель его отменит.
+func (s *GiftService) SendGift(ctx context.Context, userID int64, gift model.Gift) (*model.Gift, error) {
+	if gift.RecipientID == userID {
+		return nil, fmt.Errorf("invalid recipient: cannot send gift to yourself")
+	}
+	if err := s.validateLine(ctx, gift.Kind, gift.SeedID, gift.Amount); err != nil {
+		return nil, err
+	}
+
+	gift.SenderID = userID
+	return s.giftRepo.Create(ctx, &gift, s.limits.DailySendLimit, s.limits.DailyReceiveLimit)
+}
+
+// ProposeTrade создает предложение обмена другу. Наличие позиций у обеих сторон
+// проверяется при принятии, когда обмен выполняется целиком или не выполняется вовсе.
+func (s *GiftService) ProposeTrade(ctx context.Context, userID int64, trade model.TradeOffer) (*model.TradeOffer, error) {
+	if trade.RecipientID == userID {
+		return nil, fmt.Errorf("invalid recipient: cannot trade with yourself")
+	}
+	if len(trade.Offer) == 0 || len(trade.Request) == 0 {
+		return nil, fmt.Errorf("invalid trade: offer and request must not be empty")
+	}
+	if len(trade.Offer) > constants.MaxTradeLines || len(trade.Request) > constants.MaxTradeLines {
+		return nil, fmt.Errorf("invalid trade: at most %d lines per side", constants.MaxTradeLines)
+	}
+	for _, line := range slices.Concat(trade.Offer, trade.Request) {
+		if err := s.validateLine(ctx, line.Kind, line.SeedID, line.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	trade.ProposerID = userID
+	return s.tradeRepo.Create(ctx, &trade, s.limits.DailySendLimit, s.limits.DailyReceiveLimit)
+}
+
+// validateLine проверяет тип, семя и количество позиции подарка или обмена
+func (s *GiftService) validateLine(ctx context.Context, kind string, seedID int, amount int) error {
+	if !slices.Contains(constants.ValidExchangeKinds, kind) {
+		return fmt.Errorf("invalid kind: %s", kind)
+	}
+	if amount < 1 || amount > constants.MaxExchangeAmount {
+		return fmt.Errorf("invalid amount: must be between 1 and %d", constants.MaxExchangeAmount)
+	}
+	_, err := s.seedRepo.GetByID(ctx, seedID)
+	return err
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+)
+
+// GuildService управляет гильдиями и их общими недельными целями.
+// Цель выдается гильдии на каждую неделю (с понедельника) планировщиком или при первом обращении;
+// недовыполненная цель прошлой недели сгорает без награды.
+type GuildService struct {
+	guildRepo    *repository.GuildRepo
+	levelService *LevelService
+
+	mu  sync.Mutex
+	rng utils.RandomSource
+}
+
+// NewGuildService создает сервис с источником случайных чисел rng для выбора шаблонов целей
+func NewGuildService(guildRepo *repository.GuildRepo, levelService *LevelService, rng utils.RandomSource) *GuildService {
+	return &GuildService{
+		guildRepo:    guildRepo,
+		levelService: levelService,
+		rng:          rng,
+	}
+}
+
+// Create создает гильдию с пользователем во главе и выдает ей цель на текущую неделю
+func (s *GuildService) Create(ctx context.Context, userID int64, name string) (*model.GuildInfo, error) {
+	name = strings.TrimSpace(name)
+	if length := utf8.RuneCountInString(name); length < constants.GuildNameMinLength || length > constants.GuildNameMaxLength {
+		return nil, fmt.Errorf("invalid guild name: must be between %d and %d characters",
+			constants.GuildNameMinLength, constants.GuildNameMaxLength)
+	}
+
+	guild, err := s.guildRepo.Create(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, guild.ID)
+}
+
+// Join добавляет пользователя в гильдию guildID
+func (s *GuildService) Join(ctx context.Context, userID int64, guildID int) (*model.GuildInfo, error) {
+	if err := s.guildRepo.Join(ctx, userID, guildID, constants.GuildMaxMembers); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, guildID)
+}
+
+// Leave удаляет пользователя из его гильдии
+func (s *GuildService) Leave(ctx context.Context, userID int64) error {
+	return s.guildRepo.Leave(ctx, userID)
+}
+
+// GetMine возвращает гильдию пользователя
+func (s *GuildService) GetMine(ctx context.Context, userID int64) (*model.GuildInfo, error) {
+	guildID, err := s.guildRepo.GetGuildIDByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if guildID == nil {
+		return nil, fmt.Errorf("user is not in a guild")
+	}
+	return s.Get(ctx, *guildID)
+}
+
+// Get возвращает гильдию с участниками и целью текущей недели, выдавая цель, если планировщик еще не успел
+func (s *GuildService) Get(ctx context.Context, guildID int) (*model.GuildInfo, error) {
+	guild, err := s.guildRepo.GetByID(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := s.ensureGoal(ctx, guildID, utils.SeasonStart(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	var goalID *int
+	if goal != nil {
+		goalID = &goal.ID
+	}
+	members, err := s.guildRepo.GetMembers(ctx, guildID, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.GuildInfo{Guild: *guild, Members: members, Goal: goal}, nil
+}
+
+// Contribute сдает урожай в цель гильдии пользователя. Если вклад завершает цель, награду вместе
+// с наградами за достигнутые уровни получает каждый участник, вступивший до выдачи цели и внесший
+// не меньше минимального вклада.
+func (s *GuildService) Contribute(ctx context.Context, userID int64, amount int) (*model.GuildContribution, error) {
+	if amount < 1 {
+		return nil, fmt.Errorf("invalid amount: must be positive")
+	}
+
+	guildID, err := s.guildRepo.GetGuildIDByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if guildID == nil {
+		return nil, fmt.Errorf("user is not in a guild")
+	}
+
+	weekStart := utils.SeasonStart(time.Now())
+	if _, err := s.ensureGoal(ctx, *guildID, weekStart); err != nil {
+		return nil, err
+	}
+
+	contribution, err := s.guildRepo.Contribute(ctx, userID, weekStart, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reward := range contribution.Rewards {
+		if reward.UserID != userID {
+			continue
+		}
+		contribution.LevelUp, err = s.levelService.LevelUp(ctx, reward.Experience)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return contribution, nil
+}
+
+// RolloverGoals выдает цели на текущую неделю всем гильдиям, у которых их еще нет
+func (s *GuildService) RolloverGoals(ctx context.Context) (int, error) {
+	weekStart := utils.SeasonStart(time.Now())
+	guildIDs, err := s.guildRepo.GetGuildsWithoutGoal(ctx, weekStart)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, guildID := range guildIDs {
+		if _, err := s.ensureGoal(ctx, guildID, weekStart); err != nil {
+			return 0, fmt.Errorf("failed to roll goal for guild %d: %w", guildID, err)
+		}
+	}
+	return len(guildIDs), nil
+}
+
+// ensureGoal возвращает цель гильдии на неделю weekStart, выдавая ее по случайному активному шаблону.
+// Если активных шаблонов нет, возвращает nil.
+func (s *GuildService) ensureGoal(ctx context.Context, guildID int, weekStart time.Time) (*model.GuildGoal, error) {
+	goal, err := s.guildRepo.GetGoal(ctx, guildID, weekStart)
+	if err != nil || goal != nil {
+		return goal, err
+	}
+
+	templates, err := s.guildRepo.GetTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(templates) == 0 {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	template := templates[s.rng.Intn(len(templates))]
+	s.mu.Unlock()
+
+	if err := s.guildRepo.CreateGoal(ctx, guildID, weekStart, template); err != nil {
+		return nil, err
+	}
+	return s.guildRepo.GetGoal(ctx, guildID, weekStart)
+}
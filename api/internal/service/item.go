@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+)
+
+// ItemService применяет предметы из инвентаря к растениям
+type ItemService struct {
+	itemRepo         *repository.ItemRepo
+	plantEffectRepo  *repository.PlantEffectRepo
+	plantRevivalRepo *repository.PlantRevivalRepo
+}
+
+func NewItemService(
+	itemRepo *repository.ItemRepo,
+	plantEffectRepo *repository.PlantEffectRepo,
+	plantRevivalRepo *repository.PlantRevivalRepo,
+) *ItemService {
+	return &ItemService{
+		itemRepo:         itemRepo,
+		plantEffectRepo:  plantEffectRepo,
+		plantRevivalRepo: plantRevivalRepo,
+	}
+}
+
+// GetItem находит предмет по идентификатору или, если он не задан, по коду
+func (s *ItemService) GetItem(ctx context.Context, itemID int, code string) (*model.Item, error) {
+	if itemID > 0 {
+		return s.itemRepo.GetByID(ctx, itemID)
+	}
+	if code != "" {
+		return s.itemRepo.GetByCode(ctx, code)
+	}
+	return nil, fmt.Errorf("itemId or itemCode is required")
+}
+
+// Apply применяет предмет к растению пользователя и списывает его из инвентаря.
+//   - revive: оживляет засохшее растение;
+//   - instant_growth: сразу добавляет очки роста;
+//   - growth_boost, harvest_yield: добавляют эффект, который складывается с другими
+//     (до max_stacks эффектов предмета) и действует duration_hours часов или до сбора.
+func (s *ItemService) Apply(ctx context.Context, userID int64, plantID int, item *model.Item) (*model.ItemApplyResult, error) {
+	if item.EffectType == nil {
+		return nil, fmt.Errorf("item %s cannot be applied to plants", item.Code)
+	}
+
+	result := &model.ItemApplyResult{
+		ItemID:     item.ID,
+		ItemCode:   item.Code,
+		EffectType: *item.EffectType,
+	}
+
+	switch *item.EffectType {
+	case constants.EffectRevive:
+		if item.Code != constants.ItemWateringCan {
+			return nil, fmt.Errorf("item %s cannot be applied to plants", item.Code)
+		}
+		revival, remaining, err := s.plantRevivalRepo.ReviveWithWateringCan(ctx, userID, plantID)
+		if err != nil {
+			return nil, err
+		}
+		result.Revival = revival
+		result.Remaining = remaining
+
+	case constants.EffectInstantGrowth:
+		newGrowth, remaining, err := s.plantEffectRepo.ApplyInstantGrowth(ctx, userID, plantID, item)
+		if err != nil {
+			return nil, err
+		}
+		result.NewGrowth = &newGrowth
+		result.Remaining = remaining
+
+	case constants.EffectGrowthBoost, constants.EffectHarvestYield:
+		effect, remaining, err := s.plantEffectRepo.AddEffect(ctx, userID, plantID, item)
+		if err != nil {
+			return nil, err
+		}
+		result.Effect = effect
+		result.Remaining = remaining
+
+	default:
+		return nil, fmt.Errorf("unknown item effect: %s", *item.EffectType)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+)
+
+// LeaderboardService показывает рейтинги по снимкам, которые пересчитывает планировщик,
+// поэтому запрос рейтинга не пересчитывает значения всех пользователей.
+// Рейтинги ведутся по недельным сезонам: опыт за неделю начинается с нуля в каждом сезоне,
+// а снимки прошлых сезонов сохраняются с итоговыми местами.
+type LeaderboardService struct {
+	leaderboardRepo *repository.LeaderboardRepo
+}
+
+func NewLeaderboardService(leaderboardRepo *repository.LeaderboardRepo) *LeaderboardService {
+	return &LeaderboardService{leaderboardRepo: leaderboardRepo}
+}
+
+// Get возвращает рейтинг по метрике metric в области scope за сезон, в который попадает day
+func (s *LeaderboardService) Get(ctx context.Context, userID int64, metric, scope string, day time.Time) (*model.Leaderboard, error) {
+	if !slices.Contains(constants.ValidLeaderboardMetrics, metric) {
+		return nil, fmt.Errorf("invalid metric: %s", metric)
+	}
+	if !slices.Contains(constants.ValidLeaderboardScopes, scope) {
+		return nil, fmt.Errorf("invalid scope: %s", scope)
+	}
+
+	seasonStart := utils.SeasonStart(day)
+	computedAt, err := s.leaderboardRepo.GetComputedAt(ctx, seasonStart)
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboard := &model.Leaderboard{
+		Metric:      metric,
+		Scope:       scope,
+		SeasonStart: seasonStart,
+		SeasonEnd:   utils.SeasonEnd(seasonStart),
+		ComputedAt:  computedAt,
+		Entries:     []model.LeaderboardEntry{},
+	}
+	if computedAt == nil {
+		return leaderboard, nil
+	}
+
+	if scope == constants.LeaderboardScopeFriends {
+		leaderboard.Entries, leaderboard.Me, err = s.leaderboardRepo.GetFriends(ctx, seasonStart, metric, userID, constants.LeaderboardSize)
+	} else {
+		leaderboard.Entries, leaderboard.Me, err = s.leaderboardRepo.GetGlobal(ctx, seasonStart, metric, userID, constants.LeaderboardSize)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return leaderboard, nil
+}
+
+// Refresh пересчитывает снимки текущего сезона. Если прошлый сезон еще не подведен,
+// сначала выполняет его итоговый пересчет, чтобы опыт за последние часы сезона попал в итоги.
+func (s *LeaderboardService) Refresh(ctx context.Context) error {
+	current := utils.SeasonStart(time.Now())
+	previous := current.AddDate(0, 0, -7)
+
+	finalized, err := s.leaderboardRepo.IsFinalized(ctx, previous)
+	if err != nil {
+		return err
+	}
+	if !finalized {
+		if err := s.leaderboardRepo.Refresh(ctx, previous, true); err != nil {
+			return fmt.Errorf("failed to finalize season %s: %w", previous.Format("2006-01-02"), err)
+		}
+	}
+
+	return s.leaderboardRepo.Refresh(ctx, current, false)
+}
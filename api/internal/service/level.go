@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+)
+
+// LevelService начисляет опыт и отслеживает повышение уровня.
+// Все пути, изменяющие опыт (задачи, привычки, сбор урожая), должны использовать его.
+type LevelService struct {
+	seedRepo        *repository.SeedRepo
+	levelRewardRepo *repository.LevelRewardRepo
+}
+
+func NewLevelService(
+	seedRepo *repository.SeedRepo,
+	levelRewardRepo *repository.LevelRewardRepo,
+) *LevelService {
+	return &LevelService{
+		seedRepo:        seedRepo,
+		levelRewardRepo: levelRewardRepo,
+	}
+}
+
+// AddExperience начисляет опыт пользователю и в той же транзакции выдает награды за каждый достигнутый уровень.
+// Возвращает описание повышения уровня или nil, если уровень не изменился.
+func (s *LevelService) AddExperience(ctx context.Context, userID int64, amount int64) (*model.LevelUp, error) {
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	change, err := s.levelRewardRepo.AddExperience(ctx, userID, amount)
+	if err != nil {
+		return nil, err
+	}
+	return s.LevelUp(ctx, change)
+}
+
+// LevelUp описывает повышение уровня по изменению опыта, награды за которое уже выданы
+// в транзакции изменения опыта. Возвращает nil, если уровень не вырос.
+func (s *LevelService) LevelUp(ctx context.Context, change *model.ExperienceChange) (*model.LevelUp, error) {
+	oldLevel := utils.CalculateLevel(change.Before)
+	newLevel := utils.CalculateLevel(change.After)
+	if newLevel <= oldLevel {
+		return nil, nil
+	}
+
+	unlockedSeeds, err := s.seedRepo.GetUnlockedBetween(ctx, oldLevel, newLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.LevelUp{
+		OldLevel:      oldLevel,
+		NewLevel:      newLevel,
+		UnlockedSeeds: unlockedSeeds,
+		Rewards:       change.Rewards,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+)
+
+// MarketService рассчитывает дневные цены на урожай и продает урожай из амбара
+type MarketService struct {
+	produceRepo *repository.ProduceRepo
+	seedRepo    *repository.SeedRepo
+}
+
+func NewMarketService(produceRepo *repository.ProduceRepo, seedRepo *repository.SeedRepo) *MarketService {
+	return &MarketService{
+		produceRepo: produceRepo,
+		seedRepo:    seedRepo,
+	}
+}
+
+// Prices возвращает цены на урожай всех семян на день day вместе с количеством урожая в амбаре пользователя
+func (s *MarketService) Prices(ctx context.Context, userID int64, day time.Time) ([]model.MarketPrice, error) {
+	seeds, err := s.seedRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	produce, err := s.produceRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[int]int, len(produce))
+	for _, p := range produce {
+		owned[p.SeedID] = p.Quantity
+	}
+
+	yesterday := day.AddDate(0, 0, -1)
+	prices := make([]model.MarketPrice, 0, len(seeds))
+	for _, seed := range seeds {
+		price := utils.MarketPrice(seed.GoldReward, seed.ID, day)
+		yesterdayPrice := utils.MarketPrice(seed.GoldReward, seed.ID, yesterday)
+
+		change := 0.0
+		if yesterdayPrice > 0 {
+			change = math.Round((price-yesterdayPrice)/yesterdayPrice*10000) / 100
+		}
+
+		prices = append(prices, model.MarketPrice{
+			SeedID:         seed.ID,
+			SeedName:       seed.Name,
+			SeedIcon:       seed.Icon,
+			BasePrice:      utils.ProduceBasePrice(seed.GoldReward),
+			Price:          price,
+			YesterdayPrice: yesterdayPrice,
+			ChangePercent:  change,
+			Owned:          owned[seed.ID],
+		})
+	}
+	return prices, nil
+}
+
+// Sell продает quantity единиц урожая семени по сегодняшней цене
+func (s *MarketService) Sell(ctx context.Context, userID int64, seedID int, quantity int) (*model.MarketSale, error) {
+	if quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be positive")
+	}
+
+	seed, err := s.seedRepo.GetByID(ctx, seedID)
+	if err != nil {
+		return nil, err
+	}
+
+	unitPrice := utils.MarketPrice(seed.GoldReward, seed.ID, time.Now())
+	description := fmt.Sprintf("Продажа урожая: %s x%d", seed.Name, quantity)
+	return s.produceRepo.Sell(ctx, userID, seedID, quantity, unitPrice, description)
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+)
+
+// PlantLifecycleService обрабатывает жизненный цикл засохших растений:
+// засохшие растения не растут, ежедневно теряют рост и погибают через
+// witheredLifetimeDays дней, освобождая грядку и оставляя запись в plant_history.
+type PlantLifecycleService struct {
+	userPlantRepo        *repository.UserPlantRepo
+	witheredLifetimeDays int
+}
+
+func NewPlantLifecycleService(userPlantRepo *repository.UserPlantRepo, witheredLifetimeDays int) *PlantLifecycleService {
+	if witheredLifetimeDays <= 0 {
+		witheredLifetimeDays = constants.WitheredPlantLifetimeDays
+	}
+	return &PlantLifecycleService{
+		userPlantRepo:        userPlantRepo,
+		witheredLifetimeDays: witheredLifetimeDays,
+	}
+}
+
+// WitheredLifetimeDays возвращает, через сколько дней засохшее растение погибает
+func (s *PlantLifecycleService) WitheredLifetimeDays() int {
+	return s.witheredLifetimeDays
+}
+
+// ProcessWithered удаляет погибшие растения пользователя и уменьшает рост оставшихся засохших.
+// Возвращает количество растений, потерявших рост, и количество погибших.
+func (s *PlantLifecycleService) ProcessWithered(ctx context.Context, userID int64) (decayed int64, died int64, err error) {
+	died, err = s.userPlantRepo.RemoveDeadPlants(ctx, userID, s.witheredLifetimeDays)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	decayed, err = s.userPlantRepo.DecayWitheredPlants(ctx, userID, constants.WitheredGrowthDecayPercent)
+	if err != nil {
+		return 0, died, err
+	}
+
+	return decayed, died, nil
+}
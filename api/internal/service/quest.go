@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+)
+
+// QuestService выдает ежедневные задания по шаблонам и выдает награды за их выполнение.
+// Прогресс заданий за выполнение задач и привычек записывается вместе с выполнением (ProgressLogRepo.Complete).
+// Награда за задание не забирается обратно: выполнение, которое могло быть засчитано в задание с уже
+// полученной наградой, отменить нельзя.
+type QuestService struct {
+	questRepo    *repository.QuestRepo
+	userStatRepo *repository.UserStatRepo
+	levelService *LevelService
+
+	mu  sync.Mutex
+	rng utils.RandomSource
+}
+
+// NewQuestService создает сервис с источником случайных чисел rng для выбора шаблонов заданий
+func NewQuestService(
+	questRepo *repository.QuestRepo,
+	userStatRepo *repository.UserStatRepo,
+	levelService *LevelService,
+	rng utils.RandomSource,
+) *QuestService {
+	return &QuestService{
+		questRepo:    questRepo,
+		userStatRepo: userStatRepo,
+		levelService: levelService,
+		rng:          rng,
+	}
+}
+
+// GetToday возвращает задания пользователя на сегодня, выдавая их, если планировщик еще не успел
+func (s *QuestService) GetToday(ctx context.Context, userID int64) ([]model.UserQuest, error) {
+	today := time.Now()
+	quests, err := s.questRepo.GetByDate(ctx, userID, today)
+	if err != nil {
+		return nil, err
+	}
+	if len(quests) > 0 {
+		return quests, nil
+	}
+
+	if err := s.RollDaily(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.questRepo.GetByDate(ctx, userID, today)
+}
+
+// RollDaily выдает пользователю constants.DailyQuestCount заданий на сегодня.
+// Шаблоны выбираются случайно с учетом веса среди доступных на уровне пользователя;
+// задания на все привычки с тегом выдаются, только если у пользователя есть такие привычки.
+// Повторный вызов в тот же день ничего не меняет.
+func (s *QuestService) RollDaily(ctx context.Context, userID int64) error {
+	stats, err := s.userStatRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	templates, err := s.questRepo.GetTemplates(ctx, stats.Level())
+	if err != nil {
+		return err
+	}
+
+	candidates := make([]model.QuestTemplate, 0, len(templates))
+	for _, template := range templates {
+		if template.Kind == constants.QuestKindCompleteTaggedHabits {
+			count, err := s.questRepo.CountHabitsWithTag(ctx, userID, *template.TagName)
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				continue
+			}
+			template.Target = count
+		}
+		candidates = append(candidates, template)
+	}
+
+	quests := []model.UserQuest{}
+	for _, template := range s.pick(candidates, constants.DailyQuestCount) {
+		quests = append(quests, model.UserQuest{
+			TemplateID: template.ID,
+			Title:      template.Title,
+			Kind:       template.Kind,
+			Difficulty: template.Difficulty,
+			SeedID:     template.SeedID,
+			TagName:    template.TagName,
+			Target:     template.Target,
+		})
+	}
+
+	_, err = s.questRepo.CreateDaily(ctx, userID, time.Now(), quests)
+	return err
+}
+
+// Claim выдает награду за выполненное задание вместе с наградами за достигнутые уровни
+func (s *QuestService) Claim(ctx context.Context, userID int64, questID int) (*model.QuestClaim, error) {
+	claim, err := s.questRepo.Claim(ctx, userID, questID)
+	if err != nil {
+		return nil, err
+	}
+
+	levelUp, err := s.levelService.LevelUp(ctx, claim.Experience)
+	if err != nil {
+		return nil, err
+	}
+	claim.LevelUp = levelUp
+	return claim, nil
+}
+
+// pick выбирает до count разных шаблонов случайно пропорционально весу
+func (s *QuestService) pick(templates []model.QuestTemplate, count int) []model.QuestTemplate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pool := append([]model.QuestTemplate(nil), templates...)
+	picked := []model.QuestTemplate{}
+	for len(picked) < count && len(pool) > 0 {
+		total := 0
+		for _, template := range pool {
+			total += template.Weight
+		}
+
+		roll := s.rng.Intn(total)
+		for i, template := range pool {
+			if roll < template.Weight {
+				picked = append(picked, template)
+				pool = append(pool[:i], pool[i+1:]...)
+				break
+			}
+			roll -= template.Weight
+		}
+	}
+	return picked
+}
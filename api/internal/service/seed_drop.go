@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/RinatHar/FarmFocus/api/internal/model"
+	"github.com/RinatHar/FarmFocus/api/internal/repository"
+	"github.com/RinatHar/FarmFocus/api/internal/utils"
+)
+
+// SeedDropService бросает кости на семена, выпадающие при сборе урожая, и на дополнительный урожай от бонусов
+type SeedDropService struct {
+	seedRepo *repository.SeedRepo
+
+	mu  sync.Mutex
+	rng utils.RandomSource
+}
+
+// NewSeedDropService создает сервис с источником случайных чисел rng.
+// Передайте rand.New(rand.NewSource(seed)) с фиксированным seed, чтобы броски были детерминированы.
+func NewSeedDropService(seedRepo *repository.SeedRepo, rng utils.RandomSource) *SeedDropService {
+	return &SeedDropService{
+		seedRepo: seedRepo,
+		rng:      rng,
+	}
+}
+
+// RollHarvest бросает кости за собранное растение семени seedID и возвращает выпавшие семена.
+// Семя более высокой редкости выбирается среди негибридных семян следующей редкости, доступных на уровне userLevel.
+// Семена выдаются пользователю вместе с остальными наградами за сбор (UserPlantRepo.Harvest).
+func (s *SeedDropService) RollHarvest(ctx context.Context, seedID int, userLevel int) ([]model.SeedDrop, error) {
+	seed, err := s.seedRepo.GetByID(ctx, seedID)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []model.Seed
+	if next, ok := utils.NextRarity(seed.Rarity); ok {
+		seeds, err := s.seedRepo.GetByRarity(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range seeds {
+			if !candidate.IsHybrid && candidate.LevelRequired <= userLevel {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	return s.roll(seed, candidates), nil
+}
+
+// RollYield применяет бонус урожая bonus к награде за сбор amount (utils.RollHarvestYield)
+func (s *SeedDropService) RollYield(amount int, bonus float64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return utils.RollHarvestYield(s.rng, amount, bonus)
+}
+
+func (s *SeedDropService) roll(seed *model.Seed, candidates []model.Seed) []model.SeedDrop {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	drops := []model.SeedDrop{}
+
+	sameKind, upgrade := utils.RollSeedDrops(s.rng, seed.Rarity)
+	if sameKind > 0 {
+		drops = append(drops, newSeedDrop(seed, sameKind, false))
+	}
+	if upgrade && len(candidates) > 0 {
+		drops = append(drops, newSeedDrop(&candidates[s.rng.Intn(len(candidates))], 1, true))
+	}
+	return drops
+}
+
+func newSeedDrop(seed *model.Seed, quantity int, isUpgrade bool) model.SeedDrop {
+	return model.SeedDrop{
+		SeedID:    seed.ID,
+		SeedName:  seed.Name,
+		SeedIcon:  seed.Icon,
+		Rarity:    seed.Rarity,
+		Quantity:  quantity,
+		IsUpgrade: isUpgrade,
+	}
+}
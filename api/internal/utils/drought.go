@@ -0,0 +1,22 @@
+package utils
+
+import "github.com/RinatHar/FarmFocus/api/internal/constants"
+
+// DroughtAfterMissedDay возвращает число дней и тяжесть засухи после пропущенного дня:
+// тяжесть растет на 1, но не выше DroughtSeverityMax
+func DroughtAfterMissedDay(days, severity int) (int, int) {
+	return days + 1, min(severity+1, constants.DroughtSeverityMax)
+}
+
+// DroughtAfterActivity возвращает число дней и тяжесть засухи после первого за день выполнения:
+// тяжесть снижается на 1, а при тяжести 0 засуха завершается и счетчик дней сбрасывается
+func DroughtAfterActivity(days, severity int) (int, int) {
+	if severity <= constants.DroughtSeverityNone {
+		return days, severity
+	}
+	severity--
+	if severity == constants.DroughtSeverityNone {
+		days = 0
+	}
+	return days, severity
+}
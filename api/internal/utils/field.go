@@ -0,0 +1,20 @@
+package utils
+
+import "github.com/RinatHar/FarmFocus/api/internal/constants"
+
+// CellsAdjacent проверяет, что грядки с координатами (x1, y1) и (x2, y2) соседние по горизонтали или вертикали
+func CellsAdjacent(x1, y1, x2, y2 int) bool {
+	return abs(x1-x2)+abs(y1-y2) == 1
+}
+
+// FarmExpansionCost возвращает стоимость расширения поля, если пользователь уже купил expansions расширений
+func FarmExpansionCost(expansions int) int {
+	return constants.FarmExpansionBaseCost + expansions*constants.FarmExpansionCostStep
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
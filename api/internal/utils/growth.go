@@ -1,5 +1,13 @@
 package utils
 
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+)
+
 func CalculateGrowthPercent(currentGrowth, targetGrowth int) int {
 	if targetGrowth == 0 {
 		return 0
@@ -10,3 +18,110 @@ func CalculateGrowthPercent(currentGrowth, targetGrowth int) int {
 	}
 	return percent
 }
+
+// GrowthMultiplier возвращает множитель роста семени: 1 + seed.modification
+func GrowthMultiplier(modification float64) float64 {
+	multiplier := 1 + modification
+	if multiplier < 0 {
+		return 0
+	}
+	return multiplier
+}
+
+// ApplyGrowthModifier рассчитывает очки роста растения за одно выполнение:
+// базовые очки сложности умножаются на множитель роста семени. Результат дробный — дробная часть
+// накапливается у растения (AddPlantGrowth), поэтому пшеница (0.25) за задачу в 1 очко
+// получает 1.25 очка и за каждые 4 таких выполнения вырастает на 5 очков, а не на 4.
+func ApplyGrowthModifier(points int, modification float64) float64 {
+	if points <= 0 {
+		return 0
+	}
+	return float64(points) * GrowthMultiplier(modification)
+}
+
+// AddPlantGrowth прибавляет дробные очки роста gain (отрицательные при отмене выполнения)
+// к росту растения growth с накопленной дробной частью fraction.
+// Возвращает новый целый рост и новую дробную часть в диапазоне [0, 1); рост не опускается ниже нуля.
+func AddPlantGrowth(growth int, fraction, gain float64) (int, float64) {
+	total := math.Max(float64(growth)+fraction+gain, 0)
+	// Небольшой допуск, чтобы 0.75 + 0.25 давало целое очко несмотря на погрешность float64
+	whole := math.Floor(total + growthEpsilon)
+	return int(whole), math.Max(total-whole, 0)
+}
+
+const growthEpsilon = 1e-9
+
+// DroughtGrowthMultiplier возвращает множитель роста растений для тяжести засухи
+func DroughtGrowthMultiplier(severity int) float64 {
+	if severity <= constants.DroughtSeverityNone {
+		return 1.0
+	}
+	if severity > constants.DroughtSeverityMax {
+		severity = constants.DroughtSeverityMax
+	}
+	return constants.DroughtGrowthMultipliers[severity]
+}
+
+// ApplyDroughtPenalty уменьшает дробные очки роста в соответствии с тяжестью засухи.
+// Результат не округляется: при тяжести 1 растение за 1 очко получает 0.5 очка, а не 1.
+func ApplyDroughtPenalty(gain float64, severity int) float64 {
+	return gain * DroughtGrowthMultiplier(severity)
+}
+
+// RollHarvestYield увеличивает награду за сбор на бонус урожая (1 + bonus). Дробная часть результата
+// выпадает дополнительной единицей с соответствующим шансом, поэтому даже небольшой бонус меняет награду:
+// с пугалом (+5%) сбор 4 единиц урожая дает 5 единиц с шансом 20%, в среднем 4.2.
+func RollHarvestYield(rng RandomSource, amount int, bonus float64) int {
+	exact := float64(amount) * (1 + bonus)
+	if exact <= 0 {
+		return 0
+	}
+	whole := math.Floor(exact)
+	if fraction := exact - whole; fraction > 0 && rng.Float64() < fraction {
+		whole++
+	}
+	return int(whole)
+}
+
+// PlantStage определяет стадию роста растения по порогам семени thresholds (проценты роста,
+// с которых начинаются стадии) и возвращает номер стадии (с 1), количество стадий и путь к картинке
+// стадии в папке семени imgPlant. Пустые thresholds заменяются на constants.DefaultStageThresholds.
+func PlantStage(imgPlant string, currentGrowth, targetGrowth int, thresholds []int) (stage, stageCount int, imgPath string) {
+	if len(thresholds) == 0 {
+		thresholds = constants.DefaultStageThresholds
+	}
+
+	percent := CalculateGrowthPercent(currentGrowth, targetGrowth)
+	if targetGrowth > 0 && currentGrowth >= targetGrowth {
+		percent = 100
+	}
+
+	stage = 1
+	for i, threshold := range thresholds {
+		if percent >= threshold {
+			stage = i + 1
+		}
+	}
+
+	if imgPlant != "" {
+		imgPath = strings.TrimRight(imgPlant, "/") + "/state" + strconv.Itoa(stage) + ".png"
+	}
+	return stage, len(thresholds), imgPath
+}
+
+// ValidStageThresholds проверяет пороги стадий роста: первая стадия начинается с 0,
+// пороги строго возрастают и не превышают 100. Пустой список допустим (стадии по умолчанию).
+func ValidStageThresholds(thresholds []int) bool {
+	if len(thresholds) == 0 {
+		return true
+	}
+	if thresholds[0] != 0 {
+		return false
+	}
+	for i := 1; i < len(thresholds); i++ {
+		if thresholds[i] <= thresholds[i-1] || thresholds[i] > 100 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+)
+
+func TestApplyGrowthModifier(t *testing.T) {
+	tests := []struct {
+		name         string
+		points       int
+		modification float64
+		want         float64
+	}{
+		{name: "без модификатора", points: 2, modification: 0, want: 2},
+		{name: "пшеница, 1 очко", points: 1, modification: 0.25, want: 1.25},
+		{name: "пшеница, 3 очка", points: 3, modification: 0.25, want: 3.75},
+		{name: "баклажан, 3 очка", points: 3, modification: 0.5, want: 4.5},
+		{name: "отрицательный множитель не уходит ниже нуля", points: 2, modification: -1.5, want: 0},
+		{name: "нет очков роста", points: 0, modification: 0.5, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ApplyGrowthModifier(tt.points, tt.modification); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ApplyGrowthModifier(%d, %v) = %v, want %v", tt.points, tt.modification, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddPlantGrowth(t *testing.T) {
+	tests := []struct {
+		name         string
+		growth       int
+		fraction     float64
+		gain         float64
+		wantGrowth   int
+		wantFraction float64
+	}{
+		{name: "целые очки", growth: 5, fraction: 0, gain: 2, wantGrowth: 7, wantFraction: 0},
+		{name: "дробная часть накапливается", growth: 5, fraction: 0, gain: 1.25, wantGrowth: 6, wantFraction: 0.25},
+		{name: "накопленная часть дает целое очко", growth: 6, fraction: 0.75, gain: 1.25, wantGrowth: 8, wantFraction: 0},
+		{name: "отмена возвращает дробную часть", growth: 8, fraction: 0, gain: -1.25, wantGrowth: 6, wantFraction: 0.75},
+		{name: "рост не опускается ниже нуля", growth: 1, fraction: 0.5, gain: -3, wantGrowth: 0, wantFraction: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			growth, fraction := AddPlantGrowth(tt.growth, tt.fraction, tt.gain)
+			if growth != tt.wantGrowth || math.Abs(fraction-tt.wantFraction) > 1e-9 {
+				t.Errorf("AddPlantGrowth(%d, %v, %v) = (%d, %v), want (%d, %v)",
+					tt.growth, tt.fraction, tt.gain, growth, fraction, tt.wantGrowth, tt.wantFraction)
+			}
+		})
+	}
+}
+
+func TestAddPlantGrowthAccumulatesSmallModifiers(t *testing.T) {
+	tests := []struct {
+		name         string
+		points       int
+		modification float64
+		completions  int
+		want         int
+	}{
+		{name: "пшеница, 4 выполнения по 1 очку", points: 1, modification: 0.25, completions: 4, want: 5},
+		{name: "баклажан, 2 выполнения по 1 очку", points: 1, modification: 0.5, completions: 2, want: 3},
+		{name: "модификатор 0.1, 10 выполнений", points: 1, modification: 0.1, completions: 10, want: 11},
+		{name: "без модификатора", points: 1, modification: 0, completions: 4, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			growth, fraction := 0, 0.0
+			for range tt.completions {
+				growth, fraction = AddPlantGrowth(growth, fraction, ApplyGrowthModifier(tt.points, tt.modification))
+			}
+			if growth != tt.want {
+				t.Errorf("growth after %d completions = %d, want %d", tt.completions, growth, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyDroughtPenalty(t *testing.T) {
+	tests := []struct {
+		name     string
+		gain     float64
+		severity int
+		want     float64
+	}{
+		{name: "без засухи", gain: 1, severity: 0, want: 1},
+		{name: "тяжесть 1 замедляет рост даже при 1 очке", gain: 1, severity: 1, want: 0.5},
+		{name: "тяжесть 2", gain: 3, severity: 2, want: 0.75},
+		{name: "тяжесть выше максимальной", gain: 2, severity: 5, want: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ApplyDroughtPenalty(tt.gain, tt.severity); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ApplyDroughtPenalty(%v, %d) = %v, want %v", tt.gain, tt.severity, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRollHarvestYield(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int
+		bonus  float64
+		rolls  []float64
+		want   int
+	}{
+		{name: "без бонуса бросок не нужен", amount: 4, bonus: 0, want: 4},
+		{name: "пугало, удачный бросок", amount: 4, bonus: 0.05, rolls: []float64{0.19}, want: 5},
+		{name: "пугало, неудачный бросок", amount: 4, bonus: 0.05, rolls: []float64{0.21}, want: 4},
+		{name: "секатор дает целую единицу", amount: 4, bonus: 0.25, want: 5},
+		{name: "секатор и пугало", amount: 4, bonus: 0.3, rolls: []float64{0.1}, want: 6},
+		{name: "опыт с пугалом", amount: 30, bonus: 0.05, rolls: []float64{0.4}, want: 32},
+		{name: "нет награды", amount: 0, bonus: 0.05, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RollHarvestYield(&fixedRandom{floats: tt.rolls}, tt.amount, tt.bonus); got != tt.want {
+				t.Errorf("RollHarvestYield(%d, %v) = %d, want %d", tt.amount, tt.bonus, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRollHarvestYieldScarecrowChangesProduce(t *testing.T) {
+	const harvests = 1000
+
+	rng := rand.New(rand.NewSource(1))
+	total := 0
+	for i := 0; i < harvests; i++ {
+		total += RollHarvestYield(rng, constants.HarvestBaseYield, 0.05)
+	}
+
+	// Без пугала 1000 сборов дают ровно 4000 единиц, с пугалом — в среднем 4200
+	if total <= harvests*constants.HarvestBaseYield {
+		t.Fatalf("scarecrow did not change produce: %d units after %d harvests", total, harvests)
+	}
+	if avg := float64(total) / harvests; math.Abs(avg-4.2) > 0.05 {
+		t.Errorf("average produce with scarecrow = %v, want about 4.2", avg)
+	}
+}
@@ -0,0 +1,9 @@
+package utils
+
+import "github.com/RinatHar/FarmFocus/api/internal/constants"
+
+// GuildMinContribution возвращает минимальный вклад в цель гильдии размера target, дающий право на награду:
+// GuildMinContributionPercent процентов цели с округлением вверх, но не меньше 1
+func GuildMinContribution(target int) int {
+	return max((target*constants.GuildMinContributionPercent+99)/100, 1)
+}
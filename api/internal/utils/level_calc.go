@@ -2,25 +2,43 @@ package utils
 
 import "math"
 
-// CalculateLevel — быстрый старт + миллионы на 1000+ ур (1.025x)
+// Единая кривая уровней (совпадает с ui/src/utils/levelCalculator.ts):
+// - для 2-го уровня нужно 50 опыта
+// - каждый следующий порог больше предыдущего на 5%
+// - каждые 10 уровней к росту добавляется +1% (не более +10%, итого 15%)
+const (
+	MaxLevel            = 9999
+	levelStartThreshold = 50.0
+	levelBaseGrowth     = 1.05
+	levelMaxBonus       = 0.10
+)
+
+// levelGrowth возвращает коэффициент роста порога после достижения уровня level+1
+func levelGrowth(level int) float64 {
+	bonus := math.Min(float64(level)/10*0.01, levelMaxBonus)
+	return levelBaseGrowth + bonus
+}
+
+// CalculateLevel — текущий уровень по общему опыту
 func CalculateLevel(exp int64) int {
 	if exp <= 0 {
 		return 1
 	}
 	level := 1
-	threshold := 20.0 // супер-быстрый старт
-	remaining := float64(exp)
-	for remaining >= threshold {
-		remaining -= threshold
+	threshold := levelStartThreshold
+	remaining := exp
+	for level < MaxLevel {
+		step := int64(math.Round(threshold))
+		if remaining < step {
+			break
+		}
+		remaining -= step
+		threshold *= levelGrowth(level)
 		level++
-		threshold *= 1.025 // ← Медленный рост!
-		if level > 9999 {  // защита от overflow
+		if threshold > 1e18 { // защита от overflow
 			break
 		}
 	}
-	if level > 9999 {
-		return 9999
-	}
 	return level
 }
 
@@ -29,21 +47,21 @@ func ExperienceForLevel(level int) int64 {
 	if level <= 1 {
 		return 0
 	}
-	total := 0.0
-	threshold := 20.0
+	var total int64
+	threshold := levelStartThreshold
 	for i := 1; i < level; i++ {
-		total += threshold
-		threshold *= 1.025
-		if total > 1e18 { // защита
-			return 0x7FFFFFFFFFFFFFFF
+		total += int64(math.Round(threshold))
+		threshold *= levelGrowth(i)
+		if threshold > 1e18 { // защита
+			return math.MaxInt64
 		}
 	}
-	return int64(math.Round(total))
+	return total
 }
 
 // ExperienceForNextLevel — exp ДО следующего (от текущего exp)
 func ExperienceForNextLevel(currentLevel int, currentExp int64) int64 {
-	if currentLevel >= 9999 {
+	if currentLevel >= MaxLevel {
 		return 0
 	}
 	nextExp := ExperienceForLevel(currentLevel + 1)
@@ -58,7 +76,7 @@ func ExperienceForNextLevel(currentLevel int, currentExp int64) int64 {
 
 // ProgressToNext — 0.0..1.0 для UI-бара
 func ProgressToNext(currentLevel int, currentExp int64) float64 {
-	if currentLevel >= 9999 {
+	if currentLevel >= MaxLevel {
 		return 1.0
 	}
 	curr := float64(ExperienceForLevel(currentLevel))
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+)
+
+// ProduceBasePrice возвращает базовую цену единицы урожая семени:
+// полный сбор (HarvestBaseYield единиц) по базовой цене стоит goldReward золота
+func ProduceBasePrice(goldReward int) float64 {
+	return roundPrice(float64(goldReward) / constants.HarvestBaseYield)
+}
+
+// MarketPriceMultiplier возвращает множитель цены семени на день.
+// Генератор случайных чисел инициализируется датой и семенем, поэтому цена
+// одинакова в течение дня для всех пользователей и меняется на следующий день.
+func MarketPriceMultiplier(seedID int, day time.Time) float64 {
+	h := fnv.New64a()
+	h.Write([]byte(day.Format("2006-01-02") + ":" + strconv.Itoa(seedID)))
+	rng := rand.New(rand.NewSource(int64(h.Sum64())))
+
+	spread := constants.MarketMaxMultiplier - constants.MarketMinMultiplier
+	return constants.MarketMinMultiplier + rng.Float64()*spread
+}
+
+// MarketPrice возвращает цену единицы урожая семени на день
+func MarketPrice(goldReward int, seedID int, day time.Time) float64 {
+	return roundPrice(ProduceBasePrice(goldReward) * MarketPriceMultiplier(seedID, day))
+}
+
+// SaleGold возвращает золото за продажу quantity единиц по цене unitPrice (дробная часть отбрасывается)
+func SaleGold(unitPrice float64, quantity int) int {
+	return int(math.Floor(unitPrice*float64(quantity) + 1e-9))
+}
+
+func roundPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
@@ -0,0 +1,35 @@
+package utils
+
+import "math"
+
+// DiminishingMultiplier возвращает множитель наград за очередное выполнение за день.
+// completionsToday — сколько выполнений уже было сегодня. Первые after выполнений дают полную награду,
+// каждое следующее — в factor раз меньше предыдущего, но не меньше minMultiplier.
+func DiminishingMultiplier(completionsToday, after int, factor, minMultiplier float64) float64 {
+	if completionsToday < after {
+		return 1
+	}
+	multiplier := math.Pow(factor, float64(completionsToday-after+1))
+	return math.Max(multiplier, minMultiplier)
+}
+
+// ApplyMultiplier умножает награду на множитель с округлением
+func ApplyMultiplier(amount int, multiplier float64) int {
+	if amount <= 0 {
+		return 0
+	}
+	return int(math.Round(float64(amount) * multiplier))
+}
+
+// ApplySoftCap ограничивает награду мягким дневным лимитом: часть награды, превышающая
+// остаток лимита (softCap - earnedToday), начисляется с множителем overCapMultiplier
+func ApplySoftCap(amount, earnedToday, softCap int, overCapMultiplier float64) int {
+	if amount <= 0 || softCap <= 0 {
+		return amount
+	}
+	room := max(softCap-earnedToday, 0)
+	if amount <= room {
+		return amount
+	}
+	return room + ApplyMultiplier(amount-room, overCapMultiplier)
+}
@@ -0,0 +1,15 @@
+package utils
+
+import "time"
+
+// SeasonStart возвращает начало недельного сезона рейтингов, в который попадает t: понедельник 00:00
+func SeasonStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// SeasonEnd возвращает начало следующего сезона после сезона, начавшегося в seasonStart
+func SeasonEnd(seasonStart time.Time) time.Time {
+	return seasonStart.AddDate(0, 0, 7)
+}
@@ -0,0 +1,55 @@
+package utils
+
+import "github.com/RinatHar/FarmFocus/api/internal/constants"
+
+// RandomSource — источник случайных чисел для бросков выпадения семян.
+// *rand.Rand удовлетворяет интерфейсу; с фиксированным seed результаты бросков детерминированы.
+type RandomSource interface {
+	Float64() float64
+	Intn(n int) int
+}
+
+// SeedDropChance возвращает шанс выпадения семени того же вида за один бросок
+func SeedDropChance(rarity string) float64 {
+	return constants.SeedDropChance / rarityMultiplier(rarity)
+}
+
+// UpgradeSeedDropChance возвращает шанс выпадения семени следующей редкости после rarity.
+// Для наивысшей редкости шанс равен 0.
+func UpgradeSeedDropChance(rarity string) float64 {
+	next, ok := NextRarity(rarity)
+	if !ok {
+		return 0
+	}
+	return constants.UpgradeSeedDropChance / rarityMultiplier(next)
+}
+
+// NextRarity возвращает следующую по порядку constants.ValidRarities редкость
+func NextRarity(rarity string) (string, bool) {
+	for i, r := range constants.ValidRarities {
+		if r == rarity && i+1 < len(constants.ValidRarities) {
+			return constants.ValidRarities[i+1], true
+		}
+	}
+	return "", false
+}
+
+// RollSeedDrops бросает кости при сборе растения редкости rarity: возвращает число семян того же вида
+// и признак выпадения семени следующей редкости
+func RollSeedDrops(rng RandomSource, rarity string) (sameKind int, upgrade bool) {
+	chance := SeedDropChance(rarity)
+	for i := 0; i < constants.SeedDropRolls; i++ {
+		if rng.Float64() < chance {
+			sameKind++
+		}
+	}
+	upgrade = rng.Float64() < UpgradeSeedDropChance(rarity)
+	return sameKind, upgrade
+}
+
+func rarityMultiplier(rarity string) float64 {
+	if m, ok := constants.RarityMultipliers[rarity]; ok && m > 0 {
+		return m
+	}
+	return 1
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"math"
+	"testing"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+)
+
+// fixedRandom возвращает заранее заданные значения бросков по порядку
+type fixedRandom struct {
+	floats []float64
+}
+
+func (r *fixedRandom) Float64() float64 {
+	v := r.floats[0]
+	r.floats = r.floats[1:]
+	return v
+}
+
+func (r *fixedRandom) Intn(n int) int {
+	return 0
+}
+
+func TestSeedDropChances(t *testing.T) {
+	tests := []struct {
+		rarity      string
+		wantSame    float64
+		wantUpgrade float64
+	}{
+		{rarity: constants.RarityCommon, wantSame: 0.5, wantUpgrade: 0.1 / 1.5},
+		{rarity: constants.RarityUncommon, wantSame: 0.5 / 1.5, wantUpgrade: 0.05},
+		{rarity: constants.RarityRare, wantSame: 0.25, wantUpgrade: 0.1 / 3},
+		{rarity: constants.RarityLegendary, wantSame: 0.5 / 3, wantUpgrade: 0.02},
+		{rarity: constants.RarityUnique, wantSame: 0.1, wantUpgrade: 0},
+		{rarity: "unknown", wantSame: 0.5, wantUpgrade: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rarity, func(t *testing.T) {
+			if got := SeedDropChance(tt.rarity); math.Abs(got-tt.wantSame) > 1e-9 {
+				t.Errorf("SeedDropChance(%q) = %v, want %v", tt.rarity, got, tt.wantSame)
+			}
+			if got := UpgradeSeedDropChance(tt.rarity); math.Abs(got-tt.wantUpgrade) > 1e-9 {
+				t.Errorf("UpgradeSeedDropChance(%q) = %v, want %v", tt.rarity, got, tt.wantUpgrade)
+			}
+		})
+	}
+}
+
+func TestRollSeedDrops(t *testing.T) {
+	tests := []struct {
+		name         string
+		rarity       string
+		rolls        []float64 // SeedDropRolls бросков на семена того же вида, затем бросок на улучшение
+		wantSameKind int
+		wantUpgrade  bool
+	}{
+		{name: "обычное, все броски удачны", rarity: constants.RarityCommon, rolls: []float64{0.1, 0.49, 0.06}, wantSameKind: 2, wantUpgrade: true},
+		{name: "обычное, граница шанса не проходит", rarity: constants.RarityCommon, rolls: []float64{0.5, 0.99, 0.07}, wantSameKind: 0, wantUpgrade: false},
+		{name: "обычное, один удачный бросок", rarity: constants.RarityCommon, rolls: []float64{0.9, 0.2, 0.5}, wantSameKind: 1, wantUpgrade: false},
+		{name: "необычное, шанс ниже, чем у обычного", rarity: constants.RarityUncommon, rolls: []float64{0.3, 0.4, 0.04}, wantSameKind: 1, wantUpgrade: true},
+		{name: "редкое", rarity: constants.RarityRare, rolls: []float64{0.24, 0.26, 0.04}, wantSameKind: 1, wantUpgrade: false},
+		{name: "легендарное, улучшение до уникального", rarity: constants.RarityLegendary, rolls: []float64{0.16, 0.0, 0.019}, wantSameKind: 2, wantUpgrade: true},
+		{name: "уникальное не улучшается", rarity: constants.RarityUnique, rolls: []float64{0.0, 0.0, 0.0}, wantSameKind: 2, wantUpgrade: false},
+		{name: "неизвестная редкость считается обычной без улучшения", rarity: "unknown", rolls: []float64{0.4, 0.6, 0.0}, wantSameKind: 1, wantUpgrade: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.rolls) != constants.SeedDropRolls+1 {
+				t.Fatalf("test case has %d rolls, want %d", len(tt.rolls), constants.SeedDropRolls+1)
+			}
+			rng := &fixedRandom{floats: tt.rolls}
+
+			sameKind, upgrade := RollSeedDrops(rng, tt.rarity)
+			if sameKind != tt.wantSameKind || upgrade != tt.wantUpgrade {
+				t.Errorf("RollSeedDrops(%q) with rolls %v = (%d, %v), want (%d, %v)",
+					tt.rarity, tt.rolls, sameKind, upgrade, tt.wantSameKind, tt.wantUpgrade)
+			}
+			if len(rng.floats) != 0 {
+				t.Errorf("RollSeedDrops(%q) used %d of %d rolls", tt.rarity, len(tt.rolls)-len(rng.floats), len(tt.rolls))
+			}
+		})
+	}
+}
+
+func TestNextRarity(t *testing.T) {
+	tests := []struct {
+		rarity string
+		want   string
+		wantOK bool
+	}{
+		{rarity: constants.RarityCommon, want: constants.RarityUncommon, wantOK: true},
+		{rarity: constants.RarityRare, want: constants.RarityLegendary, wantOK: true},
+		{rarity: constants.RarityUnique, want: "", wantOK: false},
+		{rarity: "unknown", want: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rarity, func(t *testing.T) {
+			if got, ok := NextRarity(tt.rarity); got != tt.want || ok != tt.wantOK {
+				t.Errorf("NextRarity(%q) = (%q, %v), want (%q, %v)", tt.rarity, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
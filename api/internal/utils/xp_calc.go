@@ -1,12 +1,26 @@
 package utils
 
-import "math"
+import (
+	"math"
+
+	"github.com/RinatHar/FarmFocus/api/internal/constants"
+)
+
+// GetDifficultyReward возвращает награду из таблицы наград rewards по сложности.
+// Для неизвестной сложности используется награда DefaultDifficulty.
+func GetDifficultyReward(rewards map[string]constants.DifficultyReward, difficulty string) constants.DifficultyReward {
+	if reward, ok := rewards[difficulty]; ok {
+		return reward
+	}
+	return rewards[constants.DefaultDifficulty]
+}
 
 // CalculateTaskXP рассчитывает опыт для задачи по формуле: базовый опыт * (коэф уровня + коэф сложности)
-func CalculateTaskXP(baseXP int, userLevel int, difficulty string) int {
+func CalculateTaskXP(baseXP int, userLevel int, reward constants.DifficultyReward) int {
 	levelMultiplier := calculateLevelMultiplier(userLevel)
+	difficultyBonus := reward.XPBonus
 
-	xp := float64(baseXP) * (levelMultiplier)
+	xp := float64(baseXP) * (levelMultiplier + difficultyBonus)
 	return int(math.Round(xp))
 }
 
@@ -15,38 +29,10 @@ func calculateLevelMultiplier(level int) float64 {
 	return 1.0 + (float64(level-1) * 0.1)
 }
 
-// getBaseXPForDifficulty возвращает базовое количество опыта в зависимости от сложности
-func GetBaseXPForDifficulty(difficulty string) int {
-	switch difficulty {
-	case "trifle":
-		return 10 // Незначительная задача
-	case "easy":
-		return 15 // Легкая задача
-	case "normal":
-		return 30 // Обычная задача
-	case "hard":
-		return 50 // Сложная задача
-	default:
-		return 30 // Значение по умолчанию
-	}
-}
-
-// getBaseXPForHabit возвращает базовое количество опыта для привычки с учетом сложности и count
-func GetBaseXPForHabit(difficulty string, count int) int {
+// GetBaseXPForHabit возвращает базовое количество опыта для привычки с учетом награды сложности и count
+func GetBaseXPForHabit(reward constants.DifficultyReward, count int) int {
 	// Базовое значение в зависимости от сложности
-	var baseXP int
-	switch difficulty {
-	case "trifle":
-		baseXP = 10 // Незначительная привычка
-	case "easy":
-		baseXP = 15 // Легкая привычка
-	case "normal":
-		baseXP = 30 // Обычная привычка
-	case "hard":
-		baseXP = 50 // Сложная привычка
-	default:
-		baseXP = 30 // Значение по умолчанию
-	}
+	baseXP := reward.BaseXP
 
 	// Применяем коэффициент для count (логарифмическое влияние)
 	if count > 1 {
@@ -0,0 +1,51 @@
+-- +goose Up
+
+-- task_tag (связь задач и тегов многие-ко-многим)
+CREATE TABLE IF NOT EXISTS task_tag (
+    task_id INT NOT NULL REFERENCES task(id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES tag(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, tag_id)
+);
+
+-- habit_tag (связь привычек и тегов многие-ко-многим)
+CREATE TABLE IF NOT EXISTS habit_tag (
+    habit_id INT NOT NULL REFERENCES habit(id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES tag(id) ON DELETE CASCADE,
+    PRIMARY KEY (habit_id, tag_id)
+);
+
+-- Переносим существующие одиночные теги
+INSERT INTO task_tag (task_id, tag_id)
+SELECT id, tag_id FROM task WHERE tag_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+INSERT INTO habit_tag (habit_id, tag_id)
+SELECT id, tag_id FROM habit WHERE tag_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+DROP INDEX IF EXISTS idx_task_tag_id;
+DROP INDEX IF EXISTS idx_habit_tag_id;
+
+ALTER TABLE task DROP COLUMN IF EXISTS tag_id;
+ALTER TABLE habit DROP COLUMN IF EXISTS tag_id;
+
+CREATE INDEX IF NOT EXISTS idx_task_tag_tag_id ON task_tag(tag_id);
+CREATE INDEX IF NOT EXISTS idx_habit_tag_tag_id ON habit_tag(tag_id);
+
+-- +goose Down
+
+ALTER TABLE task ADD COLUMN IF NOT EXISTS tag_id INT REFERENCES tag(id);
+ALTER TABLE habit ADD COLUMN IF NOT EXISTS tag_id INT REFERENCES tag(id);
+
+-- При откате сохраняем только один (минимальный) тег
+UPDATE task t SET tag_id = (SELECT MIN(tt.tag_id) FROM task_tag tt WHERE tt.task_id = t.id);
+UPDATE habit h SET tag_id = (SELECT MIN(ht.tag_id) FROM habit_tag ht WHERE ht.habit_id = h.id);
+
+CREATE INDEX IF NOT EXISTS idx_task_tag_id ON task(tag_id);
+CREATE INDEX IF NOT EXISTS idx_habit_tag_id ON habit(tag_id);
+
+DROP INDEX IF EXISTS idx_habit_tag_tag_id;
+DROP INDEX IF EXISTS idx_task_tag_tag_id;
+
+DROP TABLE IF EXISTS habit_tag;
+DROP TABLE IF EXISTS task_tag;
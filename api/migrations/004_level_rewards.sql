@@ -0,0 +1,76 @@
+-- +goose Up
+
+-- Источник записи в логе прогресса: задача, привычка или награда за уровень
+ALTER TABLE progress_log ADD COLUMN IF NOT EXISTS source VARCHAR(30) NOT NULL DEFAULT 'task';
+ALTER TABLE progress_log ADD COLUMN IF NOT EXISTS description TEXT;
+
+UPDATE progress_log SET source = 'habit' WHERE habit_id IS NOT NULL;
+
+ALTER TABLE progress_log ALTER COLUMN source DROP DEFAULT;
+
+ALTER TABLE progress_log DROP CONSTRAINT IF EXISTS progress_log_check;
+ALTER TABLE progress_log ADD CONSTRAINT progress_log_source_check CHECK (
+    (source = 'task' AND task_id IS NOT NULL AND habit_id IS NULL) OR
+    (source = 'habit' AND habit_id IS NOT NULL AND task_id IS NULL) OR
+    (source NOT IN ('task', 'habit') AND task_id IS NULL AND habit_id IS NULL)
+);
+
+-- level_reward (награды за достижение уровня)
+CREATE TABLE IF NOT EXISTS level_reward (
+    id SERIAL PRIMARY KEY,
+    level INT NOT NULL CHECK (level > 1),
+    reward_type VARCHAR(20) NOT NULL CHECK (reward_type IN ('gold', 'seed', 'bed')),
+    seed_id INT REFERENCES seed(id) ON DELETE CASCADE,
+    amount INT NOT NULL DEFAULT 1 CHECK (amount > 0),
+    created_at TIMESTAMP DEFAULT NOW(),
+    CHECK ((reward_type = 'seed') = (seed_id IS NOT NULL))
+);
+
+-- user_level_reward (выданные пользователю награды за уровень, гарантирует однократную выдачу)
+CREATE TABLE IF NOT EXISTS user_level_reward (
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    level INT NOT NULL,
+    granted_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, level)
+);
+
+CREATE INDEX IF NOT EXISTS idx_level_reward_level ON level_reward(level);
+CREATE INDEX IF NOT EXISTS idx_progress_log_source ON progress_log(source);
+
+-- Семена ищем по названию: колонка seed.code появляется только в миграции 010
+INSERT INTO level_reward (level, reward_type, seed_id, amount)
+SELECT v.level, v.reward_type, s.id, v.amount
+FROM (VALUES
+    (2, 'gold', NULL, 20),
+    (3, 'seed', 'Пшеница', 2),
+    (5, 'gold', NULL, 50),
+    (5, 'bed', NULL, 1),
+    (6, 'seed', 'Баклажан', 1),
+    (10, 'gold', NULL, 100),
+    (10, 'bed', NULL, 1),
+    (15, 'seed', 'Баклажан', 3),
+    (15, 'bed', NULL, 1),
+    (20, 'gold', NULL, 300),
+    (20, 'bed', NULL, 1)
+) AS v(level, reward_type, seed_name, amount)
+LEFT JOIN seed s ON s.name = v.seed_name
+WHERE v.seed_name IS NULL OR s.id IS NOT NULL;
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_progress_log_source;
+DROP INDEX IF EXISTS idx_level_reward_level;
+
+DROP TABLE IF EXISTS user_level_reward;
+DROP TABLE IF EXISTS level_reward;
+
+DELETE FROM progress_log WHERE source NOT IN ('task', 'habit');
+
+ALTER TABLE progress_log DROP CONSTRAINT IF EXISTS progress_log_source_check;
+ALTER TABLE progress_log ADD CONSTRAINT progress_log_check CHECK (
+    (task_id IS NOT NULL AND habit_id IS NULL) OR
+    (task_id IS NULL AND habit_id IS NOT NULL)
+);
+
+ALTER TABLE progress_log DROP COLUMN IF EXISTS description;
+ALTER TABLE progress_log DROP COLUMN IF EXISTS source;
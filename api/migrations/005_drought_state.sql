@@ -0,0 +1,37 @@
+-- +goose Up
+
+-- Состояние засухи: количество пропущенных дней подряд и текущая тяжесть (0 — засухи нет)
+ALTER TABLE user_stat ADD COLUMN IF NOT EXISTS drought_days INT NOT NULL DEFAULT 0;
+ALTER TABLE user_stat ADD COLUMN IF NOT EXISTS drought_severity INT NOT NULL DEFAULT 0;
+
+UPDATE user_stat SET drought_days = 1, drought_severity = 1 WHERE is_drought = true;
+
+-- drought_event (история засух пользователя)
+CREATE TABLE IF NOT EXISTS drought_event (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ended_at TIMESTAMP,
+    days INT NOT NULL DEFAULT 1,
+    severity INT NOT NULL DEFAULT 1,
+    max_severity INT NOT NULL DEFAULT 1,
+    plants_withered INT NOT NULL DEFAULT 0,
+    plants_died INT NOT NULL DEFAULT 0,
+    end_reason VARCHAR(30)
+);
+
+CREATE INDEX IF NOT EXISTS idx_drought_event_user_id ON drought_event(user_id);
+-- Не более одной активной засухи на пользователя
+CREATE UNIQUE INDEX IF NOT EXISTS idx_drought_event_active ON drought_event(user_id) WHERE ended_at IS NULL;
+
+INSERT INTO drought_event (user_id, started_at, days, severity, max_severity)
+SELECT user_id, updated_at, 1, 1, 1 FROM user_stat WHERE is_drought = true;
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_drought_event_active;
+DROP INDEX IF EXISTS idx_drought_event_user_id;
+DROP TABLE IF EXISTS drought_event;
+
+ALTER TABLE user_stat DROP COLUMN IF EXISTS drought_severity;
+ALTER TABLE user_stat DROP COLUMN IF EXISTS drought_days;
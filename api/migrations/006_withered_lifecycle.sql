@@ -0,0 +1,29 @@
+-- +goose Up
+
+-- Момент, когда растение засохло (NULL — растение живое)
+ALTER TABLE user_plant ADD COLUMN IF NOT EXISTS withered_at TIMESTAMP;
+
+UPDATE user_plant SET withered_at = NOW() WHERE is_withered = true AND withered_at IS NULL;
+
+-- plant_history (история растений, убранных с грядок)
+CREATE TABLE IF NOT EXISTS plant_history (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    seed_id INT NOT NULL REFERENCES seed(id),
+    bed_id INT,
+    event VARCHAR(20) NOT NULL CHECK (event IN ('harvested', 'died', 'removed')),
+    reason VARCHAR(30),
+    final_growth INT NOT NULL DEFAULT 0,
+    planted_at TIMESTAMP NOT NULL,
+    withered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_plant_history_user_id ON plant_history(user_id, created_at DESC);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_plant_history_user_id;
+DROP TABLE IF EXISTS plant_history;
+
+ALTER TABLE user_plant DROP COLUMN IF EXISTS withered_at;
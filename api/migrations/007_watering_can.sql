@@ -0,0 +1,80 @@
+-- +goose Up
+
+-- item (справочник предметов, которые не являются семенами)
+CREATE TABLE IF NOT EXISTS item (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    icon VARCHAR(255),
+    type VARCHAR(20) NOT NULL CHECK (type IN ('consumable')),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- user_item (инвентарь предметов пользователя)
+CREATE TABLE IF NOT EXISTS user_item (
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    item_id INT NOT NULL REFERENCES item(id) ON DELETE CASCADE,
+    quantity INT NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, item_id)
+);
+
+INSERT INTO item (id, code, name, description, icon, type) VALUES
+(1, 'watering_can', 'Лейка', 'Оживляет одно засохшее растение', '/img/items/watering_can.png', 'consumable')
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval('item_id_seq', (SELECT MAX(id) FROM item));
+
+-- Расходники продаются в магазине: id_good ссылается на item.id
+ALTER TABLE good DROP CONSTRAINT IF EXISTS good_type_check;
+ALTER TABLE good ADD CONSTRAINT good_type_check CHECK (type IN ('seed', 'bed', 'consumable'));
+
+-- Расходники как награда за уровень
+ALTER TABLE level_reward ADD COLUMN IF NOT EXISTS item_id INT REFERENCES item(id) ON DELETE CASCADE;
+ALTER TABLE level_reward DROP CONSTRAINT IF EXISTS level_reward_reward_type_check;
+ALTER TABLE level_reward ADD CONSTRAINT level_reward_reward_type_check
+    CHECK (reward_type IN ('gold', 'seed', 'bed', 'item'));
+ALTER TABLE level_reward ADD CONSTRAINT level_reward_item_check
+    CHECK ((reward_type = 'item') = (item_id IS NOT NULL));
+
+INSERT INTO level_reward (level, reward_type, item_id, amount) VALUES
+(4, 'item', 1, 1),
+(8, 'item', 1, 2);
+
+-- Бесплатное массовое восстановление доступно один раз за засуху
+ALTER TABLE drought_event ADD COLUMN IF NOT EXISTS bulk_recovered_at TIMESTAMP;
+
+-- plant_revival (журнал оживления засохших растений)
+CREATE TABLE IF NOT EXISTS plant_revival (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    user_plant_id INT NOT NULL,
+    seed_id INT NOT NULL REFERENCES seed(id),
+    method VARCHAR(20) NOT NULL CHECK (method IN ('watering_can', 'bulk')),
+    drought_event_id INT REFERENCES drought_event(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_plant_revival_user_id ON plant_revival(user_id, created_at DESC);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_plant_revival_user_id;
+DROP TABLE IF EXISTS plant_revival;
+
+ALTER TABLE drought_event DROP COLUMN IF EXISTS bulk_recovered_at;
+
+DELETE FROM level_reward WHERE reward_type = 'item';
+ALTER TABLE level_reward DROP CONSTRAINT IF EXISTS level_reward_item_check;
+ALTER TABLE level_reward DROP CONSTRAINT IF EXISTS level_reward_reward_type_check;
+ALTER TABLE level_reward ADD CONSTRAINT level_reward_reward_type_check
+    CHECK (reward_type IN ('gold', 'seed', 'bed'));
+ALTER TABLE level_reward DROP COLUMN IF EXISTS item_id;
+
+DELETE FROM good WHERE type = 'consumable';
+ALTER TABLE good DROP CONSTRAINT IF EXISTS good_type_check;
+ALTER TABLE good ADD CONSTRAINT good_type_check CHECK (type IN ('seed', 'bed'));
+
+DROP TABLE IF EXISTS user_item;
+DROP TABLE IF EXISTS item;
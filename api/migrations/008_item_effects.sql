@@ -0,0 +1,61 @@
+-- +goose Up
+
+-- Эффекты предметов: тип эффекта, сила, длительность (NULL — до сбора урожая) и максимум одновременных стаков
+ALTER TABLE item DROP CONSTRAINT IF EXISTS item_type_check;
+ALTER TABLE item ADD CONSTRAINT item_type_check CHECK (type IN ('consumable', 'fertilizer', 'tool'));
+
+ALTER TABLE item ADD COLUMN IF NOT EXISTS effect_type VARCHAR(30)
+    CHECK (effect_type IN ('revive', 'instant_growth', 'growth_boost', 'harvest_yield'));
+ALTER TABLE item ADD COLUMN IF NOT EXISTS effect_value NUMERIC(6, 2) NOT NULL DEFAULT 0;
+ALTER TABLE item ADD COLUMN IF NOT EXISTS duration_hours INT CHECK (duration_hours > 0);
+ALTER TABLE item ADD COLUMN IF NOT EXISTS max_stacks INT NOT NULL DEFAULT 1 CHECK (max_stacks >= 0);
+
+UPDATE item SET effect_type = 'revive', max_stacks = 0 WHERE code = 'watering_can';
+
+INSERT INTO item (id, code, name, description, icon, type, effect_type, effect_value, duration_hours, max_stacks) VALUES
+(2, 'fertilizer', 'Удобрение', 'Сразу добавляет растению 5 очков роста', '/img/items/fertilizer.png', 'fertilizer', 'instant_growth', 5, NULL, 0),
+(3, 'growth_stimulant', 'Стимулятор роста', '+50% к росту растения на 24 часа, до 3 одновременно', '/img/items/growth_stimulant.png', 'fertilizer', 'growth_boost', 0.5, 24, 3),
+(4, 'pruner', 'Секатор', '+25% к урожаю при сборе растения, до 2 одновременно', '/img/items/pruner.png', 'tool', 'harvest_yield', 0.25, NULL, 2)
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval('item_id_seq', (SELECT MAX(id) FROM item));
+
+-- Удобрения и инструменты продаются в магазине: id_good ссылается на item.id
+ALTER TABLE good DROP CONSTRAINT IF EXISTS good_type_check;
+ALTER TABLE good ADD CONSTRAINT good_type_check CHECK (type IN ('seed', 'bed', 'consumable', 'fertilizer', 'tool'));
+
+-- plant_effect (эффекты предметов, примененных к растениям)
+CREATE TABLE IF NOT EXISTS plant_effect (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    user_plant_id INT NOT NULL REFERENCES user_plant(id) ON DELETE CASCADE,
+    item_id INT NOT NULL REFERENCES item(id),
+    effect_type VARCHAR(30) NOT NULL,
+    value NUMERIC(6, 2) NOT NULL,
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_plant_effect_plant ON plant_effect(user_plant_id, effect_type);
+CREATE INDEX IF NOT EXISTS idx_plant_effect_user_id ON plant_effect(user_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_plant_effect_user_id;
+DROP INDEX IF EXISTS idx_plant_effect_plant;
+DROP TABLE IF EXISTS plant_effect;
+
+DELETE FROM good WHERE type IN ('fertilizer', 'tool');
+ALTER TABLE good DROP CONSTRAINT IF EXISTS good_type_check;
+ALTER TABLE good ADD CONSTRAINT good_type_check CHECK (type IN ('seed', 'bed', 'consumable'));
+
+DELETE FROM user_item WHERE item_id IN (SELECT id FROM item WHERE type IN ('fertilizer', 'tool'));
+DELETE FROM item WHERE type IN ('fertilizer', 'tool');
+
+ALTER TABLE item DROP COLUMN IF EXISTS max_stacks;
+ALTER TABLE item DROP COLUMN IF EXISTS duration_hours;
+ALTER TABLE item DROP COLUMN IF EXISTS effect_value;
+ALTER TABLE item DROP COLUMN IF EXISTS effect_type;
+
+ALTER TABLE item DROP CONSTRAINT IF EXISTS item_type_check;
+ALTER TABLE item ADD CONSTRAINT item_type_check CHECK (type IN ('consumable'));
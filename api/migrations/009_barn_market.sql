@@ -0,0 +1,35 @@
+-- +goose Up
+
+-- user_produce (амбар: собранный урожай пользователя)
+CREATE TABLE IF NOT EXISTS user_produce (
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    seed_id INT NOT NULL REFERENCES seed(id) ON DELETE CASCADE,
+    quantity INT NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, seed_id)
+);
+
+-- gold_ledger (журнал всех изменений золота пользователя)
+CREATE TABLE IF NOT EXISTS gold_ledger (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    amount INT NOT NULL,
+    balance_after BIGINT NOT NULL,
+    source VARCHAR(30) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_gold_ledger_user_id ON gold_ledger(user_id, created_at DESC);
+
+-- Начальная запись с текущим балансом, чтобы журнал сходился с user_stat.gold
+INSERT INTO gold_ledger (user_id, amount, balance_after, source, description)
+SELECT user_id, gold, gold, 'opening_balance', 'Баланс на момент запуска журнала'
+FROM user_stat
+WHERE gold <> 0;
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_gold_ledger_user_id;
+DROP TABLE IF EXISTS gold_ledger;
+DROP TABLE IF EXISTS user_produce;
@@ -0,0 +1,65 @@
+-- +goose Up
+
+-- Гибридные семена нельзя купить или получить за уровень — только вывести скрещиванием
+ALTER TABLE seed ADD COLUMN IF NOT EXISTS is_hybrid BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Код семени — стабильный ключ для миграций и справочников, не зависящий от id
+ALTER TABLE seed ADD COLUMN IF NOT EXISTS code VARCHAR(50) UNIQUE;
+
+UPDATE seed SET code = 'wheat' WHERE name = 'Пшеница' AND code IS NULL;
+UPDATE seed SET code = 'aubergine' WHERE name = 'Баклажан' AND code IS NULL;
+
+INSERT INTO seed (code, name, icon, img_plant, level_required, target_growth, rarity, modification, gold_reward, xp_reward, is_hybrid, created_at) VALUES
+('golden_wheat', 'Золотая пшеница', '/assets/seeds/golden_wheat.png', '/assets/plants/golden_wheat', 1, 6, 'rare', 0.5, 20, 250, TRUE, NOW()),
+('wheat_aubergine', 'Пшеничный баклажан', '/assets/seeds/wheat_aubergine.png', '/assets/plants/wheat_aubergine', 1, 10, 'rare', 0.75, 30, 400, TRUE, NOW()),
+('royal_aubergine', 'Королевский баклажан', '/assets/seeds/royal_aubergine.png', '/assets/plants/royal_aubergine', 1, 14, 'legendary', 1.0, 60, 800, TRUE, NOW())
+ON CONFLICT (code) DO NOTHING;
+
+-- seed_recipe (рецепты скрещивания: два зрелых растения на соседних грядках дают гибридное семя)
+-- Пара родителей хранится упорядоченной: parent_a_seed_id <= parent_b_seed_id
+CREATE TABLE IF NOT EXISTS seed_recipe (
+    id SERIAL PRIMARY KEY,
+    parent_a_seed_id INT NOT NULL REFERENCES seed(id) ON DELETE CASCADE,
+    parent_b_seed_id INT NOT NULL REFERENCES seed(id) ON DELETE CASCADE,
+    result_seed_id INT NOT NULL REFERENCES seed(id) ON DELETE CASCADE,
+    chance NUMERIC(4, 3) NOT NULL CHECK (chance > 0 AND chance <= 1),
+    created_at TIMESTAMP DEFAULT NOW(),
+    CHECK (parent_a_seed_id <= parent_b_seed_id),
+    UNIQUE (parent_a_seed_id, parent_b_seed_id)
+);
+
+INSERT INTO seed_recipe (parent_a_seed_id, parent_b_seed_id, result_seed_id, chance)
+SELECT LEAST(a.id, b.id), GREATEST(a.id, b.id), r.id, v.chance
+FROM (VALUES
+    ('wheat', 'wheat', 'golden_wheat', 0.15),
+    ('wheat', 'aubergine', 'wheat_aubergine', 0.25),
+    ('aubergine', 'aubergine', 'royal_aubergine', 0.10)
+) AS v(parent_a, parent_b, result, chance)
+JOIN seed a ON a.code = v.parent_a
+JOIN seed b ON b.code = v.parent_b
+JOIN seed r ON r.code = v.result
+ON CONFLICT (parent_a_seed_id, parent_b_seed_id) DO NOTHING;
+
+-- user_hybrid (гибриды, открытые пользователем)
+CREATE TABLE IF NOT EXISTS user_hybrid (
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    recipe_id INT NOT NULL REFERENCES seed_recipe(id) ON DELETE CASCADE,
+    times_bred INT NOT NULL DEFAULT 1 CHECK (times_bred >= 1),
+    discovered_at TIMESTAMP DEFAULT NOW(),
+    last_bred_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, recipe_id)
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS user_hybrid;
+DROP TABLE IF EXISTS seed_recipe;
+
+DELETE FROM user_plant WHERE seed_id IN (SELECT id FROM seed WHERE code IN ('golden_wheat', 'wheat_aubergine', 'royal_aubergine'));
+DELETE FROM plant_history WHERE seed_id IN (SELECT id FROM seed WHERE code IN ('golden_wheat', 'wheat_aubergine', 'royal_aubergine'));
+DELETE FROM plant_revival WHERE seed_id IN (SELECT id FROM seed WHERE code IN ('golden_wheat', 'wheat_aubergine', 'royal_aubergine'));
+DELETE FROM user_seed WHERE seed_id IN (SELECT id FROM seed WHERE code IN ('golden_wheat', 'wheat_aubergine', 'royal_aubergine'));
+DELETE FROM seed WHERE code IN ('golden_wheat', 'wheat_aubergine', 'royal_aubergine');
+
+ALTER TABLE seed DROP COLUMN IF EXISTS code;
+ALTER TABLE seed DROP COLUMN IF EXISTS is_hybrid;
@@ -0,0 +1,36 @@
+-- +goose Up
+
+-- Координаты грядок на поле: x — столбец, y — ряд (с нуля).
+-- Существующие грядки раскладываются построчно по 3 в ряд, как они отображались раньше.
+ALTER TABLE bed ADD COLUMN IF NOT EXISTS x INT;
+ALTER TABLE bed ADD COLUMN IF NOT EXISTS y INT;
+
+UPDATE bed SET x = (cell_number - 1) % 3, y = (cell_number - 1) / 3 WHERE x IS NULL OR y IS NULL;
+
+ALTER TABLE bed ALTER COLUMN x SET NOT NULL;
+ALTER TABLE bed ALTER COLUMN y SET NOT NULL;
+ALTER TABLE bed ADD CONSTRAINT bed_coordinates_check CHECK (x >= 0 AND y >= 0);
+ALTER TABLE bed ADD CONSTRAINT bed_user_coordinates_key UNIQUE (user_id, x, y);
+
+-- farm_expansion (покупки расширения поля: новый ряд или столбец грядок)
+CREATE TABLE IF NOT EXISTS farm_expansion (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    direction VARCHAR(10) NOT NULL CHECK (direction IN ('row', 'column')),
+    cost INT NOT NULL CHECK (cost >= 0),
+    width INT NOT NULL,
+    height INT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_farm_expansion_user_id ON farm_expansion(user_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_farm_expansion_user_id;
+DROP TABLE IF EXISTS farm_expansion;
+
+ALTER TABLE bed DROP CONSTRAINT IF EXISTS bed_user_coordinates_key;
+ALTER TABLE bed DROP CONSTRAINT IF EXISTS bed_coordinates_check;
+ALTER TABLE bed DROP COLUMN IF EXISTS y;
+ALTER TABLE bed DROP COLUMN IF EXISTS x;
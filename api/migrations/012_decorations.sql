@@ -0,0 +1,43 @@
+-- +goose Up
+
+-- decoration (каталог декораций, которые можно поставить на поле)
+-- bonus_type/bonus_value — пассивный бонус грядкам, соседним с декорацией (NULL — без бонуса)
+CREATE TABLE IF NOT EXISTS decoration (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    icon VARCHAR(255),
+    cost INT NOT NULL CHECK (cost >= 0),
+    bonus_type VARCHAR(30) CHECK (bonus_type IN ('growth_boost', 'harvest_yield')),
+    bonus_value NUMERIC(6, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+INSERT INTO decoration (id, code, name, description, icon, cost, bonus_type, bonus_value) VALUES
+(1, 'fence', 'Забор', 'Аккуратный деревянный забор', '/img/decorations/fence.png', 10, NULL, 0),
+(2, 'path', 'Дорожка', 'Каменная дорожка между грядками', '/img/decorations/path.png', 5, NULL, 0),
+(3, 'scarecrow', 'Пугало', '+5% к урожаю соседних грядок', '/img/decorations/scarecrow.png', 40, 'harvest_yield', 0.05),
+(4, 'well', 'Колодец', '+5% к росту растений на соседних грядках', '/img/decorations/well.png', 60, 'growth_boost', 0.05)
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval('decoration_id_seq', (SELECT MAX(id) FROM decoration));
+
+-- user_decoration (декорации, поставленные пользователем на клетки поля)
+CREATE TABLE IF NOT EXISTS user_decoration (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    decoration_id INT NOT NULL REFERENCES decoration(id) ON DELETE CASCADE,
+    x INT NOT NULL CHECK (x >= 0),
+    y INT NOT NULL CHECK (y >= 0),
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (user_id, x, y)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_decoration_user_id ON user_decoration(user_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_user_decoration_user_id;
+DROP TABLE IF EXISTS user_decoration;
+DROP TABLE IF EXISTS decoration;
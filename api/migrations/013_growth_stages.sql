@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- Стадии роста семени: stage_thresholds[i] — процент роста, с которого начинается стадия i + 1.
+-- Первая стадия начинается с 0, последняя (100) — растение готово к сбору.
+-- Картинка стадии N лежит в папке img_plant: state{N}.png
+ALTER TABLE seed ADD COLUMN IF NOT EXISTS stage_thresholds INT[] NOT NULL DEFAULT '{0,34,67,100}';
+
+ALTER TABLE seed ADD CONSTRAINT seed_stage_thresholds_check
+    CHECK (cardinality(stage_thresholds) >= 1 AND stage_thresholds[1] = 0);
+
+-- +goose Down
+
+ALTER TABLE seed DROP CONSTRAINT IF EXISTS seed_stage_thresholds_check;
+ALTER TABLE seed DROP COLUMN IF EXISTS stage_thresholds;
@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- Роль пользователя: admin может изменять каталог и экономику через /admin
+ALTER TABLE user_info ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user';
+
+ALTER TABLE user_info ADD CONSTRAINT user_info_role_check
+    CHECK (role IN ('user', 'admin'));
+
+-- +goose Down
+
+ALTER TABLE user_info DROP CONSTRAINT IF EXISTS user_info_role_check;
+ALTER TABLE user_info DROP COLUMN IF EXISTS role;
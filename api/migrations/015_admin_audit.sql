@@ -0,0 +1,20 @@
+-- +goose Up
+
+-- admin_audit_log (журнал ручных изменений прогресса пользователей администраторами)
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id SERIAL PRIMARY KEY,
+    admin_id BIGINT REFERENCES user_info(id) ON DELETE SET NULL,
+    target_user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    action VARCHAR(30) NOT NULL,
+    value_before BIGINT NOT NULL,
+    value_after BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_target ON admin_audit_log(target_user_id, created_at DESC);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_admin_audit_log_target;
+DROP TABLE IF EXISTS admin_audit_log;
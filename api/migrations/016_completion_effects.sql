@@ -0,0 +1,31 @@
+-- +goose Up
+
+-- Отмененное выполнение больше не учитывается и не может быть отменено повторно
+ALTER TABLE progress_log ADD COLUMN IF NOT EXISTS undone_at TIMESTAMP;
+
+-- completion_effect (побочные эффекты выполнения задачи или привычки, которые нужно откатить при отмене)
+CREATE TABLE IF NOT EXISTS completion_effect (
+    progress_log_id INT PRIMARY KEY REFERENCES progress_log(id) ON DELETE CASCADE,
+    streak_incremented BOOLEAN NOT NULL DEFAULT false,
+    longest_streak_before INT NOT NULL DEFAULT 0,
+    streak_reward_item_id INT REFERENCES item(id) ON DELETE SET NULL,
+    streak_reward_amount INT NOT NULL DEFAULT 0,
+    drought_days_before INT NOT NULL DEFAULT 0,
+    drought_severity_before INT NOT NULL DEFAULT 0,
+    drought_days_after INT NOT NULL DEFAULT 0,
+    drought_severity_after INT NOT NULL DEFAULT 0
+);
+
+-- completion_plant_growth (на сколько выросло каждое растение при выполнении)
+CREATE TABLE IF NOT EXISTS completion_plant_growth (
+    progress_log_id INT NOT NULL REFERENCES progress_log(id) ON DELETE CASCADE,
+    user_plant_id INT NOT NULL REFERENCES user_plant(id) ON DELETE CASCADE,
+    growth INT NOT NULL,
+    PRIMARY KEY (progress_log_id, user_plant_id)
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS completion_plant_growth;
+DROP TABLE IF EXISTS completion_effect;
+ALTER TABLE progress_log DROP COLUMN IF EXISTS undone_at;
@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Базовые очки роста выполнения (после дневных ограничений) для подсчета дневного лимита роста
+ALTER TABLE completion_effect ADD COLUMN IF NOT EXISTS growth_points INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+
+ALTER TABLE completion_effect DROP COLUMN IF EXISTS growth_points;
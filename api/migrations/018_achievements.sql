@@ -0,0 +1,61 @@
+-- +goose Up
+
+-- achievement (определения достижений)
+-- counter: достижение открывается, когда счетчик пользователя (counter) достигает threshold;
+-- event: достижение открывается при первом событии event (например, первый сбор легендарного растения)
+CREATE TABLE IF NOT EXISTS achievement (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    icon VARCHAR(255),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('counter', 'event')),
+    counter VARCHAR(30) CHECK (counter IN ('tasks_completed', 'plants_harvested', 'longest_streak')),
+    event VARCHAR(50),
+    threshold INT NOT NULL DEFAULT 1 CHECK (threshold > 0),
+    reward_type VARCHAR(20) CHECK (reward_type IN ('gold', 'seed', 'item')),
+    reward_seed_id INT REFERENCES seed(id) ON DELETE SET NULL,
+    reward_item_id INT REFERENCES item(id) ON DELETE SET NULL,
+    reward_amount INT NOT NULL DEFAULT 0 CHECK (reward_amount >= 0),
+    sort_order INT NOT NULL DEFAULT 0,
+    CHECK ((kind = 'counter') = (counter IS NOT NULL)),
+    CHECK ((kind = 'event') = (event IS NOT NULL)),
+    CHECK (reward_type <> 'seed' OR reward_seed_id IS NOT NULL),
+    CHECK (reward_type <> 'item' OR reward_item_id IS NOT NULL)
+);
+
+-- user_achievement (открытые достижения пользователя, гарантирует однократную выдачу награды)
+CREATE TABLE IF NOT EXISTS user_achievement (
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    achievement_id INT NOT NULL REFERENCES achievement(id) ON DELETE CASCADE,
+    unlocked_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (user_id, achievement_id)
+);
+
+INSERT INTO achievement (code, name, description, icon, kind, counter, event, threshold, reward_type, reward_seed_id, reward_item_id, reward_amount, sort_order) VALUES
+('first_task', 'Первый шаг', 'Выполнить первую задачу или привычку', '/img/achievements/first_task.png', 'counter', 'tasks_completed', NULL, 1, 'gold', NULL, NULL, 10, 10),
+('tasks_50', 'Трудяга', 'Выполнить 50 задач или привычек', '/img/achievements/tasks_50.png', 'counter', 'tasks_completed', NULL, 50, 'gold', NULL, NULL, 100, 20),
+('tasks_500', 'Мастер дел', 'Выполнить 500 задач или привычек', '/img/achievements/tasks_500.png', 'counter', 'tasks_completed', NULL, 500, 'gold', NULL, NULL, 500, 30),
+('first_harvest', 'Первый урожай', 'Собрать первое растение', '/img/achievements/first_harvest.png', 'counter', 'plants_harvested', NULL, 1, 'seed', (SELECT id FROM seed WHERE code = 'wheat'), NULL, 2, 40),
+('harvest_100', 'Фермер', 'Собрать 100 растений', '/img/achievements/harvest_100.png', 'counter', 'plants_harvested', NULL, 100, 'item', NULL, 4, 1, 50),
+('streak_7', 'Неделя без пропусков', 'Выполнять задачи 7 дней подряд', '/img/achievements/streak_7.png', 'counter', 'longest_streak', NULL, 7, 'gold', NULL, NULL, 30, 60),
+('streak_30', 'Железная воля', 'Выполнять задачи 30 дней подряд', '/img/achievements/streak_30.png', 'counter', 'longest_streak', NULL, 30, 'item', NULL, 3, 2, 70),
+('first_legendary_harvest', 'Легенда грядок', 'Собрать легендарное растение', '/img/achievements/first_legendary_harvest.png', 'event', NULL, 'legendary_harvest', 1, 'gold', NULL, NULL, 200, 80),
+('first_hybrid', 'Селекционер', 'Вывести первое гибридное семя', '/img/achievements/first_hybrid.png', 'event', NULL, 'hybrid_bred', 1, 'gold', NULL, NULL, 50, 90)
+ON CONFLICT (code) DO NOTHING;
+
+-- Выполнения задач и привычек теперь считаются в total_tasks_completed; учитываем уже сделанные
+UPDATE user_stat us
+SET total_tasks_completed = sub.completed
+FROM (
+    SELECT user_id, COUNT(*) AS completed
+    FROM progress_log
+    WHERE (task_id IS NOT NULL OR habit_id IS NOT NULL) AND xp_earned > 0 AND undone_at IS NULL
+    GROUP BY user_id
+) sub
+WHERE us.user_id = sub.user_id;
+
+-- +goose Down
+
+DROP TABLE IF EXISTS user_achievement;
+DROP TABLE IF EXISTS achievement;
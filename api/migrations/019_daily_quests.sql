@@ -0,0 +1,62 @@
+-- +goose Up
+
+-- quest_template (шаблоны ежедневных заданий)
+-- complete_tasks / complete_habits: выполнить target задач/привычек (difficulty — только указанной сложности);
+-- complete_tagged_habits: выполнить все привычки пользователя с тегом tag_name (target считается при выдаче);
+-- harvest_plants: собрать target растений (seed_id — только указанного семени)
+CREATE TABLE IF NOT EXISTS quest_template (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    title VARCHAR(255) NOT NULL,
+    kind VARCHAR(30) NOT NULL CHECK (kind IN ('complete_tasks', 'complete_habits', 'complete_tagged_habits', 'harvest_plants')),
+    target INT NOT NULL DEFAULT 1 CHECK (target > 0),
+    difficulty VARCHAR(20) CHECK (difficulty IN ('trifle', 'easy', 'normal', 'hard')),
+    seed_id INT REFERENCES seed(id) ON DELETE CASCADE,
+    tag_name VARCHAR(50),
+    min_level INT NOT NULL DEFAULT 1 CHECK (min_level > 0),
+    weight INT NOT NULL DEFAULT 1 CHECK (weight > 0),
+    reward_gold INT NOT NULL DEFAULT 0 CHECK (reward_gold >= 0),
+    reward_xp INT NOT NULL DEFAULT 0 CHECK (reward_xp >= 0),
+    reward_item_id INT REFERENCES item(id) ON DELETE SET NULL,
+    reward_item_amount INT NOT NULL DEFAULT 0 CHECK (reward_item_amount >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    CHECK ((kind = 'complete_tagged_habits') = (tag_name IS NOT NULL)),
+    CHECK (seed_id IS NULL OR kind = 'harvest_plants'),
+    CHECK (difficulty IS NULL OR kind IN ('complete_tasks', 'complete_habits'))
+);
+
+-- user_quest (задания, выданные пользователю на день; условия копируются из шаблона при выдаче)
+CREATE TABLE IF NOT EXISTS user_quest (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    template_id INT NOT NULL REFERENCES quest_template(id) ON DELETE CASCADE,
+    quest_date DATE NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    kind VARCHAR(30) NOT NULL,
+    difficulty VARCHAR(20),
+    seed_id INT,
+    tag_name VARCHAR(50),
+    target INT NOT NULL CHECK (target > 0),
+    progress INT NOT NULL DEFAULT 0 CHECK (progress >= 0 AND progress <= target),
+    completed_at TIMESTAMP,
+    claimed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (user_id, quest_date, template_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_quest_user_date ON user_quest(user_id, quest_date);
+
+INSERT INTO quest_template (code, title, kind, target, difficulty, seed_id, tag_name, min_level, weight, reward_gold, reward_xp, reward_item_id, reward_item_amount) VALUES
+('complete_tasks_3', 'Выполните 3 задачи', 'complete_tasks', 3, NULL, NULL, NULL, 1, 3, 15, 20, NULL, 0),
+('complete_easy_tasks_5', 'Выполните 5 легких задач', 'complete_tasks', 5, 'easy', NULL, NULL, 1, 2, 15, 15, NULL, 0),
+('complete_hard_tasks_2', 'Выполните 2 сложные задачи', 'complete_tasks', 2, 'hard', NULL, NULL, 3, 2, 30, 40, NULL, 0),
+('complete_habits_3', 'Выполните 3 привычки', 'complete_habits', 3, NULL, NULL, NULL, 1, 3, 15, 20, NULL, 0),
+('complete_health_habits', 'Выполните все привычки с тегом «Здоровье»', 'complete_tagged_habits', 1, NULL, NULL, 'Здоровье', 2, 2, 25, 30, NULL, 0),
+('harvest_plants_2', 'Соберите 2 растения', 'harvest_plants', 2, NULL, NULL, NULL, 1, 2, 10, 10, 2, 1),
+('harvest_aubergine', 'Соберите баклажан', 'harvest_plants', 1, NULL, (SELECT id FROM seed WHERE code = 'aubergine'), NULL, 2, 1, 20, 25, NULL, 0)
+ON CONFLICT (code) DO NOTHING;
+
+-- +goose Down
+
+DROP TABLE IF EXISTS user_quest;
+DROP TABLE IF EXISTS quest_template;
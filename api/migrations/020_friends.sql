@@ -0,0 +1,29 @@
+-- +goose Up
+
+-- Что друзья видят на ферме пользователя: full — грядки, растения и уровень;
+-- level_only — только уровень и статистику; hidden — ничего
+ALTER TABLE user_info ADD COLUMN IF NOT EXISTS farm_privacy VARCHAR(20) NOT NULL DEFAULT 'full';
+
+ALTER TABLE user_info ADD CONSTRAINT user_info_farm_privacy_check
+    CHECK (farm_privacy IN ('full', 'level_only', 'hidden'));
+
+-- friendship (заявки в друзья и дружба; одна запись на пару пользователей)
+CREATE TABLE IF NOT EXISTS friendship (
+    id SERIAL PRIMARY KEY,
+    requester_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    addressee_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted')),
+    created_at TIMESTAMP DEFAULT NOW(),
+    accepted_at TIMESTAMP,
+    CHECK (requester_id <> addressee_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_friendship_pair
+    ON friendship (LEAST(requester_id, addressee_id), GREATEST(requester_id, addressee_id));
+CREATE INDEX IF NOT EXISTS idx_friendship_addressee ON friendship(addressee_id, status);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS friendship;
+ALTER TABLE user_info DROP CONSTRAINT IF EXISTS user_info_farm_privacy_check;
+ALTER TABLE user_info DROP COLUMN IF EXISTS farm_privacy;
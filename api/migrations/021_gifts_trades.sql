@@ -0,0 +1,50 @@
+-- +goose Up
+
+-- gift (подарки семян и урожая другу; отправленное списывается сразу и возвращается при отклонении)
+CREATE TABLE IF NOT EXISTS gift (
+    id SERIAL PRIMARY KEY,
+    sender_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    recipient_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('seed', 'produce')),
+    seed_id INT NOT NULL REFERENCES seed(id) ON DELETE CASCADE,
+    amount INT NOT NULL CHECK (amount > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    created_at TIMESTAMP DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    CHECK (sender_id <> recipient_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_gift_sender ON gift(sender_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_gift_recipient ON gift(recipient_id, status);
+
+-- trade_offer (предложения обмена; обмен выполняется атомарно при принятии)
+CREATE TABLE IF NOT EXISTS trade_offer (
+    id SERIAL PRIMARY KEY,
+    proposer_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    recipient_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    created_at TIMESTAMP DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    CHECK (proposer_id <> recipient_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_trade_offer_proposer ON trade_offer(proposer_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_trade_offer_recipient ON trade_offer(recipient_id, status);
+
+-- trade_offer_line (позиции обмена: offer — отдает автор предложения, request — отдает получатель)
+CREATE TABLE IF NOT EXISTS trade_offer_line (
+    id SERIAL PRIMARY KEY,
+    trade_id INT NOT NULL REFERENCES trade_offer(id) ON DELETE CASCADE,
+    side VARCHAR(20) NOT NULL CHECK (side IN ('offer', 'request')),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('seed', 'produce')),
+    seed_id INT NOT NULL REFERENCES seed(id) ON DELETE CASCADE,
+    amount INT NOT NULL CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_trade_offer_line_trade ON trade_offer_line(trade_id);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS trade_offer_line;
+DROP TABLE IF EXISTS trade_offer;
+DROP TABLE IF EXISTS gift;
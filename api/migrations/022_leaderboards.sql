@@ -0,0 +1,25 @@
+-- +goose Up
+
+-- leaderboard_season (недельные сезоны рейтингов; сезон начинается в понедельник)
+CREATE TABLE IF NOT EXISTS leaderboard_season (
+    season_start DATE PRIMARY KEY,
+    computed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finalized_at TIMESTAMP -- Итоговый пересчет после окончания сезона
+);
+
+-- leaderboard_entry (снимки рейтингов, пересчитываемые планировщиком)
+CREATE TABLE IF NOT EXISTS leaderboard_entry (
+    season_start DATE NOT NULL REFERENCES leaderboard_season(season_start) ON DELETE CASCADE,
+    metric VARCHAR(30) NOT NULL CHECK (metric IN ('weekly_xp', 'longest_streak', 'total_harvests')),
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    value BIGINT NOT NULL,
+    rank INT NOT NULL,
+    PRIMARY KEY (season_start, metric, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_leaderboard_entry_rank ON leaderboard_entry(season_start, metric, rank);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS leaderboard_entry;
+DROP TABLE IF EXISTS leaderboard_season;
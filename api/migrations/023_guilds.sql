@@ -0,0 +1,80 @@
+-- +goose Up
+
+-- guild (гильдии: небольшие группы пользователей с общей недельной целью)
+CREATE TABLE IF NOT EXISTS guild (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    owner_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_guild_name ON guild(LOWER(name));
+
+-- guild_member (участники гильдии; пользователь состоит не более чем в одной гильдии)
+CREATE TABLE IF NOT EXISTS guild_member (
+    user_id BIGINT PRIMARY KEY REFERENCES user_info(id) ON DELETE CASCADE,
+    guild_id INT NOT NULL REFERENCES guild(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'member')),
+    joined_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_guild_member_guild ON guild_member(guild_id);
+
+-- guild_goal_template (шаблоны недельных целей: сдать target урожая семени seed_id;
+-- награда выдается каждому участнику, внесшему вклад)
+CREATE TABLE IF NOT EXISTS guild_goal_template (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    title VARCHAR(255) NOT NULL,
+    seed_id INT NOT NULL REFERENCES seed(id) ON DELETE CASCADE,
+    target INT NOT NULL CHECK (target > 0),
+    reward_gold INT NOT NULL DEFAULT 0 CHECK (reward_gold >= 0),
+    reward_xp INT NOT NULL DEFAULT 0 CHECK (reward_xp >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+-- guild_goal (цель гильдии на неделю; условия копируются из шаблона при выдаче)
+CREATE TABLE IF NOT EXISTS guild_goal (
+    id SERIAL PRIMARY KEY,
+    guild_id INT NOT NULL REFERENCES guild(id) ON DELETE CASCADE,
+    template_id INT NOT NULL REFERENCES guild_goal_template(id) ON DELETE CASCADE,
+    week_start DATE NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    seed_id INT NOT NULL REFERENCES seed(id) ON DELETE CASCADE,
+    target INT NOT NULL CHECK (target > 0),
+    progress INT NOT NULL DEFAULT 0 CHECK (progress >= 0 AND progress <= target),
+    reward_gold INT NOT NULL DEFAULT 0,
+    reward_xp INT NOT NULL DEFAULT 0,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (guild_id, week_start)
+);
+
+-- guild_contribution (журнал вкладов в цели гильдий)
+CREATE TABLE IF NOT EXISTS guild_contribution (
+    id SERIAL PRIMARY KEY,
+    goal_id INT NOT NULL REFERENCES guild_goal(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES user_info(id) ON DELETE CASCADE,
+    amount INT NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_guild_contribution_goal ON guild_contribution(goal_id, user_id);
+
+INSERT INTO guild_goal_template (code, title, seed_id, target, reward_gold, reward_xp)
+SELECT v.code, v.title, s.id, v.target, v.reward_gold, v.reward_xp
+FROM (VALUES
+    ('deliver_wheat_200', 'Сдайте 200 пшеницы', 'wheat', 200, 60, 80),
+    ('deliver_aubergine_80', 'Сдайте 80 баклажанов', 'aubergine', 80, 80, 100),
+    ('deliver_golden_wheat_30', 'Сдайте 30 золотой пшеницы', 'golden_wheat', 30, 120, 150)
+) AS v(code, title, seed_code, target, reward_gold, reward_xp)
+JOIN seed s ON s.code = v.seed_code
+ON CONFLICT (code) DO NOTHING;
+
+-- +goose Down
+
+DROP TABLE IF EXISTS guild_contribution;
+DROP TABLE IF EXISTS guild_goal;
+DROP TABLE IF EXISTS guild_goal_template;
+DROP TABLE IF EXISTS guild_member;
+DROP TABLE IF EXISTS guild;
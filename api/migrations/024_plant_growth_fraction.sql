@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- Дробная часть очков роста растения: множитель роста семени дает дробные очки роста,
+-- которые накапливаются между выполнениями, а не теряются при округлении
+ALTER TABLE user_plant ADD COLUMN IF NOT EXISTS growth_fraction DOUBLE PRECISION NOT NULL DEFAULT 0
+    CHECK (growth_fraction >= 0 AND growth_fraction < 1);
+
+-- Точные (дробные) очки роста выполнения, чтобы отмена возвращала именно их
+ALTER TABLE completion_plant_growth ADD COLUMN IF NOT EXISTS growth_exact DOUBLE PRECISION;
+
+-- +goose Down
+
+ALTER TABLE completion_plant_growth DROP COLUMN IF EXISTS growth_exact;
+ALTER TABLE user_plant DROP COLUMN IF EXISTS growth_fraction;
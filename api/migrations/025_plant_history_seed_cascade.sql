@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- История растений удаляется вместе с семенем, как и растения на грядках (user_plant)
+ALTER TABLE plant_history DROP CONSTRAINT IF EXISTS plant_history_seed_id_fkey;
+ALTER TABLE plant_history ADD CONSTRAINT plant_history_seed_id_fkey
+    FOREIGN KEY (seed_id) REFERENCES seed(id) ON DELETE CASCADE;
+
+-- +goose Down
+
+ALTER TABLE plant_history DROP CONSTRAINT IF EXISTS plant_history_seed_id_fkey;
+ALTER TABLE plant_history ADD CONSTRAINT plant_history_seed_id_fkey
+    FOREIGN KEY (seed_id) REFERENCES seed(id);
@@ -0,0 +1,20 @@
+-- +goose Up
+
+-- Запись роста за выполнение остается и после того, как растение убрали с грядки:
+-- по ней отмена выполнения узнает, что выращенное им растение уже собрано
+ALTER TABLE completion_plant_growth DROP CONSTRAINT IF EXISTS completion_plant_growth_user_plant_id_fkey;
+
+-- Растение, которое было на грядке до переноса в историю (NULL — записи до этой миграции)
+ALTER TABLE plant_history ADD COLUMN IF NOT EXISTS user_plant_id INT;
+
+CREATE INDEX IF NOT EXISTS idx_plant_history_user_plant_id ON plant_history(user_plant_id);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_plant_history_user_plant_id;
+ALTER TABLE plant_history DROP COLUMN IF EXISTS user_plant_id;
+
+DELETE FROM completion_plant_growth cpg
+WHERE NOT EXISTS (SELECT 1 FROM user_plant up WHERE up.id = cpg.user_plant_id);
+ALTER TABLE completion_plant_growth ADD CONSTRAINT completion_plant_growth_user_plant_id_fkey
+    FOREIGN KEY (user_plant_id) REFERENCES user_plant(id) ON DELETE CASCADE;
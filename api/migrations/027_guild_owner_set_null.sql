@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- Удаление аккаунта владельца не удаляет гильдию вместе с остальными участниками
+ALTER TABLE guild ALTER COLUMN owner_id DROP NOT NULL;
+ALTER TABLE guild DROP CONSTRAINT IF EXISTS guild_owner_id_fkey;
+ALTER TABLE guild ADD CONSTRAINT guild_owner_id_fkey
+    FOREIGN KEY (owner_id) REFERENCES user_info(id) ON DELETE SET NULL;
+
+-- +goose Down
+
+UPDATE guild g SET owner_id = (
+    SELECT m.user_id FROM guild_member m WHERE m.guild_id = g.id ORDER BY m.joined_at, m.user_id LIMIT 1
+)
+WHERE g.owner_id IS NULL;
+DELETE FROM guild WHERE owner_id IS NULL;
+ALTER TABLE guild DROP CONSTRAINT IF EXISTS guild_owner_id_fkey;
+ALTER TABLE guild ADD CONSTRAINT guild_owner_id_fkey
+    FOREIGN KEY (owner_id) REFERENCES user_info(id) ON DELETE CASCADE;
+ALTER TABLE guild ALTER COLUMN owner_id SET NOT NULL;
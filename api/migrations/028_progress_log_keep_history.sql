@@ -0,0 +1,36 @@
+-- +goose Up
+
+-- История выполнений остается после удаления задачи или привычки: дневные ограничения наград,
+-- стрик и рейтинги продолжают учитывать сделанные выполнения
+ALTER TABLE progress_log DROP CONSTRAINT IF EXISTS progress_log_task_id_fkey;
+ALTER TABLE progress_log ADD CONSTRAINT progress_log_task_id_fkey
+    FOREIGN KEY (task_id) REFERENCES task(id) ON DELETE SET NULL;
+ALTER TABLE progress_log DROP CONSTRAINT IF EXISTS progress_log_habit_id_fkey;
+ALTER TABLE progress_log ADD CONSTRAINT progress_log_habit_id_fkey
+    FOREIGN KEY (habit_id) REFERENCES habit(id) ON DELETE SET NULL;
+
+ALTER TABLE progress_log DROP CONSTRAINT IF EXISTS progress_log_source_check;
+ALTER TABLE progress_log ADD CONSTRAINT progress_log_source_check CHECK (
+    (source = 'task' AND habit_id IS NULL) OR
+    (source = 'habit' AND task_id IS NULL) OR
+    (source NOT IN ('task', 'habit') AND task_id IS NULL AND habit_id IS NULL)
+);
+
+-- +goose Down
+
+DELETE FROM progress_log WHERE source = 'task' AND task_id IS NULL;
+DELETE FROM progress_log WHERE source = 'habit' AND habit_id IS NULL;
+
+ALTER TABLE progress_log DROP CONSTRAINT IF EXISTS progress_log_source_check;
+ALTER TABLE progress_log ADD CONSTRAINT progress_log_source_check CHECK (
+    (source = 'task' AND task_id IS NOT NULL AND habit_id IS NULL) OR
+    (source = 'habit' AND habit_id IS NOT NULL AND task_id IS NULL) OR
+    (source NOT IN ('task', 'habit') AND task_id IS NULL AND habit_id IS NULL)
+);
+
+ALTER TABLE progress_log DROP CONSTRAINT IF EXISTS progress_log_task_id_fkey;
+ALTER TABLE progress_log ADD CONSTRAINT progress_log_task_id_fkey
+    FOREIGN KEY (task_id) REFERENCES task(id);
+ALTER TABLE progress_log DROP CONSTRAINT IF EXISTS progress_log_habit_id_fkey;
+ALTER TABLE progress_log ADD CONSTRAINT progress_log_habit_id_fkey
+    FOREIGN KEY (habit_id) REFERENCES habit(id);
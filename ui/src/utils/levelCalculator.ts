@@ -19,8 +19,12 @@ export class LevelCalculator {
     let threshold = this.START_THRESHOLD;
     let remaining = Number(exp);
 
-    while (remaining >= threshold && level < 9999) {
-      remaining -= threshold;
+    while (level < 9999) {
+      // Порог каждого уровня округляется так же, как в experienceForLevel и на сервере
+      const step = Math.round(threshold);
+      if (remaining < step) break;
+
+      remaining -= step;
       level++;
 
       const bonus = Math.min((level - 1) / 10 * 0.01, this.MAX_BONUS);